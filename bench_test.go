@@ -1,6 +1,7 @@
 package mapset
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 )
@@ -37,6 +38,12 @@ func BenchmarkAddUnsafe(b *testing.B) {
 	benchAdd(b, NewThreadUnsafeSet())
 }
 
+func BenchmarkAddSafeStats(b *testing.B) {
+	s := NewSet()
+	EnableStats(s)
+	benchAdd(b, s)
+}
+
 func benchRemove(b *testing.B, s Set) {
 	nums := nrand(b.N)
 	for _, v := range nums {
@@ -72,6 +79,7 @@ func BenchmarkCardinalityUnsafe(b *testing.B) {
 }
 
 func benchClear(b *testing.B, s Set) {
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Clear()
@@ -92,6 +100,7 @@ func benchClone(b *testing.B, n int, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Clone()
@@ -130,6 +139,7 @@ func benchContains(b *testing.B, n int, s Set) {
 
 	nums[n-1] = -1 // Definitely not in s
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Contains(nums...)
@@ -167,6 +177,7 @@ func benchEqual(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Equal(t)
@@ -206,6 +217,7 @@ func benchDifference(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Difference(t)
@@ -219,6 +231,7 @@ func benchIsSubset(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.IsSubset(t)
@@ -256,6 +269,7 @@ func benchIsSuperset(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.IsSuperset(t)
@@ -293,6 +307,7 @@ func benchIsProperSubset(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.IsProperSubset(t)
@@ -330,6 +345,7 @@ func benchIsProperSuperset(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.IsProperSuperset(t)
@@ -393,6 +409,7 @@ func benchIntersect(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Intersect(t)
@@ -432,6 +449,7 @@ func benchSymmetricDifference(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.SymmetricDifference(t)
@@ -471,6 +489,7 @@ func benchUnion(b *testing.B, n int, s, t Set) {
 		t.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Union(t)
@@ -507,6 +526,7 @@ func benchEach(b *testing.B, n int, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.Each(func(elem interface{}) bool {
@@ -545,6 +565,7 @@ func benchIter(b *testing.B, n int, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		c := s.Iter()
@@ -584,6 +605,7 @@ func benchIterator(b *testing.B, n int, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		c := s.Iterator().C
@@ -623,6 +645,7 @@ func benchString(b *testing.B, n int, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_ = s.String()
@@ -659,6 +682,7 @@ func benchToSlice(b *testing.B, s Set) {
 		s.Add(v)
 	}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		s.ToSlice()
@@ -672,3 +696,142 @@ func BenchmarkToSliceSafe(b *testing.B) {
 func BenchmarkToSliceUnsafe(b *testing.B) {
 	benchToSlice(b, NewThreadUnsafeSet())
 }
+
+func multiSets(n, size int) []Set {
+	sets := make([]Set, n)
+	for i := range sets {
+		sets[i] = NewSetFromSlice(toInterfaces(nrand(size)))
+	}
+	return sets
+}
+
+func BenchmarkUnionChained50(b *testing.B) {
+	sets := multiSets(50, 100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u := sets[0]
+		for _, s := range sets[1:] {
+			u = u.Union(s)
+		}
+	}
+}
+
+func BenchmarkUnionAll50(b *testing.B) {
+	sets := multiSets(50, 100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UnionAll(sets...)
+	}
+}
+
+// The parallel benchmarks compare sequential and parallel algebra over
+// growing inputs, with parallelThreshold disabled, to find where the
+// crossover sits.
+func benchParallel(b *testing.B, op func(a, b Set) Set) {
+	defer func(threshold int) { parallelThreshold = threshold }(parallelThreshold)
+	parallelThreshold = 0
+
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 17, 1 << 20} {
+		x := NewThreadUnsafeSet()
+		y := NewThreadUnsafeSet()
+		for i := 0; i < size; i++ {
+			x.Add(i)
+			if i%2 == 0 {
+				y.Add(i)
+			}
+		}
+
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				op(x, y)
+			}
+		})
+	}
+}
+
+func BenchmarkIntersectSequential(b *testing.B) {
+	benchParallel(b, func(x, y Set) Set { return x.Intersect(y) })
+}
+
+func BenchmarkParallelIntersect(b *testing.B) {
+	benchParallel(b, func(x, y Set) Set { return ParallelIntersect(x, y, 0) })
+}
+
+func BenchmarkDifferenceSequential(b *testing.B) {
+	benchParallel(b, func(x, y Set) Set { return x.Difference(y) })
+}
+
+func BenchmarkParallelDifference(b *testing.B) {
+	benchParallel(b, func(x, y Set) Set { return ParallelDifference(x, y, 0) })
+}
+
+func benchClearRefill(b *testing.B, s Set) {
+	nums := toInterfaces(nrand(1000))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Clear()
+		for _, v := range nums {
+			s.Add(v)
+		}
+	}
+}
+
+func BenchmarkClearRefillSafe(b *testing.B) {
+	benchClearRefill(b, NewSet())
+}
+
+func BenchmarkClearRefillUnsafe(b *testing.B) {
+	benchClearRefill(b, NewThreadUnsafeSet())
+}
+
+func BenchmarkNewSetFromSlice1000(b *testing.B) {
+	nums := toInterfaces(nrand(1000))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSetFromSlice(nums)
+	}
+}
+
+func benchInto(b *testing.B, n int, dst, s, t Set, into func(dst, a, b Set)) {
+	nums := nrand(n)
+	for _, v := range nums {
+		s.Add(v)
+	}
+	for _, v := range nums[:n/2] {
+		t.Add(v)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		into(dst, s, t)
+	}
+}
+
+func BenchmarkUnionInto100Safe(b *testing.B) {
+	benchInto(b, 100, NewSet(), NewSet(), NewSet(), UnionInto)
+}
+
+func BenchmarkUnionInto100Unsafe(b *testing.B) {
+	benchInto(b, 100, NewThreadUnsafeSet(), NewThreadUnsafeSet(), NewThreadUnsafeSet(), UnionInto)
+}
+
+func BenchmarkIntersectInto100Safe(b *testing.B) {
+	benchInto(b, 100, NewSet(), NewSet(), NewSet(), IntersectInto)
+}
+
+func BenchmarkIntersectInto100Unsafe(b *testing.B) {
+	benchInto(b, 100, NewThreadUnsafeSet(), NewThreadUnsafeSet(), NewThreadUnsafeSet(), IntersectInto)
+}
+
+func BenchmarkDifferenceInto100Safe(b *testing.B) {
+	benchInto(b, 100, NewSet(), NewSet(), NewSet(), DifferenceInto)
+}
+
+func BenchmarkDifferenceInto100Unsafe(b *testing.B) {
+	benchInto(b, 100, NewThreadUnsafeSet(), NewThreadUnsafeSet(), NewThreadUnsafeSet(), DifferenceInto)
+}
@@ -0,0 +1,360 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// binaryFormatVersion is the first byte of every binary encoding.
+// UnmarshalBinary rejects other versions, so any change to the format
+// must bump it.
+const binaryFormatVersion byte = 1
+
+// Element type tags used by the binary encoding. Their values are part of
+// the format and must never be reordered.
+const (
+	tagNil byte = iota
+	tagBool
+	tagInt
+	tagInt8
+	tagInt16
+	tagInt32
+	tagInt64
+	tagUint
+	tagUint8
+	tagUint16
+	tagUint32
+	tagUint64
+	tagUintptr
+	tagFloat32
+	tagFloat64
+	tagComplex64
+	tagComplex128
+	tagString
+	tagTime
+)
+
+var errBinaryTruncated = errors.New("mapset: truncated binary data")
+
+// appendElement appends the tag, length and payload of elem to b. Only
+// nil, booleans, numbers, strings and time.Time values are supported.
+func appendElement(b []byte, elem interface{}) ([]byte, error) {
+	var buf [binary.MaxVarintLen64]byte
+	var tag byte
+	var p []byte
+
+	switch v := elem.(type) {
+	case nil:
+		tag = tagNil
+	case bool:
+		tag = tagBool
+		p = buf[:1]
+		if v {
+			p[0] = 1
+		} else {
+			p[0] = 0
+		}
+	case int:
+		tag, p = tagInt, buf[:binary.PutVarint(buf[:], int64(v))]
+	case int8:
+		tag, p = tagInt8, buf[:binary.PutVarint(buf[:], int64(v))]
+	case int16:
+		tag, p = tagInt16, buf[:binary.PutVarint(buf[:], int64(v))]
+	case int32:
+		tag, p = tagInt32, buf[:binary.PutVarint(buf[:], int64(v))]
+	case int64:
+		tag, p = tagInt64, buf[:binary.PutVarint(buf[:], v)]
+	case uint:
+		tag, p = tagUint, buf[:binary.PutUvarint(buf[:], uint64(v))]
+	case uint8:
+		tag, p = tagUint8, buf[:binary.PutUvarint(buf[:], uint64(v))]
+	case uint16:
+		tag, p = tagUint16, buf[:binary.PutUvarint(buf[:], uint64(v))]
+	case uint32:
+		tag, p = tagUint32, buf[:binary.PutUvarint(buf[:], uint64(v))]
+	case uint64:
+		tag, p = tagUint64, buf[:binary.PutUvarint(buf[:], v)]
+	case uintptr:
+		tag, p = tagUintptr, buf[:binary.PutUvarint(buf[:], uint64(v))]
+	case float32:
+		tag, p = tagFloat32, binary.BigEndian.AppendUint32(buf[:0], math.Float32bits(v))
+	case float64:
+		tag, p = tagFloat64, binary.BigEndian.AppendUint64(buf[:0], math.Float64bits(v))
+	case complex64:
+		tag = tagComplex64
+		p = binary.BigEndian.AppendUint32(buf[:0], math.Float32bits(real(v)))
+		p = binary.BigEndian.AppendUint32(p, math.Float32bits(imag(v)))
+	case complex128:
+		tag = tagComplex128
+		p = binary.BigEndian.AppendUint64(make([]byte, 0, 16), math.Float64bits(real(v)))
+		p = binary.BigEndian.AppendUint64(p, math.Float64bits(imag(v)))
+	case string:
+		tag, p = tagString, []byte(v)
+	case time.Time:
+		var err error
+		if p, err = v.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		tag = tagTime
+	default:
+		return nil, fmt.Errorf("mapset: cannot binary encode element of type %T", elem)
+	}
+
+	b = append(b, tag)
+	b = binary.AppendUvarint(b, uint64(len(p)))
+	return append(b, p...), nil
+}
+
+// decodeElement decodes a single element payload written by appendElement,
+// given its tag and without its length prefix.
+func decodeElement(tag byte, p []byte) (interface{}, error) {
+	switch tag {
+	case tagNil:
+		if len(p) != 0 {
+			break
+		}
+		return nil, nil
+	case tagBool:
+		if len(p) != 1 || p[0] > 1 {
+			break
+		}
+		return p[0] == 1, nil
+	case tagInt, tagInt8, tagInt16, tagInt32, tagInt64:
+		x, n := binary.Varint(p)
+		if n <= 0 || n != len(p) {
+			break
+		}
+		return narrowInt(tag, x)
+	case tagUint, tagUint8, tagUint16, tagUint32, tagUint64, tagUintptr:
+		x, n := binary.Uvarint(p)
+		if n <= 0 || n != len(p) {
+			break
+		}
+		return narrowUint(tag, x)
+	case tagFloat32:
+		if len(p) != 4 {
+			break
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(p)), nil
+	case tagFloat64:
+		if len(p) != 8 {
+			break
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(p)), nil
+	case tagComplex64:
+		if len(p) != 8 {
+			break
+		}
+		re := math.Float32frombits(binary.BigEndian.Uint32(p))
+		im := math.Float32frombits(binary.BigEndian.Uint32(p[4:]))
+		return complex(re, im), nil
+	case tagComplex128:
+		if len(p) != 16 {
+			break
+		}
+		re := math.Float64frombits(binary.BigEndian.Uint64(p))
+		im := math.Float64frombits(binary.BigEndian.Uint64(p[8:]))
+		return complex(re, im), nil
+	case tagString:
+		return string(p), nil
+	case tagTime:
+		var t time.Time
+		if err := t.UnmarshalBinary(p); err != nil {
+			return nil, err
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("mapset: unknown binary element tag %d", tag)
+	}
+	return nil, fmt.Errorf("mapset: invalid payload %v for binary element tag %d", p, tag)
+}
+
+func narrowInt(tag byte, x int64) (interface{}, error) {
+	var v interface{}
+	var ok bool
+	switch tag {
+	case tagInt:
+		v, ok = int(x), int64(int(x)) == x
+	case tagInt8:
+		v, ok = int8(x), int64(int8(x)) == x
+	case tagInt16:
+		v, ok = int16(x), int64(int16(x)) == x
+	case tagInt32:
+		v, ok = int32(x), int64(int32(x)) == x
+	default:
+		v, ok = x, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("mapset: value %v overflows binary element tag %d", x, tag)
+	}
+	return v, nil
+}
+
+func narrowUint(tag byte, x uint64) (interface{}, error) {
+	var v interface{}
+	var ok bool
+	switch tag {
+	case tagUint:
+		v, ok = uint(x), uint64(uint(x)) == x
+	case tagUint8:
+		v, ok = uint8(x), uint64(uint8(x)) == x
+	case tagUint16:
+		v, ok = uint16(x), uint64(uint16(x)) == x
+	case tagUint32:
+		v, ok = uint32(x), uint64(uint32(x)) == x
+	case tagUintptr:
+		v, ok = uintptr(x), uint64(uintptr(x)) == x
+	default:
+		v, ok = x, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("mapset: value %v overflows binary element tag %d", x, tag)
+	}
+	return v, nil
+}
+
+func unmarshalBinary(data []byte) (threadUnsafeSet, error) {
+	if len(data) == 0 {
+		return nil, errBinaryTruncated
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, fmt.Errorf("mapset: unsupported binary format version %d", data[0])
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errBinaryTruncated
+	}
+	data = data[n:]
+
+	set := newThreadUnsafeSet()
+	for i := uint64(0); i < count; i++ {
+		if len(data) == 0 {
+			return nil, errBinaryTruncated
+		}
+		tag := data[0]
+		size, n := binary.Uvarint(data[1:])
+		if n <= 0 || uint64(len(data)-1-n) < size {
+			return nil, errBinaryTruncated
+		}
+		data = data[1+n:]
+
+		elem, err := decodeElement(tag, data[:size])
+		if err != nil {
+			return nil, err
+		}
+		set.Add(elem)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("mapset: %d bytes of trailing binary data", len(data))
+	}
+	return set, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each tagged, length-prefixed element. It fails
+// for element types other than nil, booleans, numbers, strings and
+// time.Time.
+func (set *threadUnsafeSet) MarshalBinary() ([]byte, error) {
+	defer set.guardRead().done()
+	b := []byte{binaryFormatVersion}
+	b = binary.AppendUvarint(b, uint64(len(*set)))
+
+	var err error
+	for elem := range *set {
+		b, err = appendElement(b, elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadUnsafeSet) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadUnsafeSet) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadUnsafeSet) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each tagged, length-prefixed element. It fails
+// for element types other than nil, booleans, numbers, strings and
+// time.Time.
+func (set *threadSafeSet) MarshalBinary() ([]byte, error) {
+	set.RLock()
+	b, err := set.s.MarshalBinary()
+	set.RUnlock()
+
+	return b, err
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadSafeSet) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalBinary(data)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = decoded
+	set.Unlock()
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadSafeSet) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadSafeSet) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
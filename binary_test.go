@@ -0,0 +1,109 @@
+package mapset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"testing"
+	"time"
+)
+
+func binarySample() []interface{} {
+	return []interface{}{
+		nil, true, false,
+		int(-1), int8(-8), int16(16), int32(-32), int64(math.MinInt64),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(math.MaxUint64), uintptr(7),
+		float32(1.5), math.SmallestNonzeroFloat64, math.Inf(-1), complex64(1 + 2i), complex(3.25, -4.5),
+		"", "hello", time.Date(2020, 2, 29, 12, 30, 0, 123456789, time.UTC),
+	}
+}
+
+func Test_BinaryRoundTrip(t *testing.T) {
+	for _, s := range []Set{NewSetFromSlice(binarySample()), NewThreadUnsafeSetFromSlice(binarySample())} {
+		b, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := s.Clone()
+		decoded.Clear()
+		decoded.Add("stale")
+		if err := decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(s, decoded, t)
+	}
+}
+
+func Test_BinaryRoundTripTimes(t *testing.T) {
+	now := time.Now()
+	utc := time.Date(2020, 2, 29, 12, 30, 0, 0, time.UTC)
+	for _, s := range []Set{NewSet(now, utc), NewThreadUnsafeSetFromSlice([]interface{}{now, utc})} {
+		b, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := s.Clone()
+		decoded.Clear()
+		if err := decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+
+		assertEqual(s, decoded, t)
+		if !decoded.Contains(now, utc) {
+			t.Errorf("expected %v to contain the original times", decoded)
+		}
+		decoded.Remove(now)
+		if decoded.Contains(now) || decoded.Cardinality() != 1 {
+			t.Errorf("expected to remove %v from %v", now, decoded)
+		}
+	}
+}
+
+func Test_BinaryUnsupportedElement(t *testing.T) {
+	s := NewSet(struct{ A int }{1})
+	if _, err := s.(encoding.BinaryMarshaler).MarshalBinary(); err == nil {
+		t.Error("expected an error for a struct element")
+	}
+}
+
+func Test_BinaryInvalidInput(t *testing.T) {
+	valid, err := NewSet("a", 1).(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := [][]byte{
+		nil,
+		{2, 0},
+		valid[:len(valid)-1],
+		append(append([]byte{}, valid...), 0),
+		{binaryFormatVersion, 1, tagInt8, 2, 0x80, 0x02},
+		{binaryFormatVersion, 1, 0xff, 0},
+	}
+	for i, input := range inputs {
+		s := NewSet("untouched")
+		if err := s.(encoding.BinaryUnmarshaler).UnmarshalBinary(input); err == nil {
+			t.Errorf("input %d: expected an error", i)
+		}
+		if !s.Equal(NewSet("untouched")) {
+			t.Errorf("input %d: set was modified on error: %v", i, s)
+		}
+	}
+}
+
+func Test_GobRoundTrip(t *testing.T) {
+	s := NewSetFromSlice(binarySample())
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewSet()
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(s, decoded, t)
+}
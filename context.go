@@ -0,0 +1,187 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"context"
+	"time"
+)
+
+// ContextSet is implemented by the thread-safe sets returned by NewSet
+// and its variants. Its methods give up instead of blocking forever when
+// the set is held for a long time, for example by a slow Each callback
+// or an abandoned Iter consumer.
+//
+// The Context methods wait for the lock until ctx is done and then
+// return ctx.Err(), such as context.DeadlineExceeded. They poll for the
+// lock instead of queueing on it, so an abandoned wait leaves nothing
+// behind, but the wait is not fair. A waiting AddContext or
+// RemoveContext does not hold off new readers, as a blocked Add does: it
+// gets the lock only if a poll finds no reader holding it. Readers that
+// keep the set held between them, such as overlapping Each calls, make
+// it wait until ctx is done. Polls back off to one every few
+// milliseconds, so a wait may also end that long after the lock is
+// released. Use Add and Remove where writers must not starve.
+//
+// The Try methods do not wait at all and report whether the lock was
+// available.
+type ContextSet interface {
+	Set
+
+	// AddContext adds i to the set and reports whether it was added.
+	AddContext(ctx context.Context, i interface{}) (bool, error)
+
+	// ContainsContext reports whether all of the given items are in the
+	// set.
+	ContainsContext(ctx context.Context, i ...interface{}) (bool, error)
+
+	// RemoveContext removes i from the set.
+	RemoveContext(ctx context.Context, i interface{}) error
+
+	// TryAdd adds i if the set is not locked. ok reports whether the
+	// lock was available; added whether i was new.
+	TryAdd(i interface{}) (added, ok bool)
+
+	// TryContains reports whether all of the given items are in the set
+	// if the set is not locked for writing. ok reports whether the lock
+	// was available.
+	TryContains(i ...interface{}) (found, ok bool)
+
+	// TryRemove removes i if the set is not locked, and reports whether
+	// the lock was available.
+	TryRemove(i interface{}) bool
+}
+
+// lockContext calls tryLock until it succeeds or ctx is done. It
+// polls rather than waiting in Lock or RLock, which would leave a
+// goroutine blocked on the set once ctx is done and, as a pending writer,
+// hold off new readers. The polling interval doubles up to a few
+// milliseconds, so a long wait costs little. Writers are not queued
+// ahead of readers; see ContextSet for what that costs.
+func lockContext(ctx context.Context, tryLock func() bool) error {
+	const (
+		minPoll = 50 * time.Microsecond
+		maxPoll = 5 * time.Millisecond
+	)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tryLock() {
+		return nil
+	}
+
+	wait := minPoll
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if tryLock() {
+			return nil
+		}
+		if wait *= 2; wait > maxPoll {
+			wait = maxPoll
+		}
+		timer.Reset(wait)
+	}
+}
+
+func (set *threadSafeSet) AddContext(ctx context.Context, i interface{}) (bool, error) {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockContext(ctx, set.TryLock)
+	st.waited(start)
+	if err != nil {
+		return false, err
+	}
+	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
+	set.Unlock()
+	return ret, nil
+}
+
+func (set *threadSafeSet) ContainsContext(ctx context.Context, i ...interface{}) (bool, error) {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockContext(ctx, set.TryRLock)
+	st.waited(start)
+	if err != nil {
+		return false, err
+	}
+	ret := set.s.Contains(i...)
+	set.RUnlock()
+	st.looked(ret)
+	return ret, nil
+}
+
+func (set *threadSafeSet) RemoveContext(ctx context.Context, i interface{}) error {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockContext(ctx, set.TryLock)
+	st.waited(start)
+	if err != nil {
+		return err
+	}
+	n := len(set.s)
+	delete(set.s, elementKey(i))
+	st.removed(len(set.s) < n)
+	set.Unlock()
+	return nil
+}
+
+func (set *threadSafeSet) TryAdd(i interface{}) (added, ok bool) {
+	if !set.TryLock() {
+		return false, false
+	}
+	added = set.s.Add(i)
+	set.stats.Load().added(added, len(set.s))
+	set.Unlock()
+	return added, true
+}
+
+func (set *threadSafeSet) TryContains(i ...interface{}) (found, ok bool) {
+	if !set.TryRLock() {
+		return false, false
+	}
+	found = set.s.Contains(i...)
+	set.RUnlock()
+	set.stats.Load().looked(found)
+	return found, true
+}
+
+func (set *threadSafeSet) TryRemove(i interface{}) bool {
+	if !set.TryLock() {
+		return false
+	}
+	n := len(set.s)
+	delete(set.s, elementKey(i))
+	set.stats.Load().removed(len(set.s) < n)
+	set.Unlock()
+	return true
+}
@@ -0,0 +1,154 @@
+package mapset
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_ContextSet(t *testing.T) {
+	s, ok := NewSet(1).(ContextSet)
+	if !ok {
+		t.Fatal("expected NewSet to return a ContextSet")
+	}
+	ctx := context.Background()
+
+	if added, err := s.AddContext(ctx, 2); !added || err != nil {
+		t.Errorf("expected to add 2, got %v, %v", added, err)
+	}
+	if found, err := s.ContainsContext(ctx, 1, 2); !found || err != nil {
+		t.Errorf("expected to find 1 and 2, got %v, %v", found, err)
+	}
+	if err := s.RemoveContext(ctx, 1); err != nil || s.Contains(1) {
+		t.Errorf("expected to remove 1, got %v", err)
+	}
+
+	if added, ok := s.TryAdd(3); !added || !ok {
+		t.Errorf("expected to add 3 without waiting, got %v, %v", added, ok)
+	}
+	if found, ok := s.TryContains(3); !found || !ok {
+		t.Errorf("expected to find 3 without waiting, got %v, %v", found, ok)
+	}
+	if !s.TryRemove(3) || s.Contains(3) {
+		t.Error("expected to remove 3 without waiting")
+	}
+}
+
+func Test_ContextSetGivesUp(t *testing.T) {
+	s := NewSet(1).(ContextSet)
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go s.Each(func(interface{}) bool {
+		close(held)
+		<-release
+		return true
+	})
+	<-held
+
+	// Readers are not blocked by a reader.
+	if found, ok := s.TryContains(1); !ok || !found {
+		t.Errorf("expected TryContains to succeed alongside a reader, got %v, %v", found, ok)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.AddContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if err := s.RemoveContext(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, ok := s.TryAdd(2); ok {
+		t.Error("expected TryAdd to fail while the set is held")
+	}
+	if s.TryRemove(1) {
+		t.Error("expected TryRemove to fail while the set is held")
+	}
+
+	close(release)
+
+	// The abandoned waits must not leave the set locked.
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if added, err := s.AddContext(ctx, 3); err != nil || !added {
+		t.Errorf("expected to add 3 once released, got %v, %v", added, err)
+	}
+	if s.Contains(2) {
+		t.Error("expected the abandoned add not to have happened")
+	}
+}
+
+func Test_ContextSetTimedOutWriteKeepsReads(t *testing.T) {
+	s := NewSet(1).(ContextSet)
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go s.Each(func(interface{}) bool {
+		close(held)
+		<-release
+		return true
+	})
+	<-held
+	defer close(release)
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		if _, err := s.AddContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		cancel()
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected the timed out adds to leave no goroutines, got %d more", after-before)
+	}
+
+	// A timed out writer must not stay queued on the lock, where it
+	// would hold off readers.
+	if found, ok := s.TryContains(1); !ok || !found {
+		t.Errorf("expected TryContains to succeed after a timed out add, got %v, %v", found, ok)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if found, err := s.ContainsContext(ctx, 1); err != nil || !found {
+		t.Errorf("expected ContainsContext to succeed after a timed out add, got %v, %v", found, err)
+	}
+}
+
+func Test_ContextSetWriterProgress(t *testing.T) {
+	s := NewSet(1).(ContextSet)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				s.Contains(1)
+				time.Sleep(10 * time.Microsecond)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(stop)
+
+	// Writers are not queued ahead of readers, but busy readers that
+	// leave the set free now and then must not starve them.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 2; i < 20; i++ {
+		if added, err := s.AddContext(ctx, i); err != nil || !added {
+			t.Fatalf("expected to add %d alongside readers, got %v, %v", i, added, err)
+		}
+	}
+	if err := s.RemoveContext(ctx, 2); err != nil {
+		t.Fatalf("expected to remove 2 alongside readers, got %v", err)
+	}
+}
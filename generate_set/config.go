@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// A Manifest lists set types to generate in one run, read from the file
+// given to -config:
+//
+//	{
+//		"template_dir": "templates",
+//		"defaults": false,
+//		"sets": [
+//			{
+//				"struct_name": "Order",
+//				"default_value": "Order{}",
+//				"out_dir": "internal/orders",
+//				"package": "orders",
+//				"file_prefix": "order",
+//				"skip_tests": true,
+//				"variants": [{"kind": "pointer", "out_dir": "internal/orderptrs"}]
+//			}
+//		]
+//	}
+//
+// Keys are named after the flags they replace and default the same way.
+// Relative directories are relative to the manifest. Defaults adds the
+// types of -make_defaults.
+type Manifest struct {
+	TemplateDir string        `json:"template_dir"`
+	Defaults    bool          `json:"defaults"`
+	Sets        []ManifestSet `json:"sets"`
+
+	// dir is the directory of the manifest file.
+	dir string
+}
+
+// A ManifestSet describes one set type of a Manifest, and the sets of
+// pointers to or channels of its element type listed as its variants.
+type ManifestSet struct {
+	StructName   string            `json:"struct_name"`
+	ImportPath   string            `json:"import_path"`
+	DefaultValue string            `json:"default_value"`
+	OutDir       string            `json:"out_dir"`
+	Package      string            `json:"package"`
+	FilePrefix   string            `json:"file_prefix"`
+	SkipTests    bool              `json:"skip_tests"`
+	Variants     []ManifestVariant `json:"variants"`
+}
+
+// A ManifestVariant derives a set type from the element type of its
+// ManifestSet. Kind is one of the VARIANTS; the set type is named after
+// it, e.g. PointerOfOrder, and its zero value is nil. The variant is
+// generated into a package of its own and shares the import path and
+// skip_tests of its ManifestSet.
+type ManifestVariant struct {
+	Kind       string `json:"kind"`
+	OutDir     string `json:"out_dir"`
+	Package    string `json:"package"`
+	FilePrefix string `json:"file_prefix"`
+}
+
+// LoadManifest reads the manifest at path. Unknown keys are errors, so
+// that a misspelled option is not silently ignored.
+func LoadManifest(path string) (Manifest, error) {
+	var manifest Manifest
+	f, err := os.Open(path)
+	if err != nil {
+		return manifest, err
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(f)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&manifest); err != nil {
+		return manifest, fmt.Errorf("cannot read manifest %v: %w", path, err)
+	}
+	manifest.dir = filepath.Dir(path)
+	if manifest.TemplateDir != "" {
+		manifest.TemplateDir = manifest.resolve(manifest.TemplateDir)
+	}
+	return manifest, nil
+}
+
+// resolve makes a relative directory of the manifest relative to the
+// manifest itself.
+func (m Manifest) resolve(dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(m.dir, dir)
+}
+
+// SetTypes returns the validated set types of the manifest. Rather than
+// stopping at the first invalid entry, it reports every one of them,
+// prefixed with its position in the manifest.
+func (m Manifest) SetTypes() ([]SetType, error) {
+	var setTypes []SetType
+	var errs []error
+	if m.Defaults {
+		for _, setType := range MakeDefaultSetTypes() {
+			setType.OutDir = m.resolve(setType.OutDir)
+			setTypes = append(setTypes, setType)
+		}
+	}
+
+	for i, set := range m.Sets {
+		entry := fmt.Sprintf("sets[%d]", i)
+		if set.StructName != "" {
+			entry += fmt.Sprintf(" (%v)", set.StructName)
+		}
+		setType, err := m.setType(set)
+		if err != nil {
+			errs = append(errs, NewManifestError(entry, err))
+			continue
+		}
+		setTypes = append(setTypes, setType)
+
+		for j, variant := range set.Variants {
+			variantType, err := m.variantSetType(setType, variant)
+			if err != nil {
+				errs = append(errs, NewManifestError(fmt.Sprintf("%v variants[%d]", entry, j), err))
+				continue
+			}
+			setTypes = append(setTypes, variantType)
+		}
+	}
+	return setTypes, errors.Join(errs...)
+}
+
+func (m Manifest) setType(set ManifestSet) (SetType, error) {
+	if set.StructName == "" {
+		return SetType{}, NewEmptyFlagError("struct_name")
+	}
+	if set.DefaultValue == "" {
+		return SetType{}, NewEmptyFlagError("default_value")
+	}
+	setType := NewSetType(set.StructName, set.ImportPath, set.DefaultValue)
+	setType.SkipTests = set.SkipTests
+	return m.applyOptions(setType, set.OutDir, set.Package, set.FilePrefix)
+}
+
+func (m Manifest) variantSetType(base SetType, variant ManifestVariant) (SetType, error) {
+	names, ok := VARIANTS[variant.Kind]
+	if !ok {
+		reason := "only pointer and channel are supported"
+		if variant.Kind == "slice" || variant.Kind == "map" {
+			reason = "slices and maps are not comparable and cannot be set elements"
+		}
+		return SetType{}, NewInvalidFlagError("kind", variant.Kind, reason)
+	}
+	setType := newSetTypeWithTitleName(
+		fmt.Sprintf(names.DataType, base.DataType),
+		fmt.Sprintf(names.TitleName, base.TitleName),
+		base.ImportPath,
+		"nil",
+	)
+	setType.SkipTests = base.SkipTests
+	return m.applyOptions(setType, variant.OutDir, variant.Package, variant.FilePrefix)
+}
+
+// applyOptions overrides the defaults of setType with the options given
+// in the manifest, as the flags of the same names do.
+func (m Manifest) applyOptions(setType SetType, outDir, packageName, filePrefix string) (SetType, error) {
+	if outDir != "" {
+		setType.OutDir = outDir
+	}
+	setType.OutDir = m.resolve(setType.OutDir)
+	if packageName != "" {
+		setType.PackageName = packageName
+	}
+	if filePrefix != "" {
+		setType.FilePrefix = filePrefix
+	}
+	return setType, setType.Validate()
+}
@@ -7,6 +7,12 @@ import (
 const (
 	STARTS_WITH_NUM_REGEX   = `^[0-9].*`
 	SPLIT_OBJECT_NAME_REGEX = `[:/.\-_]+`
+	FILE_PREFIX_REGEX       = `^[A-Za-z0-9][A-Za-z0-9_.\-]*$`
+
+	// GENERATED_HEADER starts every generated file. It follows the
+	// convention recognized by Go tools and names the data type, which
+	// lets generate_set tell its own files apart from others.
+	GENERATED_HEADER = "// Code generated by generate_set for %v. DO NOT EDIT."
 
 	ARRAY_SET_TYPE_NAME = "ArrayOf%v"
 	CHAN_SET_TYPE_NAME  = "ChannelOf%v"
@@ -14,22 +20,107 @@ const (
 	SLICE_SET_TYPE_NAME = "SliceOf%v"
 	MAP_SET_TYPE_NAME   = "MapOf%vTo%v"
 
+	VARIANT_CHANNEL = "channel"
+	VARIANT_POINTER = "pointer"
+
+	KIND_BOOL   = "bool"
+	KIND_INT    = "int"
+	KIND_UINT   = "uint"
+	KIND_FLOAT  = "float"
+	KIND_STRING = "string"
+	KIND_TIME   = "time"
+	KIND_OTHER  = "other"
+
 	BASE_FILEPATH = "sets/%v_set"
+	TEMPLATE_DIR  = "templates"
 
-	ITERATOR_FILENAME     = "%v_iterator.go"
-	SET_FILENAME          = "%v_set.go"
-	THREADSAFE_FILENAME   = "%v_threadsafe.go"
-	THREADUNSAFE_FILENAME = "%v_threadunsafe.go"
+	BENCH_TEST_FILENAME      = "%v_bench_test.go"
+	BINARY_FILENAME          = "%v_binary.go"
+	BINARY_TEST_FILENAME     = "%v_binary_test.go"
+	COMPACT_FILENAME         = "%v_compact.go"
+	COMPACT_TEST_FILENAME    = "%v_compact_test.go"
+	CONTEXT_FILENAME         = "%v_context.go"
+	CONTEXT_TEST_FILENAME    = "%v_context_test.go"
+	CSV_FILENAME             = "%v_csv.go"
+	CSV_TEST_FILENAME        = "%v_csv_test.go"
+	FUZZ_TEST_FILENAME       = "%v_fuzz_test.go"
+	HYBRID_FILENAME          = "%v_hybrid.go"
+	HYBRID_TEST_FILENAME     = "%v_hybrid_test.go"
+	INTO_FILENAME            = "%v_into.go"
+	INTO_TEST_FILENAME       = "%v_into_test.go"
+	ITERATOR_FILENAME        = "%v_iterator.go"
+	JSON_FILENAME            = "%v_json.go"
+	JSON_TEST_FILENAME       = "%v_json_test.go"
+	MISUSE_FILENAME          = "%v_misuse.go"
+	MISUSE_DEBUG_FILENAME    = "%v_misuse_debug.go"
+	MULTI_FILENAME           = "%v_multi.go"
+	MULTI_TEST_FILENAME      = "%v_multi_test.go"
+	PAIR_FILENAME            = "%v_pair.go"
+	SET_FILENAME             = "%v_set.go"
+	SET_TEST_FILENAME        = "%v_set_test.go"
+	SIMILARITY_FILENAME      = "%v_similarity.go"
+	SIMILARITY_TEST_FILENAME = "%v_similarity_test.go"
+	SKETCH_FILENAME          = "%v_sketch.go"
+	SKETCH_TEST_FILENAME     = "%v_sketch_test.go"
+	SORT_FILENAME            = "%v_sort.go"
+	SORT_TEST_FILENAME       = "%v_sort_test.go"
+	SQL_FILENAME             = "%v_sql.go"
+	SQL_TEST_FILENAME        = "%v_sql_test.go"
+	STATS_FILENAME           = "%v_stats.go"
+	STATS_TEST_FILENAME      = "%v_stats_test.go"
+	TEXT_FILENAME            = "%v_text.go"
+	TEXT_TEST_FILENAME       = "%v_text_test.go"
+	THREADSAFE_FILENAME      = "%v_threadsafe.go"
+	THREADUNSAFE_FILENAME    = "%v_threadunsafe.go"
+	XML_FILENAME             = "%v_xml.go"
+	XML_TEST_FILENAME        = "%v_xml_test.go"
 
-	ITERATOR_TEMPLATE     = "generate_set/templates/iterator.gotemplate"
-	SET_TEMPLATE          = "generate_set/templates/set.gotemplate"
-	THREADSAFE_TEMPLATE   = "generate_set/templates/threadsafe.gotemplate"
-	THREADUNSAFE_TEMPLATE = "generate_set/templates/threadunsafe.gotemplate"
+	BENCH_TEST_TEMPLATE      = "bench_test.gotemplate"
+	BINARY_TEMPLATE          = "binary.gotemplate"
+	BINARY_TEST_TEMPLATE     = "binary_test.gotemplate"
+	COMPACT_TEMPLATE         = "compact.gotemplate"
+	COMPACT_TEST_TEMPLATE    = "compact_test.gotemplate"
+	CONTEXT_TEMPLATE         = "context.gotemplate"
+	CONTEXT_TEST_TEMPLATE    = "context_test.gotemplate"
+	CSV_TEMPLATE             = "csv.gotemplate"
+	CSV_TEST_TEMPLATE        = "csv_test.gotemplate"
+	FUZZ_TEST_TEMPLATE       = "fuzz_test.gotemplate"
+	HYBRID_TEMPLATE          = "hybrid.gotemplate"
+	HYBRID_TEST_TEMPLATE     = "hybrid_test.gotemplate"
+	INTO_TEMPLATE            = "into.gotemplate"
+	INTO_TEST_TEMPLATE       = "into_test.gotemplate"
+	ITERATOR_TEMPLATE        = "iterator.gotemplate"
+	JSON_TEMPLATE            = "json.gotemplate"
+	JSON_TEST_TEMPLATE       = "json_test.gotemplate"
+	MISUSE_TEMPLATE          = "misuse.gotemplate"
+	MISUSE_DEBUG_TEMPLATE    = "misuse_debug.gotemplate"
+	MULTI_TEMPLATE           = "multi.gotemplate"
+	MULTI_TEST_TEMPLATE      = "multi_test.gotemplate"
+	PAIR_TEMPLATE            = "pair.gotemplate"
+	SET_TEMPLATE             = "set.gotemplate"
+	SET_TEST_TEMPLATE        = "set_test.gotemplate"
+	SIMILARITY_TEMPLATE      = "similarity.gotemplate"
+	SIMILARITY_TEST_TEMPLATE = "similarity_test.gotemplate"
+	SKETCH_TEMPLATE          = "sketch.gotemplate"
+	SKETCH_TEST_TEMPLATE     = "sketch_test.gotemplate"
+	SORT_TEMPLATE            = "sort.gotemplate"
+	SORT_TEST_TEMPLATE       = "sort_test.gotemplate"
+	SQL_TEMPLATE             = "sql.gotemplate"
+	SQL_TEST_TEMPLATE        = "sql_test.gotemplate"
+	STATS_TEMPLATE           = "stats.gotemplate"
+	STATS_TEST_TEMPLATE      = "stats_test.gotemplate"
+	TEXT_TEMPLATE            = "text.gotemplate"
+	TEXT_TEST_TEMPLATE       = "text_test.gotemplate"
+	THREADSAFE_TEMPLATE      = "threadsafe.gotemplate"
+	THREADUNSAFE_TEMPLATE    = "threadunsafe.gotemplate"
+	XML_TEMPLATE             = "xml.gotemplate"
+	XML_TEST_TEMPLATE        = "xml_test.gotemplate"
 )
 
 var (
 	STARTS_WITH_NUM_REGEXP   = regexp.MustCompile(STARTS_WITH_NUM_REGEX)
 	SPLIT_OBJECT_NAME_REGEXP = regexp.MustCompile(SPLIT_OBJECT_NAME_REGEX)
+	FILE_PREFIX_REGEXP       = regexp.MustCompile(FILE_PREFIX_REGEX)
 
 	DEFAULT_TYPES = map[string]interface{}{
 		"bool":    false,
@@ -47,4 +138,67 @@ var (
 		"float64": 0.0,
 		"string":  `""`,
 	}
+
+	DATA_TYPE_KINDS = map[string]string{
+		"bool":      KIND_BOOL,
+		"int":       KIND_INT,
+		"int8":      KIND_INT,
+		"int16":     KIND_INT,
+		"int32":     KIND_INT,
+		"int64":     KIND_INT,
+		"uint":      KIND_UINT,
+		"uint8":     KIND_UINT,
+		"uint16":    KIND_UINT,
+		"uint32":    KIND_UINT,
+		"uint64":    KIND_UINT,
+		"float32":   KIND_FLOAT,
+		"float64":   KIND_FLOAT,
+		"string":    KIND_STRING,
+		"time.Time": KIND_TIME,
+	}
+
+	// SAMPLE_VALUES holds Go expressions for distinct values of each kind,
+	// which the generated tests, fuzz targets and benchmarks fill sets
+	// with. They fit the smallest type of the kind.
+	SAMPLE_VALUES = map[string][]string{
+		KIND_BOOL:   {"false", "true"},
+		KIND_INT:    {"-128", "-1", "0", "1", "2", "3", "42", "127"},
+		KIND_UINT:   {"0", "1", "2", "3", "42", "127", "128", "255"},
+		KIND_FLOAT:  {"-2.5", "-1", "0", "0.25", "1", "3.75", "1e6", "-0.001"},
+		KIND_STRING: {`""`, `"a"`, `"b"`, `"hello world"`, `"comma,separated"`, `"quoted \"value\""`, `"<tag>&amp;"`, `"ünïcödé"`},
+		KIND_TIME: {
+			"time.Unix(0, 0).UTC()",
+			"time.Unix(1, 0).UTC()",
+			"time.Unix(0, 1).UTC()",
+			"time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)",
+			"time.Date(2020, time.February, 29, 12, 30, 45, 500, time.UTC)",
+			"time.Date(1969, time.December, 31, 23, 59, 59, 0, time.UTC)",
+			"time.Date(2038, time.January, 19, 3, 14, 8, 0, time.UTC)",
+			"time.Date(9999, time.December, 31, 23, 59, 59, 999999999, time.UTC)",
+		},
+	}
+
+	// TESTED_KINDS are the kinds with SAMPLE_VALUES, for which tests are
+	// generated.
+	TESTED_KINDS = []string{KIND_BOOL, KIND_INT, KIND_UINT, KIND_FLOAT, KIND_STRING, KIND_TIME}
+
+	// VARIANTS maps the variants a manifest may request to the set type
+	// name and data type built from the element type.
+	VARIANTS = map[string]struct{ TitleName, DataType string }{
+		VARIANT_CHANNEL: {CHAN_SET_TYPE_NAME, "chan %v"},
+		VARIANT_POINTER: {PTR_SET_TYPE_NAME, "*%v"},
+	}
+
+	DATA_TYPE_BIT_SIZES = map[string]int{
+		"int8":    8,
+		"int16":   16,
+		"int32":   32,
+		"int64":   64,
+		"uint8":   8,
+		"uint16":  16,
+		"uint32":  32,
+		"uint64":  64,
+		"float32": 32,
+		"float64": 64,
+	}
 )
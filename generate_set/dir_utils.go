@@ -1,31 +1,164 @@
 package main
 
 import (
+	"embed"
+	"errors"
 	"fmt"
+	"go/parser"
+	"go/token"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
-func makeFilename(titleName string, baseFilename string) (string, error) {
-	wd, err := os.Getwd()
-	if err != nil {
-		return "", err
+// TEMPLATES holds the templates in TEMPLATE_DIR, so that generate_set runs
+// from any directory, including through go run from another module.
+//
+//go:embed templates/*.gotemplate
+var TEMPLATES embed.FS
+
+// makeFilename returns the path setType's file for baseFilename is
+// written to.
+func makeFilename(setType SetType, baseFilename string) (string, error) {
+	dir := setType.OutDir
+	if !filepath.IsAbs(dir) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(wd, dir)
+	}
+	return filepath.Join(dir, fmt.Sprintf(baseFilename, setType.FilePrefix)), nil
+}
+
+// CheckCollisions reports an error if generating setTypes would write two
+// files to one path, put two set types into one directory, where their
+// package-level functions would clash, overwrite a file generate_set did
+// not write, or add files to a directory holding another package. Every
+// collision is reported, each once.
+func CheckCollisions(setTypes []SetType, templateTypes []TemplateType) error {
+	var errs []error
+	paths := map[string]SetType{}
+	dirs := map[string]SetType{}
+	clashes := map[[2]string]bool{}
+	for _, setType := range setTypes {
+		for _, templateType := range templateTypes {
+			if !templateType.Supports(setType) {
+				continue
+			}
+			path, err := makeFilename(setType, templateType.OutFilename)
+			if err != nil {
+				return err
+			}
+			if other, ok := paths[path]; !ok {
+				paths[path] = setType
+			} else if other.DataType != setType.DataType {
+				errs = append(errs, NewCollisionError(path, fmt.Sprintf("both %v and %v would be written to it", other.DataType, setType.DataType)))
+			}
+
+			dir := filepath.Dir(path)
+			if other, ok := dirs[dir]; !ok {
+				dirs[dir] = setType
+			} else if clash := [2]string{dir, setType.DataType}; other.DataType != setType.DataType && !clashes[clash] {
+				clashes[clash] = true
+				errs = append(errs, NewCollisionError(dir, fmt.Sprintf("both %v and %v would be generated into one package", other.DataType, setType.DataType)))
+			}
+		}
+	}
+
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
 	}
-	lowerTitleName := strings.ToLower(titleName)
-	filePath := filepath.Join(wd, BASE_FILEPATH, baseFilename)
-	return fmt.Sprintf(filePath, lowerTitleName, lowerTitleName), nil
+	sort.Strings(sortedDirs)
+	for _, dir := range sortedDirs {
+		errs = append(errs, checkDirCollisions(dir, dirs[dir], paths)...)
+	}
+	return errors.Join(errs...)
 }
 
-func GetTemplate(templateFilename string) ([]byte, error) {
-	wd, err := os.Getwd()
+// checkDirCollisions checks the Go files already in dir against the files
+// setType is about to write there, returning each problem once.
+func checkDirCollisions(dir string, setType SetType, paths map[string]SetType) []error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
 	if err != nil {
-		return nil, err
+		return []error{err}
+	}
+
+	var errs []error
+	reported := map[string]bool{}
+	report := func(err error) {
+		if !reported[err.Error()] {
+			reported[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		src, err := os.ReadFile(path)
+		if err != nil {
+			report(err)
+			continue
+		}
+
+		if dataType, ok := generatedDataType(src); ok {
+			if dataType != setType.DataType {
+				report(NewCollisionError(dir, fmt.Sprintf("it already holds the generated set for %v", dataType)))
+			} else if _, ok := paths[path]; !ok {
+				report(NewCollisionError(dir, fmt.Sprintf("it holds %v, generated for %v with other file names", entry.Name(), dataType)))
+			}
+			continue
+		}
+		if _, ok := paths[path]; ok {
+			report(NewCollisionError(path, "it exists and was not written by generate_set"))
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, src, parser.PackageClauseOnly)
+		if err != nil {
+			report(err)
+			continue
+		}
+		if name := strings.TrimSuffix(file.Name.Name, "_test"); name != setType.PackageName {
+			report(NewCollisionError(dir, fmt.Sprintf("it holds package %v, not %v", name, setType.PackageName)))
+		}
+	}
+	return errs
+}
+
+// generatedDataType returns the data type named in the GENERATED_HEADER
+// of src, if src was written by generate_set.
+func generatedDataType(src []byte) (string, bool) {
+	line, _, _ := strings.Cut(string(src), "\n")
+	prefix, suffix, _ := strings.Cut(GENERATED_HEADER, "%v")
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, suffix) || len(line) < len(prefix)+len(suffix) {
+		return "", false
+	}
+	return line[len(prefix) : len(line)-len(suffix)], true
+}
+
+// GetTemplate returns the template named templateFilename. A file of that
+// name in templateDir takes precedence over the template embedded in the
+// binary, so a directory may override only some templates. An empty
+// templateDir uses the embedded templates alone.
+func GetTemplate(templateDir string, templateFilename string) ([]byte, error) {
+	if templateDir != "" {
+		b, err := ioutil.ReadFile(filepath.Join(templateDir, templateFilename))
+		if !os.IsNotExist(err) {
+			return b, err
+		}
 	}
-	filename := filepath.Join(wd, templateFilename)
-	return ioutil.ReadFile(filename)
+	return fs.ReadFile(TEMPLATES, path.Join(TEMPLATE_DIR, templateFilename))
 }
 
 func WriteGenFile(file io.Reader, path string) error {
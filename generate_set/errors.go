@@ -12,3 +12,15 @@ func NewEmptyFlagError(flagName string) error {
 func NewTypeNotSupportedError(t reflect.Type) error {
 	return fmt.Errorf("Type %v not supported.", t)
 }
+
+func NewInvalidFlagError(flagName, value, reason string) error {
+	return fmt.Errorf("invalid value %q for flag %v: %v", value, flagName, reason)
+}
+
+func NewCollisionError(path, reason string) error {
+	return fmt.Errorf("cannot generate %v: %v", path, reason)
+}
+
+func NewManifestError(entry string, err error) error {
+	return fmt.Errorf("in %v: %w", entry, err)
+}
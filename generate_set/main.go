@@ -1,6 +1,23 @@
+// Command generate_set writes type-specific sets from templates embedded
+// in the binary, so it runs from any directory. From another module, a
+// go:generate line next to the element type generates its set in place:
+//
+//	//go:generate go run github.com/emarcey/golang-set/generate_set -struct_name=Order -default_value=Order{} -out_dir=. -package=orders -file_prefix=order
+//
+// go generate runs the command in the directory of the file holding the
+// line, so -out_dir=. is that package. The module must require
+// github.com/emarcey/golang-set, which the generated code imports.
+//
+// -template_dir names a directory whose templates replace the embedded
+// ones of the same file name; the rest stay embedded.
+//
+// -config reads the set types from a JSON manifest instead, generating
+// all of them in one run; see Manifest. Errors in the manifest are
+// reported together rather than one per run.
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -11,31 +28,87 @@ func generateSet() error {
 	var importPath = flag.String("import_path", "", "go")
 	var defaultValue = flag.String("default_value", "", "default value of struct")
 	var makeDefaults = flag.Bool("make_defaults", false, "helper to run a series of pre-defined basic types")
+	var outDir = flag.String("out_dir", "", "directory to write the set to (default sets/<name>_set)")
+	var packageName = flag.String("package", "", "package name of the generated files (default mapset<name>)")
+	var filePrefix = flag.String("file_prefix", "", "prefix of the generated file names (default <name>)")
+	var templateDir = flag.String("template_dir", "", "directory of templates overriding the embedded ones")
+	var configPath = flag.String("config", "", "JSON manifest of set types to generate, replacing the flags describing one type")
 
 	flag.Parse()
 
-	templateTypes := MakeTemplateTypes()
+	var setTypes []SetType
+	if *configPath != "" {
+		var conflict string
+		flag.Visit(func(f *flag.Flag) {
+			if conflict == "" && f.Name != "config" && f.Name != "template_dir" {
+				conflict = f.Name
+			}
+		})
+		if conflict != "" {
+			return NewInvalidFlagError("config", *configPath, fmt.Sprintf("cannot be combined with -%v", conflict))
+		}
+		manifest, err := LoadManifest(*configPath)
+		if err != nil {
+			return err
+		}
+		if setTypes, err = manifest.SetTypes(); err != nil {
+			return err
+		}
+		if *templateDir == "" {
+			*templateDir = manifest.TemplateDir
+		}
+	} else {
+		if *makeDefaults {
+			setTypes = MakeDefaultSetTypes()
+		} else {
+			if *structName == "" {
+				return NewEmptyFlagError("struct_name")
+			}
+			if *defaultValue == "" {
+				return NewEmptyFlagError("default_value")
+			}
+			setTypes = []SetType{NewSetType(*structName, *importPath, *defaultValue)}
+		}
 
-	if *makeDefaults {
-		setTypes := MakeDefaultSetTypes()
-		for _, setType := range setTypes {
-			err := CreateSet(setType, templateTypes)
-			if err != nil {
+		for i := range setTypes {
+			if *outDir != "" {
+				setTypes[i].OutDir = *outDir
+			}
+			if *packageName != "" {
+				setTypes[i].PackageName = *packageName
+			}
+			if *filePrefix != "" {
+				setTypes[i].FilePrefix = *filePrefix
+			}
+			if err := setTypes[i].Validate(); err != nil {
 				return err
 			}
 		}
-		return nil
-	}
-	if *structName == "" {
-		return NewEmptyFlagError("struct_name")
 	}
-	if *defaultValue == "" {
-		return NewEmptyFlagError("default_value")
+
+	templateTypes := MakeTemplateTypes()
+	if *templateDir != "" {
+		if info, err := os.Stat(*templateDir); err != nil || !info.IsDir() {
+			return NewInvalidFlagError("template_dir", *templateDir, "not a directory")
+		}
+		for i := range templateTypes {
+			templateTypes[i].TemplateDir = *templateDir
+		}
 	}
 
-	setType := NewSetType(*structName, *importPath, *defaultValue)
+	if err := CheckCollisions(setTypes, templateTypes); err != nil {
+		return err
+	}
 
-	return CreateSet(setType, templateTypes)
+	// Keep generating after a failure, so that one run reports every set
+	// type that cannot be generated.
+	var errs []error
+	for _, setType := range setTypes {
+		if err := CreateSet(setType, templateTypes); err != nil {
+			errs = append(errs, fmt.Errorf("cannot generate the set of %v: %w", setType.DataType, err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func main() {
@@ -57,19 +57,23 @@ func FormatCode(code []byte) ([]byte, error) {
 }
 
 func CreateSetFileFromTemplate(setType SetType, templateType TemplateType) error {
-	template, err := GetTemplate(templateType.TemplateFilename)
+	template, err := GetTemplate(templateType.TemplateDir, templateType.TemplateFilename)
 	if err != nil {
 		return err
 	}
-	targetFilename, err := makeFilename(setType.TitleName, templateType.OutFilename)
+	targetFilename, err := makeFilename(setType, templateType.OutFilename)
 	if err != nil {
 		return err
 	}
-	return CreateTemplate(setType, template, targetFilename)
+	header := fmt.Sprintf(GENERATED_HEADER, setType.DataType) + "\n\n"
+	return CreateTemplate(setType, append([]byte(header), template...), targetFilename)
 }
 
 func CreateSet(setType SetType, templateTypes []TemplateType) error {
 	for _, templateType := range templateTypes {
+		if !templateType.Supports(setType) {
+			continue
+		}
 		err := CreateSetFileFromTemplate(setType, templateType)
 		if err != nil {
 			return err
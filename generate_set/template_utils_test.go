@@ -1,6 +1,10 @@
 package main
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -97,3 +101,364 @@ func TestMakeSetTypeTitleName(t *testing.T) {
 		}
 	}
 }
+
+func TestSetTypeKind(t *testing.T) {
+	var testCases = []struct {
+		given           string
+		expectedKind    string
+		expectedBitSize int
+	}{
+		{
+			given:           "bool",
+			expectedKind:    KIND_BOOL,
+			expectedBitSize: 0,
+		},
+		{
+			given:           "int",
+			expectedKind:    KIND_INT,
+			expectedBitSize: 0,
+		},
+		{
+			given:           "int16",
+			expectedKind:    KIND_INT,
+			expectedBitSize: 16,
+		},
+		{
+			given:           "uint8",
+			expectedKind:    KIND_UINT,
+			expectedBitSize: 8,
+		},
+		{
+			given:           "float32",
+			expectedKind:    KIND_FLOAT,
+			expectedBitSize: 32,
+		},
+		{
+			given:           "string",
+			expectedKind:    KIND_STRING,
+			expectedBitSize: 0,
+		},
+		{
+			given:           "time.Time",
+			expectedKind:    KIND_TIME,
+			expectedBitSize: 0,
+		},
+		{
+			given:           "thing.Thing",
+			expectedKind:    KIND_OTHER,
+			expectedBitSize: 0,
+		},
+	}
+
+	for i, testCase := range testCases {
+		setType := NewSetType(testCase.given, "", "")
+		if kind := setType.Kind(); testCase.expectedKind != kind {
+			t.Error("test", i, "given", testCase.given, "expected kind", testCase.expectedKind, "result", kind)
+		}
+		if bitSize := setType.BitSize(); testCase.expectedBitSize != bitSize {
+			t.Error("test", i, "given", testCase.given, "expected bit size", testCase.expectedBitSize, "result", bitSize)
+		}
+	}
+}
+
+func TestTemplateTypeSupports(t *testing.T) {
+	var testCases = []struct {
+		givenKinds []string
+		givenType  string
+		expected   bool
+	}{
+		{
+			givenKinds: nil,
+			givenType:  "string",
+			expected:   true,
+		},
+		{
+			givenKinds: []string{KIND_INT, KIND_UINT},
+			givenType:  "uint16",
+			expected:   true,
+		},
+		{
+			givenKinds: []string{KIND_INT, KIND_UINT},
+			givenType:  "float64",
+			expected:   false,
+		},
+	}
+
+	for i, testCase := range testCases {
+		templateType := NewTemplateType("", "", testCase.givenKinds...)
+		result := templateType.Supports(NewSetType(testCase.givenType, "", ""))
+		if testCase.expected != result {
+			t.Error("test", i, "given", testCase.givenKinds, "and", testCase.givenType, "expected", testCase.expected, "result", result)
+		}
+	}
+
+	skipped := NewSetType("int", "", "0")
+	skipped.SkipTests = true
+	if NewTemplateType(SET_TEST_TEMPLATE, SET_TEST_FILENAME).Supports(skipped) {
+		t.Error("expected test templates to be skipped for a set type skipping tests")
+	}
+	if !NewTemplateType(SET_TEMPLATE, SET_FILENAME).Supports(skipped) {
+		t.Error("expected other templates to be kept for a set type skipping tests")
+	}
+}
+
+func TestSetTypeSampleValues(t *testing.T) {
+	for _, kind := range TESTED_KINDS {
+		values := SAMPLE_VALUES[kind]
+		if len(values) < 2 {
+			t.Error("kind", kind, "expected at least 2 sample values, got", values)
+		}
+		seen := map[string]bool{}
+		for _, v := range values {
+			if seen[v] {
+				t.Error("kind", kind, "repeats sample value", v)
+			}
+			seen[v] = true
+		}
+	}
+
+	if values := NewSetType("image.Point", "image", "image.Point{}").SampleValues(); values != nil {
+		t.Error("expected no sample values for other kinds, got", values)
+	}
+	if values := NewSetType("int16", "", "0").SampleValues(); len(values) != len(SAMPLE_VALUES[KIND_INT]) {
+		t.Error("expected the int sample values for int16, got", values)
+	}
+}
+
+func TestSetTypeValidate(t *testing.T) {
+	var testCases = []struct {
+		givenPackage    string
+		givenFilePrefix string
+		givenOutDir     string
+		expectedValid   bool
+	}{
+		{givenPackage: "orders", givenFilePrefix: "order", givenOutDir: "internal/orders", expectedValid: true},
+		{givenPackage: "mapsetint", givenFilePrefix: "int-set.v2", givenOutDir: "sets/int_set", expectedValid: true},
+		{givenPackage: "1orders", givenFilePrefix: "order", givenOutDir: "internal/orders", expectedValid: false},
+		{givenPackage: "func", givenFilePrefix: "order", givenOutDir: "internal/orders", expectedValid: false},
+		{givenPackage: "_", givenFilePrefix: "order", givenOutDir: "internal/orders", expectedValid: false},
+		{givenPackage: "orders", givenFilePrefix: "../order", givenOutDir: "internal/orders", expectedValid: false},
+		{givenPackage: "orders", givenFilePrefix: "", givenOutDir: "internal/orders", expectedValid: false},
+		{givenPackage: "orders", givenFilePrefix: "order", givenOutDir: "", expectedValid: false},
+	}
+
+	for i, testCase := range testCases {
+		setType := NewSetType("Order", "", "Order{}")
+		setType.PackageName = testCase.givenPackage
+		setType.FilePrefix = testCase.givenFilePrefix
+		setType.OutDir = testCase.givenOutDir
+		err := setType.Validate()
+		if (err == nil) != testCase.expectedValid {
+			t.Error("test", i, "given", testCase.givenPackage, testCase.givenFilePrefix, testCase.givenOutDir, "expected valid", testCase.expectedValid, "result", err)
+		}
+	}
+}
+
+func TestCheckCollisions(t *testing.T) {
+	templateTypes := []TemplateType{
+		NewTemplateType("", SET_FILENAME),
+		NewTemplateType("", COMPACT_FILENAME, KIND_INT),
+	}
+	newSetType := func(dataType, dir, prefix string) SetType {
+		setType := NewSetType(dataType, "", "0")
+		setType.OutDir = dir
+		setType.PackageName = "orders"
+		setType.FilePrefix = prefix
+		return setType
+	}
+	writeFile := func(dir, name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	generated := func(dataType string) string {
+		return fmt.Sprintf(GENERATED_HEADER, dataType) + "\n\npackage orders\n"
+	}
+
+	var testCases = []struct {
+		name           string
+		givenFiles     map[string]string
+		givenTypes     func(dir string) []SetType
+		expectedErrors int
+	}{
+		{
+			name:       "empty directory",
+			givenTypes: func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+		},
+		{
+			name: "regenerating with other files of the package",
+			givenFiles: map[string]string{
+				"int_set.go":    generated("int"),
+				"order.go":      "package orders\n",
+				"order_test.go": "package orders_test\n",
+			},
+			givenTypes: func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+		},
+		{
+			name: "two types in one directory",
+			givenTypes: func(dir string) []SetType {
+				return []SetType{newSetType("int", dir, "int"), newSetType("uint", dir, "uint")}
+			},
+			expectedErrors: 1,
+		},
+		{
+			name:           "another generated type in the directory",
+			givenFiles:     map[string]string{"uint_set.go": generated("uint")},
+			givenTypes:     func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+			expectedErrors: 1,
+		},
+		{
+			name:           "the same type under another prefix",
+			givenFiles:     map[string]string{"ints_set.go": generated("int")},
+			givenTypes:     func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+			expectedErrors: 1,
+		},
+		{
+			name:           "a hand-written file in the way",
+			givenFiles:     map[string]string{"int_set.go": "package orders\n"},
+			givenTypes:     func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+			expectedErrors: 1,
+		},
+		{
+			name:           "another package in the directory",
+			givenFiles:     map[string]string{"other.go": "package other\n"},
+			givenTypes:     func(dir string) []SetType { return []SetType{newSetType("int", dir, "int")} },
+			expectedErrors: 1,
+		},
+		{
+			name: "every collision at once",
+			givenFiles: map[string]string{
+				"int_set.go":  "package orders\n",
+				"uint_set.go": generated("uint"),
+				"other.go":    "package other\n",
+				"more.go":     "package other\n",
+			},
+			givenTypes: func(dir string) []SetType {
+				return []SetType{newSetType("int", dir, "int"), newSetType("uint", dir, "uint")}
+			},
+			expectedErrors: 4,
+		},
+	}
+
+	for _, testCase := range testCases {
+		dir := t.TempDir()
+		for name, src := range testCase.givenFiles {
+			writeFile(dir, name, src)
+		}
+		err := CheckCollisions(testCase.givenTypes(dir), templateTypes)
+		errs := []error{}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+		if len(errs) != testCase.expectedErrors {
+			t.Error("test", testCase.name, "expected errors", testCase.expectedErrors, "result", err)
+		}
+	}
+}
+
+func TestGetTemplate(t *testing.T) {
+	for _, templateType := range MakeTemplateTypes() {
+		b, err := GetTemplate("", templateType.TemplateFilename)
+		if err != nil || len(b) == 0 {
+			t.Error("template", templateType.TemplateFilename, "expected to be embedded, got", len(b), "bytes and", err)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, SET_TEMPLATE), []byte("package overridden\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := GetTemplate(dir, SET_TEMPLATE); err != nil || string(b) != "package overridden\n" {
+		t.Error("expected the template in the directory to override the embedded one, got", string(b), err)
+	}
+	embedded, _ := GetTemplate("", PAIR_TEMPLATE)
+	if b, err := GetTemplate(dir, PAIR_TEMPLATE); err != nil || string(b) != string(embedded) {
+		t.Error("expected a template missing from the directory to fall back to the embedded one, got", err)
+	}
+	if _, err := GetTemplate("", "missing.gotemplate"); err == nil {
+		t.Error("expected an error for a missing template")
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest := func(src string) string {
+		path := filepath.Join(dir, "sets.json")
+		if err := os.WriteFile(path, []byte(src), 0666); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	manifest, err := LoadManifest(writeManifest(`{
+		"template_dir": "templates",
+		"sets": [
+			{
+				"struct_name": "Order",
+				"default_value": "Order{}",
+				"out_dir": "orders",
+				"package": "orders",
+				"file_prefix": "order",
+				"skip_tests": true,
+				"variants": [{"kind": "pointer", "out_dir": "orderptrs", "package": "orderptrs"}, {"kind": "channel"}]
+			},
+			{"struct_name": "int", "default_value": "0"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.TemplateDir != filepath.Join(dir, "templates") {
+		t.Error("expected the template dir relative to the manifest, got", manifest.TemplateDir)
+	}
+	setTypes, err := manifest.SetTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected = []SetType{
+		{DataType: "Order", TitleName: "Order", DefaultValue: "Order{}", PackageName: "orders", OutDir: filepath.Join(dir, "orders"), FilePrefix: "order", SkipTests: true},
+		{DataType: "*Order", TitleName: "PointerOfOrder", DefaultValue: "nil", PackageName: "orderptrs", OutDir: filepath.Join(dir, "orderptrs"), FilePrefix: "pointeroforder", SkipTests: true},
+		{DataType: "chan Order", TitleName: "ChannelOfOrder", DefaultValue: "nil", PackageName: "mapsetchanneloforder", OutDir: filepath.Join(dir, "sets/channeloforder_set"), FilePrefix: "channeloforder", SkipTests: true},
+		{DataType: "int", TitleName: "Int", DefaultValue: "0", PackageName: "mapsetint", OutDir: filepath.Join(dir, "sets/int_set"), FilePrefix: "int"},
+	}
+	if len(setTypes) != len(expected) {
+		t.Fatal("expected", len(expected), "set types, got", setTypes)
+	}
+	for i := range expected {
+		if setTypes[i] != expected[i] {
+			t.Error("set type", i, "expected", expected[i], "result", setTypes[i])
+		}
+	}
+
+	manifest, err = LoadManifest(writeManifest(`{
+		"sets": [
+			{"struct_name": "Order", "default_value": "Order{}", "package": "bad-name"},
+			{"default_value": "0"},
+			{"struct_name": "int", "default_value": "0", "variants": [{"kind": "slice"}, {"kind": "pointer"}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	setTypes, err = manifest.SetTypes()
+	if err == nil {
+		t.Fatal("expected errors for the invalid entries")
+	}
+	for _, entry := range []string{"sets[0] (Order)", "sets[1]", "sets[2] (int) variants[0]"} {
+		if !strings.Contains(err.Error(), "in "+entry+": ") {
+			t.Error("expected an error for", entry, "got", err)
+		}
+	}
+	if len(setTypes) != 2 {
+		t.Error("expected the valid int set and its pointer variant, got", setTypes)
+	}
+
+	if _, err := LoadManifest(writeManifest(`{"sets": [], "tempalte_dir": "templates"}`)); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+	if _, err := LoadManifest(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing manifest")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"go/token"
 	"strings"
 )
 
@@ -10,19 +11,76 @@ type SetType struct {
 	TitleName    string
 	ImportPath   string
 	DefaultValue string
+
+	// PackageName is the package clause of the generated files.
+	PackageName string
+	// OutDir is the directory the files are written to. Relative
+	// directories are relative to the working directory.
+	OutDir string
+	// FilePrefix starts the name of every generated file.
+	FilePrefix string
+	// SkipTests leaves out the generated tests, fuzz targets and
+	// benchmarks.
+	SkipTests bool
 }
 
 func (s1 SetType) Equal(s2 SetType) bool {
 	return s1.DataType == s2.DataType && s1.TitleName == s2.TitleName && s1.ImportPath == s2.ImportPath
 }
 
+// Kind groups the data type into one of the KIND_* families, which the
+// templates use to pick type-specific encodings. Unknown types are KIND_OTHER.
+func (s SetType) Kind() string {
+	if kind, ok := DATA_TYPE_KINDS[s.DataType]; ok {
+		return kind
+	}
+	return KIND_OTHER
+}
+
+// BitSize is the size of numeric data types in bits, as expected by strconv
+// and math. It is 0 for int and uint, which are platform-sized.
+func (s SetType) BitSize() int {
+	return DATA_TYPE_BIT_SIZES[s.DataType]
+}
+
+// SampleValues returns Go expressions for distinct values of the data type,
+// which the generated tests fill sets with. It is nil for KIND_OTHER, whose
+// values the generator cannot know, and no tests are generated for it.
+func (s SetType) SampleValues() []string {
+	return SAMPLE_VALUES[s.Kind()]
+}
+
+// Validate checks that the package name is a Go identifier and that the
+// file prefix makes plain file names.
+func (s SetType) Validate() error {
+	if !token.IsIdentifier(s.PackageName) || s.PackageName == "_" {
+		return NewInvalidFlagError("package", s.PackageName, "not a Go identifier")
+	}
+	if !FILE_PREFIX_REGEXP.MatchString(s.FilePrefix) {
+		return NewInvalidFlagError("file_prefix", s.FilePrefix, "only letters, digits, '_', '-' and '.' are allowed, starting with a letter or digit")
+	}
+	if s.OutDir == "" {
+		return NewEmptyFlagError("out_dir")
+	}
+	return nil
+}
+
+// NewSetType creates a SetType written to BASE_FILEPATH as package
+// mapset<lowercase title name>, the defaults of generate_set.
 func NewSetType(dataType, importPath, defaultValue string) SetType {
-	titleName := makeSetTypeTitleName(dataType)
+	return newSetTypeWithTitleName(dataType, makeSetTypeTitleName(dataType), importPath, defaultValue)
+}
+
+func newSetTypeWithTitleName(dataType, titleName, importPath, defaultValue string) SetType {
+	lowerTitleName := strings.ToLower(titleName)
 	return SetType{
 		DataType:     dataType,
 		TitleName:    titleName,
 		ImportPath:   importPath,
 		DefaultValue: defaultValue,
+		PackageName:  "mapset" + lowerTitleName,
+		OutDir:       fmt.Sprintf(BASE_FILEPATH, lowerTitleName),
+		FilePrefix:   lowerTitleName,
 	}
 }
 
@@ -50,20 +108,86 @@ func MakeDefaultSetTypes() []SetType {
 type TemplateType struct {
 	TemplateFilename string
 	OutFilename      string
+	Kinds            []string
+
+	// TemplateDir may hold a file overriding the embedded template; see
+	// GetTemplate.
+	TemplateDir string
 }
 
-func NewTemplateType(templateFilename, outFilename string) TemplateType {
+// NewTemplateType creates a TemplateType. If any kinds are given, the
+// template is only rendered for set types of those kinds.
+func NewTemplateType(templateFilename, outFilename string, kinds ...string) TemplateType {
 	return TemplateType{
 		TemplateFilename: templateFilename,
 		OutFilename:      outFilename,
+		Kinds:            kinds,
+	}
+}
+
+// IsTest says whether the template renders a _test.go file.
+func (t TemplateType) IsTest() bool {
+	return strings.HasSuffix(t.OutFilename, "_test.go")
+}
+
+// Supports says whether the template should be rendered for setType.
+func (t TemplateType) Supports(setType SetType) bool {
+	if setType.SkipTests && t.IsTest() {
+		return false
+	}
+	if len(t.Kinds) == 0 {
+		return true
+	}
+	kind := setType.Kind()
+	for _, k := range t.Kinds {
+		if k == kind {
+			return true
+		}
 	}
+	return false
 }
 
 func MakeTemplateTypes() []TemplateType {
 	return []TemplateType{
+		NewTemplateType(BENCH_TEST_TEMPLATE, BENCH_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(BINARY_TEMPLATE, BINARY_FILENAME),
+		NewTemplateType(BINARY_TEST_TEMPLATE, BINARY_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(COMPACT_TEMPLATE, COMPACT_FILENAME, KIND_INT, KIND_UINT),
+		NewTemplateType(COMPACT_TEST_TEMPLATE, COMPACT_TEST_FILENAME, KIND_INT, KIND_UINT),
+		NewTemplateType(CONTEXT_TEMPLATE, CONTEXT_FILENAME),
+		NewTemplateType(CONTEXT_TEST_TEMPLATE, CONTEXT_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(CSV_TEMPLATE, CSV_FILENAME),
+		NewTemplateType(CSV_TEST_TEMPLATE, CSV_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(FUZZ_TEST_TEMPLATE, FUZZ_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(HYBRID_TEMPLATE, HYBRID_FILENAME),
+		NewTemplateType(HYBRID_TEST_TEMPLATE, HYBRID_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(INTO_TEMPLATE, INTO_FILENAME),
+		NewTemplateType(INTO_TEST_TEMPLATE, INTO_TEST_FILENAME, TESTED_KINDS...),
 		NewTemplateType(ITERATOR_TEMPLATE, ITERATOR_FILENAME),
+		NewTemplateType(JSON_TEMPLATE, JSON_FILENAME),
+		NewTemplateType(JSON_TEST_TEMPLATE, JSON_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(MISUSE_TEMPLATE, MISUSE_FILENAME),
+		NewTemplateType(MISUSE_DEBUG_TEMPLATE, MISUSE_DEBUG_FILENAME),
+		NewTemplateType(MULTI_TEMPLATE, MULTI_FILENAME),
+		NewTemplateType(MULTI_TEST_TEMPLATE, MULTI_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(PAIR_TEMPLATE, PAIR_FILENAME),
 		NewTemplateType(SET_TEMPLATE, SET_FILENAME),
+		NewTemplateType(SET_TEST_TEMPLATE, SET_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(SIMILARITY_TEMPLATE, SIMILARITY_FILENAME),
+		NewTemplateType(SIMILARITY_TEST_TEMPLATE, SIMILARITY_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(SKETCH_TEMPLATE, SKETCH_FILENAME),
+		NewTemplateType(SKETCH_TEST_TEMPLATE, SKETCH_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(SORT_TEMPLATE, SORT_FILENAME),
+		NewTemplateType(SORT_TEST_TEMPLATE, SORT_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(SQL_TEMPLATE, SQL_FILENAME),
+		NewTemplateType(SQL_TEST_TEMPLATE, SQL_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(STATS_TEMPLATE, STATS_FILENAME),
+		NewTemplateType(STATS_TEST_TEMPLATE, STATS_TEST_FILENAME, TESTED_KINDS...),
+		NewTemplateType(TEXT_TEMPLATE, TEXT_FILENAME),
+		NewTemplateType(TEXT_TEST_TEMPLATE, TEXT_TEST_FILENAME, TESTED_KINDS...),
 		NewTemplateType(THREADSAFE_TEMPLATE, THREADSAFE_FILENAME),
 		NewTemplateType(THREADUNSAFE_TEMPLATE, THREADUNSAFE_FILENAME),
+		NewTemplateType(XML_TEMPLATE, XML_FILENAME),
+		NewTemplateType(XML_TEST_TEMPLATE, XML_TEST_FILENAME, TESTED_KINDS...),
 	}
 }
@@ -0,0 +1,218 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeSet()
+		return &s
+	},
+}
+
+// NewScratchSet returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchSet() Set {
+	return scratchPool.Get().(*threadUnsafeSet)
+}
+
+// Release empties s and makes it available to NewScratchSet. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Set) {
+	u, ok := s.(*threadUnsafeSet)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Set) {
+	d, x, y, locks := lockInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAll(d, y)
+	case dst == b:
+		addAll(d, x)
+	default:
+		clear(d)
+		addAll(d, x)
+		addAll(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Set) {
+	d, x, y, locks := lockInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnly(d, y)
+	case dst == b:
+		keepOnly(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Set) {
+	d, x, y, locks := lockInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchSet().(*threadUnsafeSet)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAll(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAll(dst, src threadUnsafeSet) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnly removes the elements of dst that are not in other.
+func keepOnly(dst, other threadUnsafeSet) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoLocks records the locks taken by lockInto, or the guards for
+// thread-unsafe sets.
+type intoLocks struct {
+	sets     [3]*threadSafeSet
+	distinct int
+	dst      *threadSafeSet
+	guards   [3]unsafeGuard
+}
+
+// lockInto locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockInto(dst, a, b Set) (d, x, y threadUnsafeSet, locks intoLocks) {
+	w, ok := dst.(*threadSafeSet)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeSet)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeSet).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeSet).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeSet), *b.(*threadUnsafeSet), locks
+	}
+
+	locks.sets = [3]*threadSafeSet{w, a.(*threadSafeSet), b.(*threadSafeSet)}
+	locks.distinct = len(lockOrder(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeSet).s, b.(*threadSafeSet).s, locks
+}
+
+func (l intoLocks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
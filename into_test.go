@@ -0,0 +1,121 @@
+package mapset
+
+import (
+	"sync"
+	"testing"
+)
+
+func Test_IntoOperations(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Set)
+		expected []interface{}
+	}{
+		{"UnionInto", UnionInto, []interface{}{1, 2, 3, 4}},
+		{"IntersectInto", IntersectInto, []interface{}{2, 3}},
+		{"DifferenceInto", DifferenceInto, []interface{}{1}},
+	}
+
+	for _, testCase := range testCases {
+		for _, newSet := range []func(...interface{}) Set{NewSet, func(elems ...interface{}) Set { return NewThreadUnsafeSetFromSlice(elems) }} {
+			expected := newSet(testCase.expected...)
+
+			dst := newSet(99)
+			testCase.into(dst, newSet(1, 2, 3), newSet(2, 3, 4))
+			if !dst.Equal(expected) {
+				t.Errorf("%s: expected %v, got %v", testCase.name, expected, dst)
+			}
+
+			a := newSet(1, 2, 3)
+			testCase.into(a, a, newSet(2, 3, 4))
+			if !a.Equal(expected) {
+				t.Errorf("%s into a: expected %v, got %v", testCase.name, expected, a)
+			}
+
+			b := newSet(2, 3, 4)
+			testCase.into(b, newSet(1, 2, 3), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s into b: expected %v, got %v", testCase.name, expected, b)
+			}
+		}
+	}
+
+	s := NewSet(1, 2)
+	DifferenceInto(s, s, s)
+	if s.Cardinality() != 0 {
+		t.Errorf("expected the difference of a set with itself to be empty, got %v", s)
+	}
+	s = NewSet(1, 2)
+	UnionInto(s, s, s)
+	IntersectInto(s, s, s)
+	if !s.Equal(NewSet(1, 2)) {
+		t.Errorf("expected union and intersection with itself to be unchanged, got %v", s)
+	}
+}
+
+func Test_IntoDoesNotAllocate(t *testing.T) {
+	a := NewThreadUnsafeSetFromSlice([]interface{}{1, 2, 3})
+	b := NewThreadUnsafeSetFromSlice([]interface{}{2, 3, 4})
+	dst := NewThreadUnsafeSetWithCapacity(8)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		UnionInto(dst, a, b)
+		IntersectInto(dst, a, b)
+		DifferenceInto(dst, a, b)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func Test_ScratchSet(t *testing.T) {
+	s := NewScratchSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(1)
+	Release(s)
+	Release(NewSet(1))
+
+	if again := NewScratchSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
+
+func Test_IntoConcurrent(t *testing.T) {
+	sets := []Set{NewSet(1, 2), NewSet(2, 3), NewSet(3, 4)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Rotate the roles so that every set is written and read.
+			dst, a, b := sets[i%3], sets[(i+1)%3], sets[(i+2)%3]
+			UnionInto(dst, a, b)
+			IntersectInto(a, a, b)
+			DifferenceInto(b, dst, b)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func Test_SinglePassOperations(t *testing.T) {
+	for _, newSet := range []func(...interface{}) Set{NewSet, func(elems ...interface{}) Set { return NewThreadUnsafeSetFromSlice(elems) }} {
+		a := newSet(1, 2, 3)
+		b := newSet(1, 2, 3, 4)
+
+		if !a.IsProperSubset(b) || b.IsProperSubset(a) || a.IsProperSubset(a) {
+			t.Error("unexpected IsProperSubset result")
+		}
+		if !b.IsProperSuperset(a) || a.IsProperSuperset(b) || b.IsProperSuperset(b) {
+			t.Error("unexpected IsProperSuperset result")
+		}
+		if newSet(1, 5).IsProperSubset(b) {
+			t.Error("expected a set with an element outside b not to be a proper subset")
+		}
+		if sd := a.SymmetricDifference(newSet(3, 4)); !sd.Equal(newSet(1, 2, 4)) {
+			t.Errorf("expected a symmetric difference of 1, 2 and 4, got %v", sd)
+		}
+	}
+}
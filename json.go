@@ -0,0 +1,265 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// JSONNumberMode selects the type that JSON numbers are decoded into.
+type JSONNumberMode int
+
+const (
+	// JSONNumberAsNumber keeps numbers as json.Number. This is the
+	// default, and the behaviour of UnmarshalJSON.
+	JSONNumberAsNumber JSONNumberMode = iota
+
+	// JSONNumberAsInt decodes numbers as int, failing on numbers that
+	// are not integers.
+	JSONNumberAsInt
+
+	// JSONNumberAsInt64 decodes numbers as int64, failing on numbers
+	// that are not integers.
+	JSONNumberAsInt64
+
+	// JSONNumberAsFloat64 decodes numbers as float64.
+	JSONNumberAsFloat64
+
+	// JSONNumberAsIntOrFloat64 decodes integers as int and all other
+	// numbers as float64.
+	JSONNumberAsIntOrFloat64
+)
+
+// JSONDecodeOptions controls how a JSON array is turned into set
+// elements.
+type JSONDecodeOptions struct {
+	// Strict fails on nested arrays and objects, which are otherwise
+	// skipped, and on elements that appear more than once.
+	Strict bool
+
+	// Numbers selects the type of decoded numbers.
+	Numbers JSONNumberMode
+
+	// Decode, if set, converts every element of the array itself,
+	// replacing the handling of numbers, arrays and objects.
+	Decode func(json.RawMessage) (interface{}, error)
+}
+
+// JSONEncodeOptions controls how EncodeJSON writes a set.
+type JSONEncodeOptions struct {
+	// Sorted writes elements in a stable order instead of map order.
+	// Sorting needs a slice holding every element of the set.
+	Sorted bool
+}
+
+// UnmarshalJSONWith adds the elements of the JSON array b to s,
+// decoding them according to opts. Nothing is added on error.
+func UnmarshalJSONWith(b []byte, s Set, opts JSONDecodeOptions) error {
+	elems, err := opts.decodeArray(b)
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func (opts JSONDecodeOptions) decodeArray(b []byte) ([]interface{}, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return nil, err
+	}
+
+	var seen threadUnsafeSet
+	if opts.Strict {
+		seen = newThreadUnsafeSet()
+	}
+
+	elems := make([]interface{}, 0, len(raws))
+	for _, raw := range raws {
+		elem, ok, err := opts.decodeElement(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if opts.Strict && !seen.Add(elem) {
+			return nil, fmt.Errorf("mapset: duplicate JSON element %s", raw)
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// decodeElement decodes a single array element. It reports false for
+// elements that are skipped.
+func (opts JSONDecodeOptions) decodeElement(raw json.RawMessage) (interface{}, bool, error) {
+	if opts.Decode != nil {
+		elem, err := opts.Decode(raw)
+		return elem, err == nil, err
+	}
+
+	var elem interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&elem); err != nil {
+		return nil, false, err
+	}
+
+	switch t := elem.(type) {
+	case []interface{}, map[string]interface{}:
+		if opts.Strict {
+			return nil, false, fmt.Errorf("mapset: unsupported JSON element %s", raw)
+		}
+		return nil, false, nil
+	case json.Number:
+		n, err := opts.Numbers.convert(t)
+		return n, err == nil, err
+	default:
+		return elem, true, nil
+	}
+}
+
+func (mode JSONNumberMode) convert(n json.Number) (interface{}, error) {
+	switch mode {
+	case JSONNumberAsInt:
+		i, err := strconv.ParseInt(string(n), 10, 0)
+		return int(i), err
+	case JSONNumberAsInt64:
+		return n.Int64()
+	case JSONNumberAsFloat64:
+		return n.Float64()
+	case JSONNumberAsIntOrFloat64:
+		if i, err := strconv.ParseInt(string(n), 10, 0); err == nil {
+			return int(i), nil
+		}
+		return n.Float64()
+	default:
+		return n, nil
+	}
+}
+
+// EncodeJSON writes s to w as a JSON array, one element at a time, so
+// that memory use does not grow with the size of the set unless
+// opts.Sorted is set. Encoding stops with ctx.Err() once ctx is done.
+//
+// A thread-safe set stays read-locked while unsorted output is written.
+func EncodeJSON(ctx context.Context, w io.Writer, s Set, opts JSONEncodeOptions) error {
+	bw := bufio.NewWriter(w)
+	if err := bw.WriteByte('['); err != nil {
+		return err
+	}
+
+	first := true
+	var err error
+	write := func(elem interface{}) bool {
+		if err = ctx.Err(); err != nil {
+			return true
+		}
+		if !first {
+			if err = bw.WriteByte(','); err != nil {
+				return true
+			}
+		}
+		first = false
+
+		var b []byte
+		if b, err = json.Marshal(elem); err != nil {
+			return true
+		}
+		_, err = bw.Write(b)
+		return err != nil
+	}
+
+	if opts.Sorted {
+		elems := s.ToSlice()
+		sortElements(elems)
+		for _, elem := range elems {
+			if write(elem) {
+				break
+			}
+		}
+	} else {
+		s.Each(write)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bw.WriteByte(']'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// DecodeJSON reads a JSON array from r and adds its elements to s as
+// they are decoded, according to opts, without buffering the whole
+// input. A JSON null adds nothing. Decoding stops with ctx.Err() once
+// ctx is done; elements decoded before an error stay in s. In strict
+// mode, an element that is already in s counts as a duplicate.
+func DecodeJSON(ctx context.Context, r io.Reader, s Set, opts JSONDecodeOptions) error {
+	d := json.NewDecoder(r)
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("mapset: expected a JSON array, found %v", tok)
+	}
+
+	for d.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			return err
+		}
+		elem, ok, err := opts.decodeElement(raw)
+		if err != nil {
+			return err
+		}
+		if ok && !s.Add(elem) && opts.Strict {
+			return fmt.Errorf("mapset: duplicate JSON element %s", raw)
+		}
+	}
+
+	// Consume the closing bracket.
+	_, err = d.Token()
+	return err
+}
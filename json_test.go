@@ -0,0 +1,201 @@
+package mapset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func Test_UnmarshalJSONWithNumbers(t *testing.T) {
+	var testCases = []struct {
+		mode     JSONNumberMode
+		input    string
+		expected Set
+	}{
+		{JSONNumberAsNumber, `[1, 2.5]`, NewSet(json.Number("1"), json.Number("2.5"))},
+		{JSONNumberAsInt, `[1, -2]`, NewSet(1, -2)},
+		{JSONNumberAsInt64, `[1, -2]`, NewSet(int64(1), int64(-2))},
+		{JSONNumberAsFloat64, `[1, 2.5]`, NewSet(1.0, 2.5)},
+		{JSONNumberAsIntOrFloat64, `[1, 2.5, "a", true, null]`, NewSet(1, 2.5, "a", true, nil)},
+	}
+
+	for i, testCase := range testCases {
+		s := NewSet()
+		if err := UnmarshalJSONWith([]byte(testCase.input), s, JSONDecodeOptions{Numbers: testCase.mode}); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if !s.Equal(testCase.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, testCase.expected, s)
+		}
+	}
+
+	if err := UnmarshalJSONWith([]byte(`[1.5]`), NewSet(), JSONDecodeOptions{Numbers: JSONNumberAsInt}); err == nil {
+		t.Error("expected an error decoding 1.5 as an int")
+	}
+}
+
+func Test_MarshalJSONRoundTripContains(t *testing.T) {
+	b, err := json.Marshal(NewSet(1, 2, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSet()
+	if err := UnmarshalJSONWith(b, s, JSONDecodeOptions{Numbers: JSONNumberAsInt}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Contains(1, 2, 3) {
+		t.Errorf("expected 1, 2 and 3 after a round trip, got %v", s)
+	}
+}
+
+func Test_UnmarshalJSONWithStrict(t *testing.T) {
+	s := NewSet()
+	if err := UnmarshalJSONWith([]byte(`["a", [1], {"b": 2}]`), s, JSONDecodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(NewSet("a")) {
+		t.Errorf("nested elements should be skipped, got %v", s)
+	}
+
+	for _, input := range []string{`["a", [1]]`, `["a", {"b": 2}]`, `["a", "a"]`, `[1, 1.0]`} {
+		s := NewSet("untouched")
+		opts := JSONDecodeOptions{Strict: true, Numbers: JSONNumberAsFloat64}
+		if err := UnmarshalJSONWith([]byte(input), s, opts); err == nil {
+			t.Errorf("expected a strict error for %s", input)
+		}
+		if !s.Equal(NewSet("untouched")) {
+			t.Errorf("set was modified decoding %s: %v", input, s)
+		}
+	}
+}
+
+type upperString string
+
+func Test_UnmarshalJSONWithDecodeHook(t *testing.T) {
+	opts := JSONDecodeOptions{
+		Decode: func(raw json.RawMessage) (interface{}, error) {
+			var str string
+			if err := json.Unmarshal(raw, &str); err != nil {
+				return nil, errors.New("expected a string")
+			}
+			return upperString(strings.ToUpper(str)), nil
+		},
+	}
+
+	s := NewSet()
+	if err := UnmarshalJSONWith([]byte(`["a", "b"]`), s, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(NewSet(upperString("A"), upperString("B"))) {
+		t.Errorf("unexpected set %v", s)
+	}
+	if err := UnmarshalJSONWith([]byte(`["a", 1]`), NewSet(), opts); err == nil {
+		t.Error("expected the hook's error")
+	}
+}
+
+func Test_UnmarshalJSONConcurrent(t *testing.T) {
+	s := NewSet()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := json.Unmarshal([]byte(`["a", "b", 1, 2]`), s); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			s.Contains("a")
+			s.Cardinality()
+		}()
+	}
+	wg.Wait()
+
+	if s.Cardinality() != 4 {
+		t.Errorf("unexpected set %v", s)
+	}
+}
+
+func Test_EncodeJSONSorted(t *testing.T) {
+	s := NewSet("b", 3, "a", true, 1.5, nil, -2)
+
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, s, JSONEncodeOptions{Sorted: true}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != `[null,true,-2,1.5,3,"a","b"]` {
+		t.Errorf("unexpected encoding %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := EncodeJSON(context.Background(), &buf, NewThreadUnsafeSet(), JSONEncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != `[]` {
+		t.Errorf("unexpected encoding %s", buf.String())
+	}
+}
+
+func Test_EncodeDecodeJSONRoundTrip(t *testing.T) {
+	s := NewSet()
+	for i := 0; i < 10000; i++ {
+		s.Add(i)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, s, JSONEncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := NewSet()
+	if err := DecodeJSON(context.Background(), &buf, decoded, JSONDecodeOptions{Numbers: JSONNumberAsInt}); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(decoded) {
+		t.Error("expected the streamed set to round trip")
+	}
+}
+
+func Test_DecodeJSONErrors(t *testing.T) {
+	var testCases = []struct {
+		input string
+		opts  JSONDecodeOptions
+	}{
+		{`{"a": 1}`, JSONDecodeOptions{}},
+		{`[1, 2`, JSONDecodeOptions{}},
+		{`[1, 1]`, JSONDecodeOptions{Strict: true}},
+		{`[[1]]`, JSONDecodeOptions{Strict: true}},
+	}
+
+	for i, testCase := range testCases {
+		if err := DecodeJSON(context.Background(), strings.NewReader(testCase.input), NewSet(), testCase.opts); err == nil {
+			t.Errorf("test %d: expected an error decoding %s", i, testCase.input)
+		}
+	}
+
+	s := NewSet()
+	if err := DecodeJSON(context.Background(), strings.NewReader(`null`), s, JSONDecodeOptions{}); err != nil || s.Cardinality() != 0 {
+		t.Errorf("expected null to decode as no elements, got %v, %v", s, err)
+	}
+}
+
+func Test_EncodeDecodeJSONCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	if err := EncodeJSON(ctx, &buf, NewSet(1, 2), JSONEncodeOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled encoding, got %v", err)
+	}
+	if err := DecodeJSON(ctx, strings.NewReader(`[1, 2]`), NewSet(), JSONDecodeOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled decoding, got %v", err)
+	}
+}
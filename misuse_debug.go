@@ -0,0 +1,179 @@
+//go:build mapsetdebug
+
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Building with the mapsetdebug tag checks every thread-unsafe set made
+// by NewThreadUnsafeSet and its variants for use from several goroutines
+// at once. Instead of the runtime's bare "concurrent map writes", misuse
+// panics with a message naming where the set was created:
+//
+//	go test -tags mapsetdebug ./...
+//
+// The checks are best effort: they catch operations that overlap in
+// time, which is also when the runtime would fail, and cost an atomic
+// operation and a map lookup per call. A goroutine may modify a set
+// from its own Each callback, as ranging over a map allows; only other
+// goroutines are caught.
+
+// setGuard counts the operations in progress on one thread-unsafe set.
+type setGuard struct {
+	readers atomic.Int32
+	writers atomic.Int32
+	stack   []byte
+
+	// iterating counts the Each calls in progress on each goroutine,
+	// which count as readers but may write to the set themselves.
+	mu        sync.Mutex
+	iterating map[int64]int32
+}
+
+// setGuards maps the address of each tracked set to its guard. Keys are
+// not pointers, so the table does not keep sets alive; a finalizer
+// removes the entry once a set is collected.
+var setGuards sync.Map
+
+type unsafeGuard struct {
+	g     *setGuard
+	write bool
+	goid  int64 // the iterating goroutine, for Each
+}
+
+// trackUnsafeSet starts checking set, recording the caller's stack as
+// the place it was created. set must be heap allocated.
+func trackUnsafeSet(set *threadUnsafeSet) {
+	setGuards.Store(uintptr(unsafe.Pointer(set)), &setGuard{stack: debug.Stack()})
+	runtime.SetFinalizer(set, func(set *threadUnsafeSet) {
+		setGuards.Delete(uintptr(unsafe.Pointer(set)))
+	})
+}
+
+func (set *threadUnsafeSet) guard() *setGuard {
+	g, ok := setGuards.Load(uintptr(unsafe.Pointer(set)))
+	if !ok {
+		return nil
+	}
+	return g.(*setGuard)
+}
+
+func (set *threadUnsafeSet) guardRead() unsafeGuard {
+	g := set.guard()
+	if g == nil {
+		return unsafeGuard{}
+	}
+	g.readers.Add(1)
+	if g.writers.Load() != 0 {
+		g.readers.Add(-1)
+		g.misuse("read during a concurrent write")
+	}
+	return unsafeGuard{g: g}
+}
+
+func (set *threadUnsafeSet) guardWrite() unsafeGuard {
+	g := set.guard()
+	if g == nil {
+		return unsafeGuard{}
+	}
+	if !g.writers.CompareAndSwap(0, 1) {
+		g.misuse("concurrent writes")
+	}
+	if readers := g.readers.Load(); readers != 0 && readers > g.iteratingOn(goroutineID()) {
+		g.writers.Add(-1)
+		g.misuse("write during a concurrent read")
+	}
+	return unsafeGuard{g: g, write: true}
+}
+
+// guardIterate is guardRead for Each, whose callback may write to the
+// set from the same goroutine.
+func (set *threadUnsafeSet) guardIterate() unsafeGuard {
+	u := set.guardRead()
+	if u.g == nil {
+		return u
+	}
+	u.goid = goroutineID()
+	u.g.mu.Lock()
+	if u.g.iterating == nil {
+		u.g.iterating = map[int64]int32{}
+	}
+	u.g.iterating[u.goid]++
+	u.g.mu.Unlock()
+	return u
+}
+
+// iteratingOn returns the number of Each calls in progress on goroutine
+// goid.
+func (g *setGuard) iteratingOn(goid int64) int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.iterating[goid]
+}
+
+func (u unsafeGuard) done() {
+	switch {
+	case u.g == nil:
+	case u.write:
+		u.g.writers.Add(-1)
+	default:
+		if u.goid != 0 {
+			u.g.mu.Lock()
+			if u.g.iterating[u.goid]--; u.g.iterating[u.goid] == 0 {
+				delete(u.g.iterating, u.goid)
+			}
+			u.g.mu.Unlock()
+		}
+		u.g.readers.Add(-1)
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, parsed from its
+// stack trace. It is slow, but only needed to tell Each callbacks apart
+// from other goroutines.
+func goroutineID() int64 {
+	var buf [64]byte
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
+
+func (g *setGuard) misuse(what string) {
+	panic(fmt.Sprintf("mapset: %s on a thread-unsafe set; use NewSet for sets shared between goroutines. The set was created at:\n\n%s", what, g.stack))
+}
@@ -0,0 +1,224 @@
+//go:build mapsetdebug
+
+package mapset
+
+import (
+	"strings"
+	"testing"
+)
+
+func expectMisuse(t *testing.T, what string, fn func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		r := recover()
+		msg, ok := r.(string)
+		if !ok {
+			t.Fatalf("expected a misuse panic, got %v", r)
+		}
+		if !strings.Contains(msg, what) {
+			t.Errorf("expected the panic to mention %q, got %q", what, msg)
+		}
+		if !strings.Contains(msg, "newUnsafeSetForMisuse") {
+			t.Errorf("expected the panic to include the creation stack, got %q", msg)
+		}
+	}()
+	fn()
+}
+
+func newUnsafeSetForMisuse() Set {
+	return NewThreadUnsafeSet()
+}
+
+func Test_MisuseWriteDuringRead(t *testing.T) {
+	s := newUnsafeSetForMisuse()
+	s.Add(1)
+
+	held := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Each(func(interface{}) bool {
+			close(held)
+			<-release
+			return true
+		})
+	}()
+	<-held
+
+	expectMisuse(t, "write during a concurrent read", func() { s.Add(2) })
+	close(release)
+	<-done
+
+	// The failed write must not leave the set marked as busy.
+	s.Add(3)
+	if !s.Contains(1, 3) || s.Contains(2) {
+		t.Errorf("expected {1, 3} after the failed write, got %v", s)
+	}
+}
+
+func Test_MisuseStuckIterator(t *testing.T) {
+	s := newUnsafeSetForMisuse()
+	s.Add(1)
+	s.Add(2)
+
+	ch := s.Iter()
+	<-ch
+	expectMisuse(t, "write during a concurrent read", func() { s.Remove(1) })
+	for range ch {
+	}
+}
+
+func Test_MisuseSequentialUse(t *testing.T) {
+	s := NewThreadUnsafeSet()
+	done := make(chan struct{})
+	go func() {
+		s.Add(1)
+		close(done)
+	}()
+	<-done
+
+	// Handing a set over between goroutines is fine.
+	s.Add(2)
+	for range s.Iter() {
+	}
+	s.Each(func(interface{}) bool {
+		return false
+	})
+	s.Remove(1)
+	if !s.Union(s).Equal(s) || s.Cardinality() != 1 {
+		t.Errorf("expected {2}, got %v", s)
+	}
+}
+
+func Test_MisuseWriteInOwnEach(t *testing.T) {
+	s := newUnsafeSetForMisuse()
+	for i := 0; i < 10; i++ {
+		s.Add(i)
+	}
+
+	// Modifying a map while ranging over it is legal on one goroutine.
+	s.Each(func(elem interface{}) bool {
+		s.Remove(elem)
+		return false
+	})
+	if s.Cardinality() != 0 {
+		t.Errorf("expected the callback to remove every element, got %v", s)
+	}
+
+	s.Add(1)
+	s.Each(func(interface{}) bool {
+		s.Each(func(interface{}) bool {
+			s.Add(2)
+			return true
+		})
+		return true
+	})
+	if !s.Contains(1, 2) {
+		t.Errorf("expected a nested callback to add 2, got %v", s)
+	}
+
+	// Another goroutine writing during the callback is still caught.
+	var r interface{}
+	s.Each(func(interface{}) bool {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer func() { r = recover() }()
+			s.Add(3)
+		}()
+		<-done
+		return true
+	})
+	if msg, _ := r.(string); !strings.Contains(msg, "write during a concurrent read") {
+		t.Errorf("expected a misuse panic from the other goroutine, got %v", r)
+	}
+	if s.Contains(3) {
+		t.Errorf("expected the write from another goroutine to fail, got %v", s)
+	}
+}
+
+func Test_MisuseMultiResult(t *testing.T) {
+	a := NewThreadUnsafeSetFromSlice([]interface{}{1, 2, 3, 5})
+	b := NewThreadUnsafeSetFromSlice([]interface{}{2, 3, 4})
+	for name, s := range map[string]Set{
+		"UnionAll":      UnionAll(a, b),
+		"IntersectAll":  IntersectAll(a, b),
+		"DifferenceAll": DifferenceAll(a, b),
+	} {
+		ch := s.Iter()
+		<-ch
+		func() {
+			defer func() {
+				if msg, _ := recover().(string); !strings.Contains(msg, "write during a concurrent read") {
+					t.Errorf("%s: expected a misuse panic, got %q", name, msg)
+				}
+			}()
+			s.Add(6)
+		}()
+		for range ch {
+		}
+	}
+}
+
+func Test_MisuseReadsOfSeveralSets(t *testing.T) {
+	s := newUnsafeSetForMisuse()
+	s.Add(1)
+	other := NewThreadUnsafeSetFromSlice([]interface{}{1, 2})
+
+	// Each operation takes other before s, so a failure must also
+	// release other.
+	for name, fn := range map[string]func(){
+		"UnionAll":                func() { UnionAll(other, s) },
+		"IntersectAll":            func() { IntersectAll(other, s) },
+		"DifferenceAll":           func() { DifferenceAll(other, s) },
+		"ParallelIntersect":       func() { ParallelIntersect(other, s, 2) },
+		"ParallelDifference":      func() { ParallelDifference(other, s, 2) },
+		"Partition":               func() { Partition(s, 2) },
+		"SymmetricDifferenceSize": func() { SymmetricDifferenceSize(other, s) },
+		"ToBloomFilter":           func() { ToBloomFilter(s, 0.01) },
+		"UnionInto":               func() { UnionInto(NewThreadUnsafeSet(), other, s) },
+		"MarshalBinary":           func() { s.(*threadUnsafeSet).MarshalBinary() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := s.(*threadUnsafeSet).guardWrite()
+			expectMisuse(t, "read during a concurrent write", fn)
+			w.done()
+			other.Add(3)
+			s.Add(3)
+		})
+	}
+}
+
+func Test_MisuseDecodeDuringRead(t *testing.T) {
+	s := newUnsafeSetForMisuse()
+	s.Add(1)
+	s.Add(2)
+	other := NewThreadUnsafeSetFromSlice([]interface{}{3})
+	encoded, err := other.(*threadUnsafeSet).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ch := s.Iter()
+	<-ch
+	for name, fn := range map[string]func(){
+		"UnmarshalBinary": func() { s.(*threadUnsafeSet).UnmarshalBinary(encoded) },
+		"UnmarshalText":   func() { s.(*threadUnsafeSet).UnmarshalText([]byte("3")) },
+		"Scan":            func() { s.(*threadUnsafeSet).Scan("{3}") },
+		"UnionInto":       func() { UnionInto(s, other, other) },
+		"Release":         func() { Release(s) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			expectMisuse(t, "write during a concurrent read", fn)
+		})
+	}
+	for range ch {
+	}
+
+	if !s.Equal(NewThreadUnsafeSetFromSlice([]interface{}{1, 2})) {
+		t.Errorf("expected the failed writes to leave {1, 2}, got %v", s)
+	}
+	other.Add(4)
+}
@@ -0,0 +1,133 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Set) Set {
+	if len(sets) == 0 {
+		return NewSet()
+	}
+	maps, unlock := readLockSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Set) Set {
+	if len(sets) == 0 {
+		return NewSet()
+	}
+	maps, unlock := readLockSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeSet()
+	for elem := range maps[smallest] {
+		if containedInAll(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Set, sets ...Set) Set {
+	maps, unlock := readLockSets(append([]Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeSet()
+	for elem := range maps[0] {
+		if !containedInAny(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapLike(base, difference)
+}
+
+func containedInAll(maps []threadUnsafeSet, skip int, elem interface{}) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAny(maps []threadUnsafeSet, elem interface{}) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapLike returns s as a Set of the same implementation as like.
+func wrapLike(like Set, s threadUnsafeSet) Set {
+	if _, ok := like.(*threadSafeSet); ok {
+		return &threadSafeSet{s: s}
+	}
+	trackUnsafeSet(&s)
+	return &s
+}
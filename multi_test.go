@@ -0,0 +1,106 @@
+package mapset
+
+import (
+	"sync"
+	"testing"
+)
+
+func Test_UnionAll(t *testing.T) {
+	a := NewSet(1, 2)
+	b := NewSet(2, 3)
+	c := NewSet(4)
+
+	u := UnionAll(a, b, c)
+	if !u.Equal(NewSet(1, 2, 3, 4)) {
+		t.Errorf("expected the union of all sets, got %v", u)
+	}
+	if _, ok := u.(*threadSafeSet); !ok {
+		t.Error("expected a thread-safe result for thread-safe inputs")
+	}
+
+	if UnionAll().Cardinality() != 0 {
+		t.Error("expected an empty union of no sets")
+	}
+	if !UnionAll(a, a).Equal(a) {
+		t.Error("expected a repeated set to be locked once and unioned with itself")
+	}
+}
+
+func Test_IntersectAll(t *testing.T) {
+	a := NewThreadUnsafeSet()
+	b := NewThreadUnsafeSet()
+	c := NewThreadUnsafeSet()
+	for i := 0; i < 10; i++ {
+		a.Add(i)
+		if i%2 == 0 {
+			b.Add(i)
+		}
+		if i%3 == 0 {
+			c.Add(i)
+		}
+	}
+
+	i := IntersectAll(a, b, c)
+	if !i.Equal(NewThreadUnsafeSetFromSlice([]interface{}{0, 6})) {
+		t.Errorf("expected the intersection of all sets, got %v", i)
+	}
+	if _, ok := i.(*threadUnsafeSet); !ok {
+		t.Error("expected a thread-unsafe result for thread-unsafe inputs")
+	}
+
+	if IntersectAll(a, NewThreadUnsafeSet()).Cardinality() != 0 {
+		t.Error("expected an empty intersection with an empty set")
+	}
+	if !IntersectAll(a).Equal(a) {
+		t.Error("expected the intersection of one set to equal it")
+	}
+}
+
+func Test_DifferenceAll(t *testing.T) {
+	base := NewSet(1, 2, 3, 4, 5)
+
+	d := DifferenceAll(base, NewSet(1), NewSet(2, 6), NewSet(5))
+	if !d.Equal(NewSet(3, 4)) {
+		t.Errorf("expected 3 and 4 to remain, got %v", d)
+	}
+	if !DifferenceAll(base).Equal(base) {
+		t.Error("expected the difference with no sets to equal the base")
+	}
+	if DifferenceAll(base, base).Cardinality() != 0 {
+		t.Error("expected the difference with itself to be empty")
+	}
+}
+
+func Test_MultiSetOpsMixedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected mixing implementations to panic")
+		}
+	}()
+	UnionAll(NewSet(1), NewThreadUnsafeSet())
+}
+
+func Test_MultiSetOpsConcurrent(t *testing.T) {
+	sets := []Set{NewSet(), NewSet(), NewSet()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			sets[i%len(sets)].Add(i)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			// Pass the sets in varying orders; locking must not depend on it.
+			UnionAll(sets[i%3], sets[(i+1)%3], sets[(i+2)%3])
+			IntersectAll(sets[(i+2)%3], sets[i%3])
+			DifferenceAll(sets[(i+1)%3], sets...)
+		}(i)
+	}
+	wg.Wait()
+
+	if UnionAll(sets...).Cardinality() != 50 {
+		t.Error("expected every added element in the union")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// parallelThreshold is the size of the filtered input below which the
+// parallel operations run sequentially; see BenchmarkParallelIntersect
+// for where splitting the work starts to pay off. It is a variable so
+// that the benchmarks can disable it.
+var parallelThreshold = 32 * 1024
+
+// ParallelIntersect returns the intersection of a and b, computed by n
+// goroutines that each probe one chunk of the smaller set. If n is not
+// positive, runtime.GOMAXPROCS(0) goroutines are used. Small inputs are
+// intersected on the calling goroutine.
+//
+// The sets must share one implementation, as for Intersect, and the
+// result uses it too. Thread-safe sets are read-locked for the duration.
+func ParallelIntersect(a, b Set, n int) Set {
+	maps, unlock := readLockSets([]Set{a, b})
+	defer unlock()
+
+	small, large := maps[0], maps[1]
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	result := parallelFilter(small, n, func(elem interface{}) bool {
+		_, ok := large[elem]
+		return ok
+	})
+	return wrapLike(a, result)
+}
+
+// ParallelDifference returns the elements of a that are not in b,
+// computed by n goroutines that each filter one chunk of a. Parallelism,
+// implementations and locking follow ParallelIntersect.
+func ParallelDifference(a, b Set, n int) Set {
+	maps, unlock := readLockSets([]Set{a, b})
+	defer unlock()
+
+	other := maps[1]
+	result := parallelFilter(maps[0], n, func(elem interface{}) bool {
+		_, ok := other[elem]
+		return !ok
+	})
+	return wrapLike(a, result)
+}
+
+// parallelFilter returns the elements of s for which keep returns true.
+// keep is called concurrently and must only read shared state.
+func parallelFilter(s threadUnsafeSet, n int, keep func(interface{}) bool) threadUnsafeSet {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	if n == 1 || len(s) < parallelThreshold {
+		result := newThreadUnsafeSet()
+		for elem := range s {
+			if keep(elem) {
+				result[elem] = struct{}{}
+			}
+		}
+		return result
+	}
+
+	elems := make([]interface{}, 0, len(s))
+	for elem := range s {
+		elems = append(elems, elem)
+	}
+
+	parts := make([][]interface{}, n)
+	chunk := (len(elems) + n - 1) / n
+	var wg sync.WaitGroup
+	for i := range parts {
+		lo, hi := i*chunk, (i+1)*chunk
+		if lo >= len(elems) {
+			break
+		}
+		if hi > len(elems) {
+			hi = len(elems)
+		}
+
+		wg.Add(1)
+		go func(i int, elems []interface{}) {
+			defer wg.Done()
+			var kept []interface{}
+			for _, elem := range elems {
+				if keep(elem) {
+					kept = append(kept, elem)
+				}
+			}
+			parts[i] = kept
+		}(i, elems[lo:hi])
+	}
+	wg.Wait()
+
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+	result := make(threadUnsafeSet, size)
+	for _, part := range parts {
+		for _, elem := range part {
+			result[elem] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Partition splits s into n disjoint sets whose union is s, by hashing
+// each element. The hash depends only on the element, so equal elements
+// of different sets land in shards with the same index, and shards of
+// two sets can be combined pairwise. Shards use the implementation of s.
+// Partition panics if n is not positive.
+func Partition(s Set, n int) []Set {
+	if n <= 0 {
+		panic(fmt.Sprintf("mapset: cannot partition into %d sets", n))
+	}
+	maps, unlock := readLockSets([]Set{s})
+	defer unlock()
+
+	shards := make([]threadUnsafeSet, n)
+	for i := range shards {
+		shards[i] = make(threadUnsafeSet, len(maps[0])/n)
+	}
+	var buf []byte
+	for elem := range maps[0] {
+		var h uint64
+		h, buf = hashElement(buf[:0], elem)
+		shards[h%uint64(n)][elem] = struct{}{}
+	}
+
+	sets := make([]Set, n)
+	for i, shard := range shards {
+		sets[i] = wrapLike(s, shard)
+	}
+	return sets
+}
+
+// hashElement returns a hash of elem that is stable across processes,
+// using buf as scratch space, and the grown buffer. Elements without a
+// binary encoding are hashed by their formatted value. elem is hashed
+// as the set stores it, so that filters built from a set find every
+// value the set contains.
+func hashElement(buf []byte, elem interface{}) (uint64, []byte) {
+	elem = elementKey(elem)
+	b, err := appendElement(buf, elem)
+	if err != nil {
+		b = fmt.Appendf(buf, "%T %#v", elem, elem)
+	}
+	return sketch.Hash64(b, 0), b
+}
@@ -0,0 +1,80 @@
+package mapset
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_ParallelIntersectDifference(t *testing.T) {
+	for _, size := range []int{10, 3 * parallelThreshold} {
+		a := NewThreadUnsafeSet()
+		b := NewThreadUnsafeSet()
+		for i := 0; i < size; i++ {
+			a.Add(i)
+			if i%3 == 0 {
+				b.Add(i)
+			}
+		}
+
+		for _, n := range []int{0, 1, 4, 7} {
+			if got := ParallelIntersect(a, b, n); !got.Equal(a.Intersect(b)) {
+				t.Errorf("size %d, n %d: unexpected intersection of %d elements", size, n, got.Cardinality())
+			}
+			if got := ParallelDifference(a, b, n); !got.Equal(a.Difference(b)) {
+				t.Errorf("size %d, n %d: unexpected difference of %d elements", size, n, got.Cardinality())
+			}
+		}
+	}
+
+	safe := ParallelIntersect(NewSet(1, 2), NewSet(2, 3), 2)
+	if _, ok := safe.(*threadSafeSet); !ok || !safe.Equal(NewSet(2)) {
+		t.Errorf("expected a thread-safe intersection, got %v", safe)
+	}
+}
+
+func Test_Partition(t *testing.T) {
+	s := NewSet()
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+		s.Add(fmt.Sprint(i))
+	}
+	s.Add(struct{ X int }{1})
+
+	shards := Partition(s, 8)
+	if len(shards) != 8 {
+		t.Fatalf("expected 8 shards, got %d", len(shards))
+	}
+	if !UnionAll(shards...).Equal(s) {
+		t.Error("expected the shards to cover the set")
+	}
+	total := 0
+	for _, shard := range shards {
+		total += shard.Cardinality()
+		if shard.Cardinality() == 0 {
+			t.Error("expected every shard to receive elements")
+		}
+	}
+	if total != s.Cardinality() {
+		t.Errorf("expected disjoint shards, got %d elements in total", total)
+	}
+
+	// Equal elements of different sets land in the same shard.
+	other := Partition(NewSet(42, "42"), 8)
+	for i := range other {
+		other[i].Each(func(elem interface{}) bool {
+			if !shards[i].Contains(elem) {
+				t.Errorf("expected %v in shard %d", elem, i)
+			}
+			return false
+		})
+	}
+}
+
+func Test_PartitionInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic partitioning into zero sets")
+		}
+	}()
+	Partition(NewSet(1), 0)
+}
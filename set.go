@@ -40,7 +40,13 @@ package mapset
 // operations that can be applied to that set.
 type Set interface {
 	// Adds an element to the set. Returns whether
-	// the item was added.
+	// the item was added. A time.Time is stored as
+	// t.Round(0), without its monotonic clock
+	// reading. Its location is kept, so the same
+	// instant in two locations is two elements, as
+	// with ==. Encodings keep a time's offset but
+	// not its *time.Location, so a time outside UTC
+	// and Local decodes as a different element.
 	Add(i interface{}) bool
 
 	// Returns the number of elements in the set.
@@ -80,6 +86,10 @@ type Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -178,7 +188,7 @@ type Set interface {
 // NewSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewSet(s ...interface{}) Set {
-	set := newThreadSafeSet()
+	set := threadSafeSet{s: newThreadUnsafeSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -202,6 +212,23 @@ func NewSetFromSlice(s []interface{}) Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeSet() Set {
 	set := newThreadUnsafeSet()
+	trackUnsafeSet(&set)
+	return &set
+}
+
+// NewSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewSetWithCapacity(n int) Set {
+	return &threadSafeSet{s: newThreadUnsafeSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeSetWithCapacity(n int) Set {
+	set := newThreadUnsafeSetWithCapacity(n)
+	trackUnsafeSet(&set)
 	return &set
 }
 
@@ -209,7 +236,7 @@ func NewThreadUnsafeSet() Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeSetFromSlice(s []interface{}) Set {
-	a := NewThreadUnsafeSet()
+	a := NewThreadUnsafeSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
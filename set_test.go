@@ -25,7 +25,10 @@ SOFTWARE.
 
 package mapset
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func makeSet(ints []int) Set {
 	set := NewSet()
@@ -110,6 +113,30 @@ func Test_AddUnsafeSetNoDuplicate(t *testing.T) {
 	}
 }
 
+func Test_AddTime(t *testing.T) {
+	now := time.Now()
+	zoned := time.Date(2020, 2, 29, 12, 30, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	for _, s := range []Set{NewSet(), NewThreadUnsafeSet()} {
+		s.Add(now)
+		if s.Add(now.Round(0)) {
+			t.Errorf("expected %v without its monotonic reading to be the same element", now)
+		}
+		s.Add(zoned)
+		if !s.Add(zoned.UTC()) {
+			t.Errorf("expected %v in another location to be a different element", zoned)
+		}
+		if !s.Contains(now, zoned) || s.Cardinality() != 3 {
+			t.Errorf("expected 3 times, got %v", s)
+		}
+
+		s.Remove(zoned.UTC())
+		s.Remove(now)
+		if got := s.ToSlice(); len(got) != 1 || got[0].(time.Time).Location() != zoned.Location() {
+			t.Errorf("expected %v to keep its location, got %v", zoned, got)
+		}
+	}
+}
+
 func Test_RemoveSet(t *testing.T) {
 	a := makeSet([]int{6, 3, 1})
 
@@ -1198,3 +1225,51 @@ func Test_Example(t *testing.T) {
 	   fmt.Println(allClasses.ContainsAll("Welding", "Automotive", "English"))
 	*/
 }
+
+func Test_NewSetWithCapacity(t *testing.T) {
+	for _, s := range []Set{NewSetWithCapacity(10), NewThreadUnsafeSetWithCapacity(10)} {
+		if s.Cardinality() != 0 {
+			t.Error("expected a new set with capacity to be empty")
+		}
+		s.Add(1)
+		if !s.Contains(1) {
+			t.Error("expected a set with capacity to accept elements")
+		}
+	}
+}
+
+func Test_Grow(t *testing.T) {
+	for _, s := range []Set{NewSet(1, 2), NewThreadUnsafeSetFromSlice([]interface{}{1, 2})} {
+		s.Grow(100)
+		s.Grow(0)
+		if s.Cardinality() != 2 || !s.Contains(1, 2) {
+			t.Errorf("expected Grow to keep the elements, got %v", s)
+		}
+
+		allocs := testing.AllocsPerRun(1, func() {
+			for i := 3; i < 50; i++ {
+				s.Add(i)
+			}
+		})
+		if allocs > 0 {
+			t.Errorf("expected no allocations adding within the grown capacity, got %v", allocs)
+		}
+	}
+}
+
+func Test_ClearReusesSet(t *testing.T) {
+	for _, s := range []Set{NewSet(1, 2, 3), NewThreadUnsafeSetFromSlice([]interface{}{1, 2, 3})} {
+		c := s.Clone()
+		s.Clear()
+		if s.Cardinality() != 0 || s.Contains(1) {
+			t.Errorf("expected an empty set after Clear, got %v", s)
+		}
+		if c.Cardinality() != 3 {
+			t.Error("expected Clear to leave clones untouched")
+		}
+		s.Add(4)
+		if s.Cardinality() != 1 || !s.Contains(4) {
+			t.Errorf("expected a cleared set to be reusable, got %v", s)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s BoolSet) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleBoolValues[i%len(sampleBoolValues)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewBoolSet())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeBoolSet())
+}
+
+func benchContains(b *testing.B, s BoolSet) {
+	for _, v := range sampleBoolValues[:len(sampleBoolValues)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleBoolValues[i%len(sampleBoolValues)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewBoolSet())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeBoolSet())
+}
+
+func benchUnion(b *testing.B, x, y BoolSet) {
+	half := len(sampleBoolValues) / 2
+	for _, v := range sampleBoolValues[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleBoolValues[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewBoolSet(), NewBoolSet())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeBoolSet(), NewThreadUnsafeBoolSet())
+}
+
+func benchMarshalJSON(b *testing.B, s BoolSet) {
+	for _, v := range sampleBoolValues {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewBoolSet())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeBoolSet())
+}
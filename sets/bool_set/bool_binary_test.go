@@ -0,0 +1,14 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s BoolSet) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s BoolSet) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertBoolRoundTrip(t, marshal, unmarshal)
+}
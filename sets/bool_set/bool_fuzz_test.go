@@ -0,0 +1,129 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// fuzzBoolSet builds a set from the sample values whose bit is set in
+// mask.
+func fuzzBoolSet(mask uint64) BoolSet {
+	s := NewThreadUnsafeBoolSet()
+	for i, v := range sampleBoolValues {
+		if mask&(1<<uint(i)) != 0 {
+			s.Add(v)
+		}
+	}
+	return s
+}
+
+// canonicalBoolString prints the elements of s in order, so that
+// sets can be compared even when equal elements are not ==, like times
+// in equal but distinct locations.
+func canonicalBoolString(s BoolSet) string {
+	elems := s.ToSlice()
+	sortBoolElements(elems)
+	return fmt.Sprint(elems)
+}
+
+func FuzzSetOperations(f *testing.F) {
+	f.Add(uint64(0), uint64(0))
+	f.Add(uint64(0b1011), uint64(0b0110))
+	f.Add(^uint64(0), uint64(1))
+
+	f.Fuzz(func(t *testing.T, x, y uint64) {
+		a, b := fuzzBoolSet(x), fuzzBoolSet(y)
+		union, intersection := a.Union(b), a.Intersect(b)
+		difference, symmetric := a.Difference(b), a.SymmetricDifference(b)
+
+		subset := true
+		for i, v := range sampleBoolValues {
+			inA, inB := x&(1<<uint(i)) != 0, y&(1<<uint(i)) != 0
+			if inA && !inB {
+				subset = false
+			}
+			if union.Contains(v) != (inA || inB) {
+				t.Errorf("Union of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if intersection.Contains(v) != (inA && inB) {
+				t.Errorf("Intersect of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if difference.Contains(v) != (inA && !inB) {
+				t.Errorf("Difference of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if symmetric.Contains(v) != (inA != inB) {
+				t.Errorf("SymmetricDifference of %v and %v: wrong membership of %v", a, b, v)
+			}
+		}
+		if a.IsSubset(b) != subset || b.IsSuperset(a) != subset {
+			t.Errorf("IsSubset and IsSuperset of %v and %v: expected %v", a, b, subset)
+		}
+		if union.Cardinality() != intersection.Cardinality()+symmetric.Cardinality() {
+			t.Errorf("expected |A ∪ B| = |A ∩ B| + |A △ B| for %v and %v", a, b)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, v := range sampleBoolValues {
+		b, err := json.Marshal(NewBoolSet(v))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[1, "a", true, null]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewThreadUnsafeBoolSet()
+		if err := json.Unmarshal(data, s); err != nil {
+			return
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("marshaling %v decoded from %q: %v", s, data, err)
+		}
+		again := NewThreadUnsafeBoolSet()
+		if err := json.Unmarshal(b, again); err != nil {
+			t.Fatalf("unmarshaling %q: %v", b, err)
+		}
+		if want, got := canonicalBoolString(s), canonicalBoolString(again); want != got {
+			t.Fatalf("round trip of %q: expected %s, got %s", data, want, got)
+		}
+	})
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	for i := range sampleBoolValues {
+		b, err := fuzzBoolSet(1 << uint(i)).(*threadUnsafeBoolSet).MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := newThreadUnsafeBoolSet()
+		if err := s.UnmarshalBinary(data); err != nil {
+			return
+		}
+		b, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("marshaling %v decoded from %v: %v", &s, data, err)
+		}
+		again := newThreadUnsafeBoolSet()
+		if err := again.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unmarshaling %v: %v", b, err)
+		}
+		if want, got := canonicalBoolString(&s), canonicalBoolString(&again); want != got {
+			t.Fatalf("round trip of %v: expected %s, got %s", data, want, got)
+		}
+	})
+}
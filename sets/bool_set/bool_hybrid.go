@@ -0,0 +1,168 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// BoolHybridSet counts distinct bool elements exactly while
+// there are at most a threshold of them, and then converts itself into a
+// HyperLogLog sketch, bounding its memory at the cost of an approximate
+// count. Hybrid sets with the same precision can be merged, for example
+// to combine per-shard counts.
+//
+// A BoolHybridSet is safe for concurrent use.
+type BoolHybridSet struct {
+	mu        sync.RWMutex
+	exact     threadUnsafeBoolSet // nil once converted
+	hll       *sketch.HyperLogLog
+	threshold int
+	precision uint8
+}
+
+// NewBoolHybridSet returns an empty hybrid set that converts to a
+// HyperLogLog of the given precision once it holds more than threshold
+// elements. It panics if precision is out of the range accepted by
+// sketch.NewHyperLogLog.
+func NewBoolHybridSet(threshold int, precision uint8) *BoolHybridSet {
+	// Validate the precision up front rather than on conversion.
+	sketch.NewHyperLogLog(precision)
+
+	return &BoolHybridSet{
+		exact:     newThreadUnsafeBoolSet(),
+		threshold: threshold,
+		precision: precision,
+	}
+}
+
+// Add adds elem to the set.
+func (s *BoolHybridSet) Add(elem bool) {
+	elem = keyBool(elem)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.exact == nil {
+		h, _ := hashBoolElement(nil, elem)
+		s.hll.AddHash(h)
+		return
+	}
+	s.exact.Add(elem)
+	if len(s.exact) > s.threshold {
+		s.convert()
+	}
+}
+
+// Cardinality returns the number of distinct elements added: exact
+// while IsExact reports true, estimated afterwards.
+func (s *BoolHybridSet) Cardinality() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.exact != nil {
+		return uint64(len(s.exact))
+	}
+	return s.hll.Estimate()
+}
+
+// IsExact reports whether the set still holds its elements exactly.
+func (s *BoolHybridSet) IsExact() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.exact != nil
+}
+
+// Exact returns a copy of the elements while the set is exact, and nil
+// once it has converted to a sketch.
+func (s *BoolHybridSet) Exact() BoolSet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.exact == nil {
+		return nil
+	}
+	return s.exact.Clone()
+}
+
+// Sketch returns a HyperLogLog of the elements added, built from the
+// exact elements if the set has not converted yet. The result is a
+// copy that does not track later additions.
+func (s *BoolHybridSet) Sketch() *sketch.HyperLogLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.exact == nil {
+		return s.hll.Clone()
+	}
+	return s.sketchExact()
+}
+
+// Merge adds the elements counted by other to s. The result is exact
+// only if both sets are exact and their union stays within the
+// threshold of s. Both sets must have the same precision; otherwise s
+// is left unchanged and an error is returned.
+func (s *BoolHybridSet) Merge(other *BoolHybridSet) error {
+	// The precision never changes, so it can be checked without locks.
+	if s.precision != other.precision {
+		return fmt.Errorf("mapsetbool: cannot merge hybrid sets of precision %d and %d", s.precision, other.precision)
+	}
+
+	// Snapshot other first so that the two locks are never held together.
+	other.mu.RLock()
+	exact, hll := other.exact, other.hll
+	if exact != nil {
+		exact = *other.exact.Clone().(*threadUnsafeBoolSet)
+	} else {
+		hll = hll.Clone()
+	}
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if exact != nil && s.exact != nil {
+		for elem := range exact {
+			s.exact.Add(elem)
+		}
+		if len(s.exact) > s.threshold {
+			s.convert()
+		}
+		return nil
+	}
+
+	if s.exact != nil {
+		s.convert()
+	}
+	if exact != nil {
+		var buf []byte
+		for elem := range exact {
+			var h uint64
+			h, buf = hashBoolElement(buf[:0], elem)
+			s.hll.AddHash(h)
+		}
+		return nil
+	}
+	return s.hll.Merge(hll)
+}
+
+// convert replaces the exact elements with a sketch. It must be called
+// with the write lock held.
+func (s *BoolHybridSet) convert() {
+	s.hll = s.sketchExact()
+	s.exact = nil
+}
+
+func (s *BoolHybridSet) sketchExact() *sketch.HyperLogLog {
+	hll := sketch.NewHyperLogLog(s.precision)
+	var buf []byte
+	for elem := range s.exact {
+		var h uint64
+		h, buf = hashBoolElement(buf[:0], elem)
+		hll.AddHash(h)
+	}
+	return hll
+}
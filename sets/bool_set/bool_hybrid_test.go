@@ -0,0 +1,127 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"testing"
+)
+
+// boolHybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const boolHybridPrecision = 14
+
+// assertBoolHybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertBoolHybridCount(t *testing.T, name string, s *BoolHybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleBoolValues) / 2
+	s := NewBoolHybridSet(half, boolHybridPrecision)
+	for _, v := range sampleBoolValues[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertBoolHybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeBoolSetFromSlice(sampleBoolValues[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleBoolValues[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleBoolValues[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertBoolHybridCount(t, "converted", s, half+1)
+	s.Add(sampleBoolValues[0])
+	assertBoolHybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleBoolValues) / 2
+	newHybrid := func(sketched bool, elems []bool) *BoolHybridSet {
+		threshold := len(sampleBoolValues)
+		if sketched {
+			threshold = 0
+		}
+		s := NewBoolHybridSet(threshold, boolHybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleBoolValues[:half+1])
+		other := newHybrid(tc.sketchedO, sampleBoolValues[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeBoolSetFromSlice(sampleBoolValues)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleBoolValues, s.Exact())
+		}
+		assertBoolHybridCount(t, tc.name, s, len(sampleBoolValues))
+		assertBoolHybridCount(t, tc.name+" leaves other", other, len(sampleBoolValues)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewBoolHybridSet(half, boolHybridPrecision)
+	s.Add(sampleBoolValues[0])
+	if err := s.Merge(newHybrid(false, sampleBoolValues)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertBoolHybridCount(t, "merged past the threshold", s, len(sampleBoolValues))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleBoolValues) / 2
+	for _, threshold := range []int{len(sampleBoolValues), 0} {
+		s := NewBoolHybridSet(threshold, boolHybridPrecision)
+		for _, v := range sampleBoolValues[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleBoolValues), 0} {
+			other := NewBoolHybridSet(otherThreshold, boolHybridPrecision-2)
+			other.Add(sampleBoolValues[len(sampleBoolValues)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, boolHybridPrecision, boolHybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeBoolSetFromSlice(sampleBoolValues[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
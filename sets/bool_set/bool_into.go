@@ -0,0 +1,195 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeBoolSet()
+		return &s
+	},
+}
+
+// NewScratchBoolSet returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchBoolSet() BoolSet {
+	return scratchPool.Get().(*threadUnsafeBoolSet)
+}
+
+// Release empties s and makes it available to NewScratchBoolSet. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s BoolSet) {
+	u, ok := s.(*threadUnsafeBoolSet)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b BoolSet) {
+	d, x, y, locks := lockBoolInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllBool(d, y)
+	case dst == b:
+		addAllBool(d, x)
+	default:
+		clear(d)
+		addAllBool(d, x)
+		addAllBool(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b BoolSet) {
+	d, x, y, locks := lockBoolInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyBool(d, y)
+	case dst == b:
+		keepOnlyBool(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b BoolSet) {
+	d, x, y, locks := lockBoolInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchBoolSet().(*threadUnsafeBoolSet)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllBool(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllBool(dst, src threadUnsafeBoolSet) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyBool removes the elements of dst that are not in other.
+func keepOnlyBool(dst, other threadUnsafeBoolSet) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoBoolLocks records the locks taken by lockBoolInto, or the guards for
+// thread-unsafe sets.
+type intoBoolLocks struct {
+	sets     [3]*threadSafeBoolSet
+	distinct int
+	dst      *threadSafeBoolSet
+	guards   [3]unsafeBoolGuard
+}
+
+// lockBoolInto locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockBoolInto(dst, a, b BoolSet) (d, x, y threadUnsafeBoolSet, locks intoBoolLocks) {
+	w, ok := dst.(*threadSafeBoolSet)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeBoolSet)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeBoolSet).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeBoolSet).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeBoolSet), *b.(*threadUnsafeBoolSet), locks
+	}
+
+	locks.sets = [3]*threadSafeBoolSet{w, a.(*threadSafeBoolSet), b.(*threadSafeBoolSet)}
+	locks.distinct = len(lockBoolOrder(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeBoolSet).s, b.(*threadSafeBoolSet).s, locks
+}
+
+func (l intoBoolLocks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleBoolValues)
+	aValues, bValues := sampleBoolValues[:n-1], sampleBoolValues[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b BoolSet)
+		expected []bool
+	}{
+		{"UnionInto", UnionInto, sampleBoolValues},
+		{"IntersectInto", IntersectInto, sampleBoolValues[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleBoolValues[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range boolSetFactories() {
+			expected := fillBoolSet(newSet(), testCase.expected...)
+
+			dst := fillBoolSet(newSet(), sampleBoolValues[n-1])
+			testCase.into(dst, fillBoolSet(newSet(), aValues...), fillBoolSet(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillBoolSet(newSet(), aValues...)
+			testCase.into(a, a, fillBoolSet(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillBoolSet(newSet(), bValues...)
+			testCase.into(b, fillBoolSet(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range boolSetFactories() {
+		s := fillBoolSet(newSet(), sampleBoolValues...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchBoolSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleBoolValues[0])
+	Release(s)
+	Release(NewBoolSet(sampleBoolValues[0]))
+
+	if again := NewScratchBoolSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
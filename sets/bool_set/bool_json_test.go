@@ -0,0 +1,49 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s BoolSet) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s BoolSet) error { return json.Unmarshal(b, s) }
+	assertBoolRoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewBoolSet(sampleBoolValues...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewBoolSet()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleBoolValues[i%len(sampleBoolValues)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewBoolSet(sampleBoolValues...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleBoolValues, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetbool
+
+// unsafeBoolGuard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeBoolGuard struct{}
+
+func trackBoolSet(set *threadUnsafeBoolSet) {}
+
+func (set *threadUnsafeBoolSet) guardRead() unsafeBoolGuard { return unsafeBoolGuard{} }
+
+func (set *threadUnsafeBoolSet) guardWrite() unsafeBoolGuard { return unsafeBoolGuard{} }
+
+func (set *threadUnsafeBoolSet) guardIterate() unsafeBoolGuard { return unsafeBoolGuard{} }
+
+func (unsafeBoolGuard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...BoolSet) BoolSet {
+	if len(sets) == 0 {
+		return NewBoolSet()
+	}
+	maps, unlock := readLockBoolSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeBoolSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapBoolLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...BoolSet) BoolSet {
+	if len(sets) == 0 {
+		return NewBoolSet()
+	}
+	maps, unlock := readLockBoolSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeBoolSet()
+	for elem := range maps[smallest] {
+		if containedInAllBool(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapBoolLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base BoolSet, sets ...BoolSet) BoolSet {
+	maps, unlock := readLockBoolSets(append([]BoolSet{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeBoolSet()
+	for elem := range maps[0] {
+		if !containedInAnyBool(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapBoolLike(base, difference)
+}
+
+func containedInAllBool(maps []threadUnsafeBoolSet, skip int, elem bool) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyBool(maps []threadUnsafeBoolSet, elem bool) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapBoolLike returns s as a Set of the same implementation as like.
+func wrapBoolLike(like BoolSet, s threadUnsafeBoolSet) BoolSet {
+	if _, ok := like.(*threadSafeBoolSet); ok {
+		return &threadSafeBoolSet{s: s}
+	}
+	trackBoolSet(&s)
+	return &s
+}
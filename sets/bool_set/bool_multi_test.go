@@ -0,0 +1,42 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import "testing"
+
+func TestMultiSetOperations(t *testing.T) {
+	for name, newSet := range boolSetFactories() {
+		all := fillBoolSet(newSet(), sampleBoolValues...)
+		first := fillBoolSet(newSet(), sampleBoolValues[0])
+		rest := fillBoolSet(newSet(), sampleBoolValues[1:]...)
+
+		var testCases = []struct {
+			op            string
+			got, expected BoolSet
+		}{
+			{"UnionAll", UnionAll(first, rest), all},
+			{"UnionAll of one set", UnionAll(rest), rest},
+			{"IntersectAll", IntersectAll(all, rest, all), rest},
+			{"IntersectAll of disjoint sets", IntersectAll(all, first, rest), newSet()},
+			{"DifferenceAll", DifferenceAll(all, rest), first},
+			{"DifferenceAll of every set", DifferenceAll(all, first, rest), newSet()},
+			{"DifferenceAll of no sets", DifferenceAll(rest), rest},
+		}
+		for _, testCase := range testCases {
+			// Equal also checks that the result shares the implementation
+			// of the inputs.
+			if !testCase.got.Equal(testCase.expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.op, testCase.expected, testCase.got)
+			}
+		}
+		if all.Cardinality() != len(sampleBoolValues) || rest.Cardinality() != len(sampleBoolValues)-1 {
+			t.Errorf("%s: expected the inputs to be left untouched, got %v and %v", name, all, rest)
+		}
+	}
+
+	for op, s := range map[string]BoolSet{"UnionAll": UnionAll(), "IntersectAll": IntersectAll()} {
+		if _, ok := s.(*threadSafeBoolSet); !ok || s.Cardinality() != 0 {
+			t.Errorf("%s: expected an empty thread-safe set with no inputs, got %T %v", op, s, s)
+		}
+	}
+}
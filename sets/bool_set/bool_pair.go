@@ -0,0 +1,224 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A BoolPair represents a 2-tuple of values.
+type BoolPair struct {
+	First  bool
+	Second bool
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *BoolPair) Equal(other BoolPair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair BoolPair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// BoolPairSet is an unordered set of BoolPairs, as
+// returned by CartesianProduct.
+type BoolPairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p BoolPair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...BoolPair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(BoolPair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other BoolPairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p BoolPair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []BoolPair
+}
+
+type threadUnsafeBoolPairSet map[BoolPair]struct{}
+
+func newThreadUnsafeBoolPairSet() threadUnsafeBoolPairSet {
+	return make(threadUnsafeBoolPairSet)
+}
+
+func (set *threadUnsafeBoolPairSet) Add(p BoolPair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeBoolPairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeBoolPairSet) Contains(p ...BoolPair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeBoolPairSet) Each(cb func(BoolPair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeBoolPairSet) Equal(other BoolPairSet) bool {
+	_ = other.(*threadUnsafeBoolPairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeBoolPairSet) Remove(p BoolPair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeBoolPairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeBoolPairSet) ToSlice() []BoolPair {
+	keys := make([]BoolPair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeBoolPairSet struct {
+	s threadUnsafeBoolPairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeBoolPairSet) Add(p BoolPair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeBoolPairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeBoolPairSet) Contains(p ...BoolPair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeBoolPairSet) Each(cb func(BoolPair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockBoolPairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockBoolPair does for sets.
+func rlockBoolPairSets(x, y *threadSafeBoolPairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockBoolPairSets releases the locks taken by rlockBoolPairSets.
+func runlockBoolPairSets(x, y *threadSafeBoolPairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeBoolPairSet) Equal(other BoolPairSet) bool {
+	o := other.(*threadSafeBoolPairSet)
+
+	rlockBoolPairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockBoolPairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeBoolPairSet) Remove(p BoolPair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeBoolPairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeBoolPairSet) ToSlice() []BoolPair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
 package mapsetbool
 
 // BoolSet is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type BoolSet interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other BoolSet) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type BoolSet interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() bool
 
-	// Returns all subsets of a given set (Power BoolSet).
-	// Not yet supported
-	// PowerSet() BoolSet
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []BoolSet
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other BoolSet) BoolSet
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other BoolSet) BoolPairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []bool
@@ -145,7 +154,7 @@ type BoolSet interface {
 // NewBoolSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewBoolSet(s ...bool) BoolSet {
-	set := newThreadSafeBoolSet()
+	set := threadSafeBoolSet{s: newThreadUnsafeBoolSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewBoolSetFromSlice(s []bool) BoolSet {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeBoolSet() BoolSet {
 	set := newThreadUnsafeBoolSet()
+	trackBoolSet(&set)
+	return &set
+}
+
+// NewBoolSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewBoolSetWithCapacity(n int) BoolSet {
+	return &threadSafeBoolSet{s: newThreadUnsafeBoolSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeBoolSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeBoolSetWithCapacity(n int) BoolSet {
+	set := newThreadUnsafeBoolSetWithCapacity(n)
+	trackBoolSet(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeBoolSet() BoolSet {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeBoolSetFromSlice(s []bool) BoolSet {
-	a := NewThreadUnsafeBoolSet()
+	a := NewThreadUnsafeBoolSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
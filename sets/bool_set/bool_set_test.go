@@ -0,0 +1,99 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleBoolValues are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleBoolValues = []bool{
+	false,
+	true,
+}
+
+// boolSetFactories returns constructors for both implementations.
+func boolSetFactories() map[string]func() BoolSet {
+	return map[string]func() BoolSet{
+		"safe":   func() BoolSet { return NewBoolSet() },
+		"unsafe": NewThreadUnsafeBoolSet,
+	}
+}
+
+// fillBoolSet adds values to s and returns it.
+func fillBoolSet(s BoolSet, values ...bool) BoolSet {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertBoolRoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertBoolRoundTrip(t *testing.T, marshal func(BoolSet) ([]byte, error), unmarshal func([]byte, BoolSet) error) {
+	t.Helper()
+	contents := [][]bool{sampleBoolValues, sampleBoolValues[:1]}
+	for name, newSet := range boolSetFactories() {
+		for _, values := range contents {
+			s := fillBoolSet(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range boolSetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[bool, BoolSet]{
+				New:   newSet,
+				Elems: sampleBoolValues,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range boolSetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleBoolValues[0])
+		a.Add(sampleBoolValues[1])
+		for _, v := range sampleBoolValues {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleBoolValues); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(BoolPair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
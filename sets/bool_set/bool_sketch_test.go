@@ -0,0 +1,41 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"testing"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewBoolSet(sampleBoolValues...)
+	queries := s.ToSlice()
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewBoolSet(sampleBoolValues...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleBoolValues) {
+		t.Errorf("expected %d elements, got %d", len(sampleBoolValues), f.Len())
+	}
+	for _, v := range sampleBoolValues {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+}
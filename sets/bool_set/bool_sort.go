@@ -0,0 +1,18 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"sort"
+)
+
+// lessBool orders elements for output that must be stable,
+// such as sorted encodings.
+func lessBool(a, b bool) bool {
+	return !a && b
+}
+
+// sortBoolElements sorts elems in place using lessBool.
+func sortBoolElements(elems []bool) {
+	sort.Slice(elems, func(i, j int) bool { return lessBool(elems[i], elems[j]) })
+}
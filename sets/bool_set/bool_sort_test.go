@@ -0,0 +1,26 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]bool, 0, len(sampleBoolValues))
+	for i := len(sampleBoolValues) - 1; i >= 0; i-- {
+		elems = append(elems, sampleBoolValues[i])
+	}
+	sortBoolElements(elems)
+	for i, elem := range elems {
+		if lessBool(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessBool(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewBoolSet(elems...).Equal(NewBoolSet(sampleBoolValues...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleBoolValues, elems)
+	}
+}
@@ -0,0 +1,254 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BoolSQLFormat selects how a set is stored in a database column.
+type BoolSQLFormat int
+
+const (
+	// BoolSQLArray stores sets as Postgres array literals such as
+	// {a,b}, for array columns.
+	BoolSQLArray BoolSQLFormat = iota
+
+	// BoolSQLJSON stores sets as JSON arrays, for json, jsonb and
+	// text columns.
+	BoolSQLJSON
+)
+
+var errSQLMultidimensional = errors.New("mapsetbool: multidimensional arrays are not supported")
+
+// BoolSQLValue adapts a BoolSet for use as a query argument or a
+// scan destination with database/sql, in a chosen format.
+//
+// A NULL column scans as an empty set. Scanning replaces the contents
+// of the set and detects the format of the column, so either format
+// can be read regardless of Format.
+type BoolSQLValue struct {
+	set    BoolSet
+	Format BoolSQLFormat
+}
+
+// NewBoolSQLValue returns a BoolSQLValue that reads and writes s
+// in format.
+func NewBoolSQLValue(s BoolSet, format BoolSQLFormat) *BoolSQLValue {
+	return &BoolSQLValue{set: s, Format: format}
+}
+
+// Value implements driver.Valuer.
+func (v *BoolSQLValue) Value() (driver.Value, error) {
+	if v.set == nil {
+		return nil, nil
+	}
+	if v.Format == BoolSQLJSON {
+		b, err := json.Marshal(v.set)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return formatBoolSQLArray(v.set)
+}
+
+// Scan implements sql.Scanner.
+func (v *BoolSQLValue) Scan(src interface{}) error {
+	elems, err := scanBoolSQL(src)
+	if err != nil {
+		return err
+	}
+
+	v.set.Clear()
+	for _, elem := range elems {
+		v.set.Add(elem)
+	}
+	return nil
+}
+
+// formatBoolSQLArray renders s as a Postgres array literal, in
+// sorted order.
+func formatBoolSQLArray(s BoolSet) (string, error) {
+	elems := s.ToSlice()
+	sortBoolElements(elems)
+
+	items := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		item, err := formatBoolText(elem)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, item)
+	}
+	return "{" + strings.Join(items, ",") + "}", nil
+}
+
+// scanBoolSQL decodes a column holding either a JSON array or a
+// Postgres array literal. NULL decodes as no elements.
+func scanBoolSQL(src interface{}) ([]bool, error) {
+	var text []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		text = v
+	case string:
+		text = []byte(v)
+	default:
+		return nil, fmt.Errorf("mapsetbool: cannot scan %T into a set", src)
+	}
+
+	text = bytes.TrimSpace(text)
+	switch {
+	case bytes.HasPrefix(text, []byte("[")):
+		var elems []bool
+		if err := json.Unmarshal(text, &elems); err != nil {
+			return nil, err
+		}
+		return elems, nil
+	case bytes.HasPrefix(text, []byte("{")):
+		items, err := parseSQLArray(string(text))
+		if err != nil {
+			return nil, err
+		}
+
+		elems := make([]bool, 0, len(items))
+		for _, item := range items {
+			if item.null {
+				return nil, errors.New("mapsetbool: cannot scan a NULL array element")
+			}
+			elem, err := parseBoolText(item.text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("mapsetbool: cannot scan %q into a set", text)
+	}
+}
+
+// sqlArrayItem is a single element of a Postgres array literal.
+type sqlArrayItem struct {
+	text string
+	null bool
+}
+
+// parseSQLArray parses a one-dimensional Postgres array literal such
+// as {a,"b c",NULL}.
+func parseSQLArray(s string) ([]sqlArrayItem, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("mapsetbool: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var items []sqlArrayItem
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+
+		var item sqlArrayItem
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("mapsetbool: unterminated quote in array literal")
+			}
+			item.text = b.String()
+			s = strings.TrimLeft(s[i+1:], " \t\n\r")
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			item.text = strings.TrimSpace(s[:end])
+			if item.text == "" {
+				return nil, fmt.Errorf("mapsetbool: empty unquoted element in array literal")
+			}
+			if strings.ContainsAny(item.text, `{}"\`) {
+				if strings.HasPrefix(item.text, "{") {
+					return nil, errSQLMultidimensional
+				}
+				return nil, fmt.Errorf("mapsetbool: invalid array element %q", item.text)
+			}
+			item.null = strings.EqualFold(item.text, "NULL")
+			s = s[end:]
+		}
+		items = append(items, item)
+
+		if s == "" {
+			return items, nil
+		}
+		if s[0] != ',' {
+			return nil, fmt.Errorf("mapsetbool: expected ',' in array literal, found %q", s)
+		}
+		s = s[1:]
+	}
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewBoolSQLValue for JSON columns.
+func (set *threadUnsafeBoolSet) Value() (driver.Value, error) {
+	return formatBoolSQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. NULL scans as an empty set.
+// The set is left untouched on error.
+func (set *threadUnsafeBoolSet) Scan(src interface{}) error {
+	elems, err := scanBoolSQL(src)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeBoolSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewBoolSQLValue for JSON columns.
+func (set *threadSafeBoolSet) Value() (driver.Value, error) {
+	return formatBoolSQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. NULL scans as an empty set.
+// The set is left untouched on error.
+func (set *threadSafeBoolSet) Scan(src interface{}) error {
+	elems, err := scanBoolSQL(src)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeBoolSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,57 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	s := NewBoolSet(sampleBoolValues[0])
+	st := EnableStats(s)
+	if EnableStats(s) != st {
+		t.Error("expected EnableStats to return the existing stats")
+	}
+
+	s.Add(sampleBoolValues[1])
+	s.Add(sampleBoolValues[1])
+	s.Contains(sampleBoolValues[0])
+	s.Contains(sampleBoolValues[0], sampleBoolValues[1])
+	s.Remove(sampleBoolValues[1])
+	s.Remove(sampleBoolValues[1])
+	s.Contains(sampleBoolValues[1])
+	s.Pop()
+
+	want := BoolStatsSnapshot{
+		Adds:           1,
+		Removes:        2,
+		Hits:           2,
+		Misses:         1,
+		MaxCardinality: 2,
+	}
+	got := st.Snapshot()
+	if got.LockWaits != 8 {
+		t.Errorf("expected 8 timed lock acquisitions, got %d", got.LockWaits)
+	}
+	got.LockWaits, got.LockWait, got.MaxLockWait = 0, 0, 0
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	var decoded BoolStatsSnapshot
+	if err := json.Unmarshal([]byte(st.String()), &decoded); err != nil || decoded.Adds != 1 {
+		t.Errorf("expected String to hold the snapshot as JSON, got %s, %v", st.String(), err)
+	}
+
+	st.Reset()
+	if got := st.Snapshot(); got != (BoolStatsSnapshot{}) {
+		t.Errorf("expected zero stats after Reset, got %+v", got)
+	}
+
+	DisableStats(s)
+	s.Add(sampleBoolValues[1])
+	if got := st.Snapshot(); got.Adds != 0 {
+		t.Errorf("expected no adds recorded after DisableStats, got %d", got.Adds)
+	}
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s BoolSet) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s BoolSet) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertBoolRoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
 package mapsetbool
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeBoolSet struct {
-	s threadUnsafeBoolSet
+	s     threadUnsafeBoolSet
+	stats atomic.Pointer[BoolSetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeBoolSet() threadSafeBoolSet {
 	return threadSafeBoolSet{s: newThreadUnsafeBoolSet()}
 }
 
+// readLockBoolSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockBoolSets(sets []BoolSet) ([]threadUnsafeBoolSet, func()) {
+	maps := make([]threadUnsafeBoolSet, len(sets))
+	if _, ok := sets[0].(*threadSafeBoolSet); !ok {
+		guards := make([]unsafeBoolGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeBoolSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeBoolSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeBoolSet))
+	}
+	distinct := lockBoolOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeBoolSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockBoolOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockBoolOrder(sets []*threadSafeBoolSet) []*threadSafeBoolSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockBoolPair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockBoolPair(x, y *threadSafeBoolSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockBoolPair releases the locks taken by rlockBoolPair.
+func runlockBoolPair(x, y *threadSafeBoolSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeBoolSet) Add(i bool) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeBoolSet) Contains(i ...bool) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeBoolSet) IsSubset(other BoolSet) bool {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
 func (set *threadSafeBoolSet) IsProperSubset(other BoolSet) bool {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockBoolPair(set, o)
+	defer runlockBoolPair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeBoolSet) IsProperSuperset(other BoolSet) bool {
 func (set *threadSafeBoolSet) Union(other BoolSet) BoolSet {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
+	readBoolPair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeBoolSet)
 	ret := &threadSafeBoolSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
 func (set *threadSafeBoolSet) Intersect(other BoolSet) BoolSet {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
+	readBoolPair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeBoolSet)
 	ret := &threadSafeBoolSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
 func (set *threadSafeBoolSet) Difference(other BoolSet) BoolSet {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
+	readBoolPair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeBoolSet)
 	ret := &threadSafeBoolSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
 func (set *threadSafeBoolSet) SymmetricDifference(other BoolSet) BoolSet {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
+	readBoolPair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeBoolSet)
 	ret := &threadSafeBoolSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
 func (set *threadSafeBoolSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeBoolSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeBoolSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeBoolSet) Remove(i bool) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyBool(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeBoolSet) Cardinality() int {
 }
 
 func (set *threadSafeBoolSet) Each(cb func(bool) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeBoolSet) Iterator() *BoolIterator {
 func (set *threadSafeBoolSet) Equal(other BoolSet) bool {
 	o := other.(*threadSafeBoolSet)
 
-	set.RLock()
-	o.RLock()
+	rlockBoolPair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockBoolPair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeBoolSet) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeBoolSet) PowerSet() BoolSet {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeBoolSet)
-    set.RUnlock()
+func (set *threadSafeBoolSet) PowerSet() []BoolSet {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeBoolSet{s: newThreadUnsafeBoolSet()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeBoolSet)
-        ret.Add(&threadSafeBoolSet{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]BoolSet, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeBoolSet)
+		ret = append(ret, &threadSafeBoolSet{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeBoolSet) Pop() bool {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeBoolSet) CartesianProduct(other BoolSet) BoolSet {
-    o := other.(*threadSafeBoolSet)
+func (set *threadSafeBoolSet) CartesianProduct(other BoolSet) BoolPairSet {
+	o := other.(*threadSafeBoolSet)
 
-    set.RLock()
-    o.RLock()
+	rlockBoolPair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeBoolSet)
-    ret := &threadSafeBoolSet{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeBoolPairSet)
+	ret := &threadSafeBoolPairSet{s: *unsafeCartProduct}
+	runlockBoolPair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeBoolSet) ToSlice() []bool {
-	keys := make([]bool, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]bool, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeBoolSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeBoolSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
 package mapsetbool
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeBoolSet map[bool]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  bool
-	Second bool
-}
-
 func newThreadUnsafeBoolSet() threadUnsafeBoolSet {
 	return make(threadUnsafeBoolSet)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeBoolSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeBoolSetWithCapacity(n int) threadUnsafeBoolSet {
+	return make(threadUnsafeBoolSet, n)
+}
 
-	return false
+// keyBool returns the map key of i.
+func keyBool(i bool) bool {
+	return i
 }
 
 func (set *threadUnsafeBoolSet) Add(i bool) bool {
+	defer set.guardWrite().done()
+	i = keyBool(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeBoolSet) Add(i bool) bool {
 }
 
 func (set *threadUnsafeBoolSet) Contains(i ...bool) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyBool(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeBoolSet) Contains(i ...bool) bool {
 
 func (set *threadUnsafeBoolSet) IsSubset(other BoolSet) bool {
 	_ = other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeBoolSet) IsSubset(other BoolSet) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeBoolSet) IsProperSubset(other BoolSet) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeBoolSet) IsSuperset(other BoolSet) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeBoolSet) IsSuperset(other BoolSet) bool {
 }
 
 func (set *threadUnsafeBoolSet) IsProperSuperset(other BoolSet) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeBoolSet) Union(other BoolSet) BoolSet {
 	o := other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeBoolSet()
+	unionedSet := newThreadUnsafeBoolSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeBoolSet) Union(other BoolSet) BoolSet {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackBoolSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeBoolSet) Intersect(other BoolSet) BoolSet {
 	o := other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeBoolSet()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeBoolSet) Intersect(other BoolSet) BoolSet {
 			}
 		}
 	}
+	trackBoolSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeBoolSet) Difference(other BoolSet) BoolSet {
 	_ = other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeBoolSet()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeBoolSet) Difference(other BoolSet) BoolSet {
 			difference.Add(elem)
 		}
 	}
+	trackBoolSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeBoolSet) SymmetricDifference(other BoolSet) BoolSet {
-	_ = other.(*threadUnsafeBoolSet)
+	o := other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeBoolSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackBoolSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeBoolSet) Clear() {
-	*set = newThreadUnsafeBoolSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeBoolSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeBoolSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeBoolSet) Remove(i bool) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyBool(i))
 }
 
 func (set *threadUnsafeBoolSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeBoolSet) Each(cb func(bool) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeBoolSet) Each(cb func(bool) bool) {
 func (set *threadUnsafeBoolSet) Iter() <-chan bool {
 	ch := make(chan bool)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeBoolSet) Iterator() *BoolIterator {
 	iterator, ch, stopCh := newBoolIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeBoolSet) Iterator() *BoolIterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeBoolSet) Iterator() *BoolIterator {
 
 func (set *threadUnsafeBoolSet) Equal(other BoolSet) bool {
 	_ = other.(*threadUnsafeBoolSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeBoolSet) Equal(other BoolSet) bool {
 }
 
 func (set *threadUnsafeBoolSet) Clone() BoolSet {
-	clonedSet := newThreadUnsafeBoolSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeBoolSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackBoolSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeBoolSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeBoolSet) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeBoolSet) Pop() bool {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeBoolSet) Pop() bool {
 	return false
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeBoolSet) PowerSet() BoolSet {
-	powSet := NewThreadUnsafeBoolSet()
+func (set *threadUnsafeBoolSet) PowerSet() []BoolSet {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeBoolSet()
-	powSet.Add(&nullset)
+	powSet := []BoolSet{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeBoolSet()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeBoolSet()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeBoolSet)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeBoolSet) CartesianProduct(other BoolSet) BoolSet {
+func (set *threadUnsafeBoolSet) CartesianProduct(other BoolSet) BoolPairSet {
 	o := other.(*threadUnsafeBoolSet)
-	cartProduct := NewThreadUnsafeBoolSet()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeBoolPairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := BoolPair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeBoolSet) ToSlice() []bool {
+	defer set.guardRead().done()
 	keys := make([]bool, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeBoolSet) ToSlice() []bool {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeBoolSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, BoolJSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeBoolSet) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeBoolSet) UnmarshalJSON(data []byte) error {
 	var i []bool
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
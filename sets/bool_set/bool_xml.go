@@ -0,0 +1,154 @@
+// Code generated by generate_set for bool. DO NOT EDIT.
+
+package mapsetbool
+
+import (
+	"encoding/xml"
+)
+
+// DefaultBoolXMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultBoolXMLItemName = "item"
+
+// BoolXML adapts a BoolSet to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type BoolXML struct {
+	Set BoolSet
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultBoolXMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x BoolXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeBoolXML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *BoolXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeBoolXML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x BoolXML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultBoolXMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeBoolXML(e *xml.Encoder, start xml.StartElement, s BoolSet, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortBoolElements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatBoolText(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeBoolXML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeBoolXML(dec *xml.Decoder, itemName string) ([]bool, error) {
+	var elems []bool
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseBoolText(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultBoolXMLItemName. Use BoolXML for other
+// names.
+func (set *threadUnsafeBoolSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeBoolXML(e, start, set, DefaultBoolXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeBoolSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeBoolXML(d, DefaultBoolXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeBoolSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultBoolXMLItemName. Use BoolXML for other
+// names.
+func (set *threadSafeBoolSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeBoolXML(e, start, set, DefaultBoolXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeBoolSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeBoolXML(d, DefaultBoolXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeBoolSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
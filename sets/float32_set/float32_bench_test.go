@@ -0,0 +1,86 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Float32Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleFloat32Values[i%len(sampleFloat32Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewFloat32Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeFloat32Set())
+}
+
+func benchContains(b *testing.B, s Float32Set) {
+	for _, v := range sampleFloat32Values[:len(sampleFloat32Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleFloat32Values[i%len(sampleFloat32Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewFloat32Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeFloat32Set())
+}
+
+func benchUnion(b *testing.B, x, y Float32Set) {
+	half := len(sampleFloat32Values) / 2
+	for _, v := range sampleFloat32Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleFloat32Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewFloat32Set(), NewFloat32Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeFloat32Set(), NewThreadUnsafeFloat32Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Float32Set) {
+	for _, v := range sampleFloat32Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewFloat32Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeFloat32Set())
+}
@@ -0,0 +1,143 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// binaryFormatVersion is the first byte of every binary encoding.
+// Encodings in this package start with a version byte that decoders
+// check, so any change to a format must bump its version.
+const binaryFormatVersion byte = 1
+
+var errBinaryTruncated = errors.New("mapsetfloat32: truncated binary data")
+
+// appendFloat32Element appends the length-prefixed binary encoding
+// of elem to b.
+func appendFloat32Element(b []byte, elem float32) ([]byte, error) {
+	b = binary.AppendUvarint(b, 4)
+	return binary.BigEndian.AppendUint32(b, math.Float32bits(float32(elem))), nil
+}
+
+// decodeFloat32Element decodes a single element payload written by
+// appendFloat32Element, without its length prefix.
+func decodeFloat32Element(payload []byte) (float32, error) {
+	if len(payload) != 4 {
+		return 0, fmt.Errorf("mapsetfloat32: invalid float32 payload %v", payload)
+	}
+	return float32(math.Float32frombits(binary.BigEndian.Uint32(payload))), nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadUnsafeFloat32Set) MarshalBinary() ([]byte, error) {
+	defer set.guardRead().done()
+	b := []byte{binaryFormatVersion}
+	b = binary.AppendUvarint(b, uint64(len(*set)))
+
+	var err error
+	for elem := range *set {
+		b, err = appendFloat32Element(b, elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadUnsafeFloat32Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalFloat32Binary(data)
+	if err != nil {
+		return err
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadUnsafeFloat32Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadUnsafeFloat32Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+func unmarshalFloat32Binary(data []byte) (threadUnsafeFloat32Set, error) {
+	if len(data) == 0 {
+		return nil, errBinaryTruncated
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, fmt.Errorf("mapsetfloat32: unsupported binary format version %d", data[0])
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errBinaryTruncated
+	}
+	data = data[n:]
+
+	set := newThreadUnsafeFloat32Set()
+	for i := uint64(0); i < count; i++ {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, errBinaryTruncated
+		}
+		data = data[n:]
+
+		elem, err := decodeFloat32Element(data[:size])
+		if err != nil {
+			return nil, err
+		}
+		set.Add(elem)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("mapsetfloat32: %d bytes of trailing binary data", len(data))
+	}
+	return set, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadSafeFloat32Set) MarshalBinary() ([]byte, error) {
+	set.RLock()
+	b, err := set.s.MarshalBinary()
+	set.RUnlock()
+
+	return b, err
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadSafeFloat32Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalFloat32Binary(data)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = decoded
+	set.Unlock()
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadSafeFloat32Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadSafeFloat32Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
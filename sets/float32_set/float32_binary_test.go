@@ -0,0 +1,14 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s Float32Set) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s Float32Set) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertFloat32RoundTrip(t, marshal, unmarshal)
+}
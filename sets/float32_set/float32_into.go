@@ -0,0 +1,195 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeFloat32Set()
+		return &s
+	},
+}
+
+// NewScratchFloat32Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchFloat32Set() Float32Set {
+	return scratchPool.Get().(*threadUnsafeFloat32Set)
+}
+
+// Release empties s and makes it available to NewScratchFloat32Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Float32Set) {
+	u, ok := s.(*threadUnsafeFloat32Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Float32Set) {
+	d, x, y, locks := lockFloat32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllFloat32(d, y)
+	case dst == b:
+		addAllFloat32(d, x)
+	default:
+		clear(d)
+		addAllFloat32(d, x)
+		addAllFloat32(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Float32Set) {
+	d, x, y, locks := lockFloat32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyFloat32(d, y)
+	case dst == b:
+		keepOnlyFloat32(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Float32Set) {
+	d, x, y, locks := lockFloat32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchFloat32Set().(*threadUnsafeFloat32Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllFloat32(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllFloat32(dst, src threadUnsafeFloat32Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyFloat32 removes the elements of dst that are not in other.
+func keepOnlyFloat32(dst, other threadUnsafeFloat32Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoFloat32Locks records the locks taken by lockFloat32Into, or the guards for
+// thread-unsafe sets.
+type intoFloat32Locks struct {
+	sets     [3]*threadSafeFloat32Set
+	distinct int
+	dst      *threadSafeFloat32Set
+	guards   [3]unsafeFloat32Guard
+}
+
+// lockFloat32Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockFloat32Into(dst, a, b Float32Set) (d, x, y threadUnsafeFloat32Set, locks intoFloat32Locks) {
+	w, ok := dst.(*threadSafeFloat32Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeFloat32Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeFloat32Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeFloat32Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeFloat32Set), *b.(*threadUnsafeFloat32Set), locks
+	}
+
+	locks.sets = [3]*threadSafeFloat32Set{w, a.(*threadSafeFloat32Set), b.(*threadSafeFloat32Set)}
+	locks.distinct = len(lockFloat32Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeFloat32Set).s, b.(*threadSafeFloat32Set).s, locks
+}
+
+func (l intoFloat32Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleFloat32Values)
+	aValues, bValues := sampleFloat32Values[:n-1], sampleFloat32Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Float32Set)
+		expected []float32
+	}{
+		{"UnionInto", UnionInto, sampleFloat32Values},
+		{"IntersectInto", IntersectInto, sampleFloat32Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleFloat32Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range float32SetFactories() {
+			expected := fillFloat32Set(newSet(), testCase.expected...)
+
+			dst := fillFloat32Set(newSet(), sampleFloat32Values[n-1])
+			testCase.into(dst, fillFloat32Set(newSet(), aValues...), fillFloat32Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillFloat32Set(newSet(), aValues...)
+			testCase.into(a, a, fillFloat32Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillFloat32Set(newSet(), bValues...)
+			testCase.into(b, fillFloat32Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range float32SetFactories() {
+		s := fillFloat32Set(newSet(), sampleFloat32Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchFloat32Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleFloat32Values[0])
+	Release(s)
+	Release(NewFloat32Set(sampleFloat32Values[0]))
+
+	if again := NewScratchFloat32Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
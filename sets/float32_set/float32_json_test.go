@@ -0,0 +1,49 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Float32Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Float32Set) error { return json.Unmarshal(b, s) }
+	assertFloat32RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewFloat32Set(sampleFloat32Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewFloat32Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleFloat32Values[i%len(sampleFloat32Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewFloat32Set(sampleFloat32Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleFloat32Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetfloat32
+
+// unsafeFloat32Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeFloat32Guard struct{}
+
+func trackFloat32Set(set *threadUnsafeFloat32Set) {}
+
+func (set *threadUnsafeFloat32Set) guardRead() unsafeFloat32Guard { return unsafeFloat32Guard{} }
+
+func (set *threadUnsafeFloat32Set) guardWrite() unsafeFloat32Guard { return unsafeFloat32Guard{} }
+
+func (set *threadUnsafeFloat32Set) guardIterate() unsafeFloat32Guard { return unsafeFloat32Guard{} }
+
+func (unsafeFloat32Guard) done() {}
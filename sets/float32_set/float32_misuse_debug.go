@@ -0,0 +1,156 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+//go:build mapsetdebug
+
+package mapsetfloat32
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Building with the mapsetdebug tag checks every thread-unsafe set made
+// by NewThreadUnsafeFloat32Set and its variants for use from several goroutines
+// at once. Instead of the runtime's bare "concurrent map writes", misuse
+// panics with a message naming where the set was created:
+//
+//	go test -tags mapsetdebug ./...
+//
+// The checks are best effort: they catch operations that overlap in
+// time, which is also when the runtime would fail, and cost an atomic
+// operation and a map lookup per call. A goroutine may modify a set
+// from its own Each callback, as ranging over a map allows; only other
+// goroutines are caught.
+
+// setFloat32Guard counts the operations in progress on one thread-unsafe set.
+type setFloat32Guard struct {
+	readers atomic.Int32
+	writers atomic.Int32
+	stack   []byte
+
+	// iterating counts the Each calls in progress on each goroutine,
+	// which count as readers but may write to the set themselves.
+	mu        sync.Mutex
+	iterating map[int64]int32
+}
+
+// setFloat32Guards maps the address of each tracked set to its guard. Keys are
+// not pointers, so the table does not keep sets alive; a finalizer
+// removes the entry once a set is collected.
+var setFloat32Guards sync.Map
+
+type unsafeFloat32Guard struct {
+	g     *setFloat32Guard
+	write bool
+	goid  int64 // the iterating goroutine, for Each
+}
+
+// trackFloat32Set starts checking set, recording the caller's stack as
+// the place it was created. set must be heap allocated.
+func trackFloat32Set(set *threadUnsafeFloat32Set) {
+	setFloat32Guards.Store(uintptr(unsafe.Pointer(set)), &setFloat32Guard{stack: debug.Stack()})
+	runtime.SetFinalizer(set, func(set *threadUnsafeFloat32Set) {
+		setFloat32Guards.Delete(uintptr(unsafe.Pointer(set)))
+	})
+}
+
+func (set *threadUnsafeFloat32Set) guard() *setFloat32Guard {
+	g, ok := setFloat32Guards.Load(uintptr(unsafe.Pointer(set)))
+	if !ok {
+		return nil
+	}
+	return g.(*setFloat32Guard)
+}
+
+func (set *threadUnsafeFloat32Set) guardRead() unsafeFloat32Guard {
+	g := set.guard()
+	if g == nil {
+		return unsafeFloat32Guard{}
+	}
+	g.readers.Add(1)
+	if g.writers.Load() != 0 {
+		g.readers.Add(-1)
+		g.misuse("read during a concurrent write")
+	}
+	return unsafeFloat32Guard{g: g}
+}
+
+func (set *threadUnsafeFloat32Set) guardWrite() unsafeFloat32Guard {
+	g := set.guard()
+	if g == nil {
+		return unsafeFloat32Guard{}
+	}
+	if !g.writers.CompareAndSwap(0, 1) {
+		g.misuse("concurrent writes")
+	}
+	if readers := g.readers.Load(); readers != 0 && readers > g.iteratingOn(goroutineFloat32ID()) {
+		g.writers.Add(-1)
+		g.misuse("write during a concurrent read")
+	}
+	return unsafeFloat32Guard{g: g, write: true}
+}
+
+// guardIterate is guardRead for Each, whose callback may write to the
+// set from the same goroutine.
+func (set *threadUnsafeFloat32Set) guardIterate() unsafeFloat32Guard {
+	u := set.guardRead()
+	if u.g == nil {
+		return u
+	}
+	u.goid = goroutineFloat32ID()
+	u.g.mu.Lock()
+	if u.g.iterating == nil {
+		u.g.iterating = map[int64]int32{}
+	}
+	u.g.iterating[u.goid]++
+	u.g.mu.Unlock()
+	return u
+}
+
+// iteratingOn returns the number of Each calls in progress on goroutine
+// goid.
+func (g *setFloat32Guard) iteratingOn(goid int64) int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.iterating[goid]
+}
+
+func (u unsafeFloat32Guard) done() {
+	switch {
+	case u.g == nil:
+	case u.write:
+		u.g.writers.Add(-1)
+	default:
+		if u.goid != 0 {
+			u.g.mu.Lock()
+			if u.g.iterating[u.goid]--; u.g.iterating[u.goid] == 0 {
+				delete(u.g.iterating, u.goid)
+			}
+			u.g.mu.Unlock()
+		}
+		u.g.readers.Add(-1)
+	}
+}
+
+// goroutineFloat32ID returns the ID of the calling goroutine, parsed from its
+// stack trace. It is slow, but only needed to tell Each callbacks apart
+// from other goroutines.
+func goroutineFloat32ID() int64 {
+	var buf [64]byte
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
+
+func (g *setFloat32Guard) misuse(what string) {
+	panic(fmt.Sprintf("mapsetfloat32: %s on a thread-unsafe set; use NewFloat32Set for sets shared between goroutines. The set was created at:\n\n%s", what, g.stack))
+}
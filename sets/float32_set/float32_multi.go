@@ -0,0 +1,110 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Float32Set) Float32Set {
+	if len(sets) == 0 {
+		return NewFloat32Set()
+	}
+	maps, unlock := readLockFloat32Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeFloat32Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapFloat32Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Float32Set) Float32Set {
+	if len(sets) == 0 {
+		return NewFloat32Set()
+	}
+	maps, unlock := readLockFloat32Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeFloat32Set()
+	for elem := range maps[smallest] {
+		if containedInAllFloat32(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapFloat32Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Float32Set, sets ...Float32Set) Float32Set {
+	maps, unlock := readLockFloat32Sets(append([]Float32Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeFloat32Set()
+	for elem := range maps[0] {
+		if !containedInAnyFloat32(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapFloat32Like(base, difference)
+}
+
+func containedInAllFloat32(maps []threadUnsafeFloat32Set, skip int, elem float32) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyFloat32(maps []threadUnsafeFloat32Set, elem float32) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapFloat32Like returns s as a Set of the same implementation as like.
+func wrapFloat32Like(like Float32Set, s threadUnsafeFloat32Set) Float32Set {
+	if _, ok := like.(*threadSafeFloat32Set); ok {
+		return &threadSafeFloat32Set{s: s}
+	}
+	trackFloat32Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Float32Pair represents a 2-tuple of values.
+type Float32Pair struct {
+	First  float32
+	Second float32
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Float32Pair) Equal(other Float32Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Float32Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Float32PairSet is an unordered set of Float32Pairs, as
+// returned by CartesianProduct.
+type Float32PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Float32Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Float32Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Float32Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Float32PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Float32Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Float32Pair
+}
+
+type threadUnsafeFloat32PairSet map[Float32Pair]struct{}
+
+func newThreadUnsafeFloat32PairSet() threadUnsafeFloat32PairSet {
+	return make(threadUnsafeFloat32PairSet)
+}
+
+func (set *threadUnsafeFloat32PairSet) Add(p Float32Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeFloat32PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeFloat32PairSet) Contains(p ...Float32Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeFloat32PairSet) Each(cb func(Float32Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeFloat32PairSet) Equal(other Float32PairSet) bool {
+	_ = other.(*threadUnsafeFloat32PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeFloat32PairSet) Remove(p Float32Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeFloat32PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeFloat32PairSet) ToSlice() []Float32Pair {
+	keys := make([]Float32Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeFloat32PairSet struct {
+	s threadUnsafeFloat32PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeFloat32PairSet) Add(p Float32Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeFloat32PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeFloat32PairSet) Contains(p ...Float32Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeFloat32PairSet) Each(cb func(Float32Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockFloat32PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockFloat32Pair does for sets.
+func rlockFloat32PairSets(x, y *threadSafeFloat32PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockFloat32PairSets releases the locks taken by rlockFloat32PairSets.
+func runlockFloat32PairSets(x, y *threadSafeFloat32PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeFloat32PairSet) Equal(other Float32PairSet) bool {
+	o := other.(*threadSafeFloat32PairSet)
+
+	rlockFloat32PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockFloat32PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeFloat32PairSet) Remove(p Float32Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeFloat32PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeFloat32PairSet) ToSlice() []Float32Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
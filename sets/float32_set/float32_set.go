@@ -1,3 +1,5 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
 package mapsetfloat32
 
 // Float32Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Float32Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Float32Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Float32Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() float32
 
-	// Returns all subsets of a given set (Power Float32Set).
-	// Not yet supported
-	// PowerSet() Float32Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Float32Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Float32Set) Float32Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Float32Set) Float32PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []float32
@@ -145,7 +154,7 @@ type Float32Set interface {
 // NewFloat32Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewFloat32Set(s ...float32) Float32Set {
-	set := newThreadSafeFloat32Set()
+	set := threadSafeFloat32Set{s: newThreadUnsafeFloat32SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewFloat32SetFromSlice(s []float32) Float32Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeFloat32Set() Float32Set {
 	set := newThreadUnsafeFloat32Set()
+	trackFloat32Set(&set)
+	return &set
+}
+
+// NewFloat32SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewFloat32SetWithCapacity(n int) Float32Set {
+	return &threadSafeFloat32Set{s: newThreadUnsafeFloat32SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeFloat32SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeFloat32SetWithCapacity(n int) Float32Set {
+	set := newThreadUnsafeFloat32SetWithCapacity(n)
+	trackFloat32Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeFloat32Set() Float32Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeFloat32SetFromSlice(s []float32) Float32Set {
-	a := NewThreadUnsafeFloat32Set()
+	a := NewThreadUnsafeFloat32SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,105 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleFloat32Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleFloat32Values = []float32{
+	-2.5,
+	-1,
+	0,
+	0.25,
+	1,
+	3.75,
+	1e6,
+	-0.001,
+}
+
+// float32SetFactories returns constructors for both implementations.
+func float32SetFactories() map[string]func() Float32Set {
+	return map[string]func() Float32Set{
+		"safe":   func() Float32Set { return NewFloat32Set() },
+		"unsafe": NewThreadUnsafeFloat32Set,
+	}
+}
+
+// fillFloat32Set adds values to s and returns it.
+func fillFloat32Set(s Float32Set, values ...float32) Float32Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertFloat32RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertFloat32RoundTrip(t *testing.T, marshal func(Float32Set) ([]byte, error), unmarshal func([]byte, Float32Set) error) {
+	t.Helper()
+	contents := [][]float32{sampleFloat32Values, sampleFloat32Values[:1]}
+	for name, newSet := range float32SetFactories() {
+		for _, values := range contents {
+			s := fillFloat32Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range float32SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[float32, Float32Set]{
+				New:   newSet,
+				Elems: sampleFloat32Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range float32SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleFloat32Values[0])
+		a.Add(sampleFloat32Values[1])
+		for _, v := range sampleFloat32Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleFloat32Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Float32Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// hashFloat32Element returns a hash of elem that is stable across
+// processes, using buf as scratch space, and the grown buffer. Elements
+// without a binary encoding are hashed by their formatted value. elem
+// is hashed as the set stores it, so that filters built from a set find
+// every value the set contains.
+func hashFloat32Element(buf []byte, elem float32) (uint64, []byte) {
+	elem = keyFloat32(elem)
+	b, err := appendFloat32Element(buf, elem)
+	if err != nil {
+		b = fmt.Appendf(buf, "%#v", elem)
+	}
+	return sketch.Hash64(b, 0), b
+}
+
+// MinHashSignature returns the MinHash signature of set under hasher.
+// Elements are hashed by their binary encoding, so signatures of
+// Float32Sets are comparable with each other but not with those of
+// other set types.
+func MinHashSignature(set Float32Set, hasher *sketch.MinHasher) sketch.Signature {
+	sig := hasher.NewSignature()
+	var buf []byte
+	set.Each(func(elem float32) bool {
+		var h uint64
+		h, buf = hashFloat32Element(buf[:0], elem)
+		hasher.Push(sig, h)
+		return false
+	})
+	return sig
+}
+
+// ToHyperLogLog returns a HyperLogLog sketch of set with the given
+// precision, hashing elements as MinHashSignature does, so that it can
+// be merged with sketches of other Float32Sets.
+func ToHyperLogLog(set Float32Set, precision uint8) *sketch.HyperLogLog {
+	hll := sketch.NewHyperLogLog(precision)
+	var buf []byte
+	set.Each(func(elem float32) bool {
+		var x uint64
+		x, buf = hashFloat32Element(buf[:0], elem)
+		hll.AddHash(x)
+		return false
+	})
+	return hll
+}
+
+// Float32BloomFilter is a sketch.BloomFilter over float32 elements, which hashes
+// elements as MinHashSignature does. A decoded filter answers queries
+// the same way as the one that was encoded.
+type Float32BloomFilter struct {
+	sketch.BloomFilter
+}
+
+// ToBloomFilter returns a Bloom filter holding the elements of set,
+// sized for a false positive rate of about fpRate.
+func ToBloomFilter(set Float32Set, fpRate float64) *Float32BloomFilter {
+	filter := &Float32BloomFilter{*sketch.NewBloomFilter(set.Cardinality(), fpRate)}
+	var buf []byte
+	set.Each(func(elem float32) bool {
+		var h uint64
+		h, buf = hashFloat32Element(buf[:0], elem)
+		filter.AddHash(h)
+		return false
+	})
+	return filter
+}
+
+// Add adds elem to the filter.
+func (f *Float32BloomFilter) Add(elem float32) {
+	h, _ := hashFloat32Element(nil, elem)
+	f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Float32BloomFilter) MightContain(elem float32) bool {
+	h, _ := hashFloat32Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Float32CuckooFilter is a sketch.CuckooFilter over float32 elements, which hashes
+// elements as MinHashSignature does.
+type Float32CuckooFilter struct {
+	sketch.CuckooFilter
+}
+
+// ToCuckooFilter returns a cuckoo filter holding the elements of set,
+// sized for a false positive rate of about fpRate. Elements with equal
+// hashes, such as NaNs, are added once. The filter is grown until every
+// element fits, up to a limit, past which an error is returned.
+func ToCuckooFilter(set Float32Set, fpRate float64) (*Float32CuckooFilter, error) {
+	var hashes []uint64
+	var buf []byte
+	set.Each(func(elem float32) bool {
+		var h uint64
+		h, buf = hashFloat32Element(buf[:0], elem)
+		hashes = append(hashes, h)
+		return false
+	})
+	f, err := newCuckooFilterFromHashes(hashes, fpRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Float32CuckooFilter{*f}, nil
+}
+
+// newCuckooFilterFromHashes returns a filter holding the distinct hashes,
+// doubling its capacity at most cuckooMaxGrowths times.
+func newCuckooFilterFromHashes(hashes []uint64, fpRate float64) (*sketch.CuckooFilter, error) {
+	// A bucket pair holds eight fingerprints, so repeats of one hash
+	// would collide however large the filter grows.
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	const cuckooMaxGrowths = 8
+	n := len(hashes)
+	for i := 0; i <= cuckooMaxGrowths; i++ {
+		f := sketch.NewCuckooFilter(n, fpRate)
+		if addAllHashes(f, hashes) {
+			return f, nil
+		}
+		n *= 2
+	}
+	return nil, fmt.Errorf("mapsetfloat32: cannot fit %d distinct hashes in a cuckoo filter", len(hashes))
+}
+
+func addAllHashes(f *sketch.CuckooFilter, hashes []uint64) bool {
+	for _, h := range hashes {
+		if !f.AddHash(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds elem to the filter and reports whether there was room.
+func (f *Float32CuckooFilter) Add(elem float32) bool {
+	h, _ := hashFloat32Element(nil, elem)
+	return f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Float32CuckooFilter) MightContain(elem float32) bool {
+	h, _ := hashFloat32Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Delete removes elem from the filter and reports whether it was found.
+// Only delete elements that were added.
+func (f *Float32CuckooFilter) Delete(elem float32) bool {
+	h, _ := hashFloat32Element(nil, elem)
+	return f.DeleteHash(h)
+}
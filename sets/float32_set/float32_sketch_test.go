@@ -0,0 +1,52 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewFloat32Set(sampleFloat32Values...)
+	queries := s.ToSlice()
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewFloat32Set(sampleFloat32Values...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleFloat32Values) {
+		t.Errorf("expected %d elements, got %d", len(sampleFloat32Values), f.Len())
+	}
+	for _, v := range sampleFloat32Values {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+
+	// NaNs are distinct keys with equal hashes, which must be added once
+	// rather than grow the filter forever.
+	nans := NewThreadUnsafeFloat32Set()
+	for i := 0; i < 100; i++ {
+		nans.Add(float32(math.NaN()))
+	}
+	if f, err := ToCuckooFilter(nans, 0.001); err != nil || f.Len() != 1 {
+		t.Errorf("expected the NaNs to be added once, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"cmp"
+	"sort"
+)
+
+// lessFloat32 orders elements for output that must be stable,
+// such as sorted encodings. NaN orders before every other number.
+func lessFloat32(a, b float32) bool {
+	return cmp.Less(a, b)
+}
+
+// sortFloat32Elements sorts elems in place using lessFloat32.
+func sortFloat32Elements(elems []float32) {
+	sort.Slice(elems, func(i, j int) bool { return lessFloat32(elems[i], elems[j]) })
+}
@@ -0,0 +1,41 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]float32, 0, len(sampleFloat32Values))
+	for i := len(sampleFloat32Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleFloat32Values[i])
+	}
+	sortFloat32Elements(elems)
+	for i, elem := range elems {
+		if lessFloat32(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessFloat32(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewFloat32Set(elems...).Equal(NewFloat32Set(sampleFloat32Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleFloat32Values, elems)
+	}
+}
+
+func TestSortNaN(t *testing.T) {
+	nan := float32(math.NaN())
+	elems := []float32{1, nan, -1, nan, 0}
+	sortFloat32Elements(elems)
+	if !math.IsNaN(float64(elems[0])) || !math.IsNaN(float64(elems[1])) {
+		t.Fatalf("expected the NaNs first, got %v", elems)
+	}
+	for i, want := range []float32{-1, 0, 1} {
+		if elems[i+2] != want {
+			t.Errorf("expected %v at %d, got %v", want, i+2, elems)
+		}
+	}
+}
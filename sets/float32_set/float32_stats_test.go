@@ -0,0 +1,57 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	s := NewFloat32Set(sampleFloat32Values[0])
+	st := EnableStats(s)
+	if EnableStats(s) != st {
+		t.Error("expected EnableStats to return the existing stats")
+	}
+
+	s.Add(sampleFloat32Values[1])
+	s.Add(sampleFloat32Values[1])
+	s.Contains(sampleFloat32Values[0])
+	s.Contains(sampleFloat32Values[0], sampleFloat32Values[1])
+	s.Remove(sampleFloat32Values[1])
+	s.Remove(sampleFloat32Values[1])
+	s.Contains(sampleFloat32Values[1])
+	s.Pop()
+
+	want := Float32StatsSnapshot{
+		Adds:           1,
+		Removes:        2,
+		Hits:           2,
+		Misses:         1,
+		MaxCardinality: 2,
+	}
+	got := st.Snapshot()
+	if got.LockWaits != 8 {
+		t.Errorf("expected 8 timed lock acquisitions, got %d", got.LockWaits)
+	}
+	got.LockWaits, got.LockWait, got.MaxLockWait = 0, 0, 0
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	var decoded Float32StatsSnapshot
+	if err := json.Unmarshal([]byte(st.String()), &decoded); err != nil || decoded.Adds != 1 {
+		t.Errorf("expected String to hold the snapshot as JSON, got %s, %v", st.String(), err)
+	}
+
+	st.Reset()
+	if got := st.Snapshot(); got != (Float32StatsSnapshot{}) {
+		t.Errorf("expected zero stats after Reset, got %+v", got)
+	}
+
+	DisableStats(s)
+	s.Add(sampleFloat32Values[1])
+	if got := st.Snapshot(); got.Adds != 0 {
+		t.Errorf("expected no adds recorded after DisableStats, got %d", got.Adds)
+	}
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Float32Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Float32Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertFloat32RoundTrip(t, marshal, unmarshal)
+}
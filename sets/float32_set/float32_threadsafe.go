@@ -1,11 +1,16 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
 package mapsetfloat32
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeFloat32Set struct {
-	s threadUnsafeFloat32Set
+	s     threadUnsafeFloat32Set
+	stats atomic.Pointer[Float32SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeFloat32Set() threadSafeFloat32Set {
 	return threadSafeFloat32Set{s: newThreadUnsafeFloat32Set()}
 }
 
+// readLockFloat32Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockFloat32Sets(sets []Float32Set) ([]threadUnsafeFloat32Set, func()) {
+	maps := make([]threadUnsafeFloat32Set, len(sets))
+	if _, ok := sets[0].(*threadSafeFloat32Set); !ok {
+		guards := make([]unsafeFloat32Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeFloat32Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeFloat32Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeFloat32Set))
+	}
+	distinct := lockFloat32Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeFloat32Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockFloat32Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockFloat32Order(sets []*threadSafeFloat32Set) []*threadSafeFloat32Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockFloat32Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockFloat32Pair(x, y *threadSafeFloat32Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockFloat32Pair releases the locks taken by rlockFloat32Pair.
+func runlockFloat32Pair(x, y *threadSafeFloat32Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeFloat32Set) Add(i float32) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeFloat32Set) Contains(i ...float32) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) IsSubset(other Float32Set) bool {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) IsProperSubset(other Float32Set) bool {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockFloat32Pair(set, o)
+	defer runlockFloat32Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeFloat32Set) IsProperSuperset(other Float32Set) bool {
 func (set *threadSafeFloat32Set) Union(other Float32Set) Float32Set {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
+	readFloat32Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeFloat32Set)
 	ret := &threadSafeFloat32Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) Intersect(other Float32Set) Float32Set {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
+	readFloat32Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeFloat32Set)
 	ret := &threadSafeFloat32Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) Difference(other Float32Set) Float32Set {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
+	readFloat32Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeFloat32Set)
 	ret := &threadSafeFloat32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) SymmetricDifference(other Float32Set) Float32Set {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
+	readFloat32Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeFloat32Set)
 	ret := &threadSafeFloat32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat32Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeFloat32Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeFloat32Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeFloat32Set) Remove(i float32) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyFloat32(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeFloat32Set) Cardinality() int {
 }
 
 func (set *threadSafeFloat32Set) Each(cb func(float32) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeFloat32Set) Iterator() *Float32Iterator {
 func (set *threadSafeFloat32Set) Equal(other Float32Set) bool {
 	o := other.(*threadSafeFloat32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat32Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat32Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeFloat32Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeFloat32Set) PowerSet() Float32Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeFloat32Set)
-    set.RUnlock()
+func (set *threadSafeFloat32Set) PowerSet() []Float32Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeFloat32Set{s: newThreadUnsafeFloat32Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeFloat32Set)
-        ret.Add(&threadSafeFloat32Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Float32Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeFloat32Set)
+		ret = append(ret, &threadSafeFloat32Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeFloat32Set) Pop() float32 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeFloat32Set) CartesianProduct(other Float32Set) Float32Set {
-    o := other.(*threadSafeFloat32Set)
+func (set *threadSafeFloat32Set) CartesianProduct(other Float32Set) Float32PairSet {
+	o := other.(*threadSafeFloat32Set)
 
-    set.RLock()
-    o.RLock()
+	rlockFloat32Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeFloat32Set)
-    ret := &threadSafeFloat32Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeFloat32PairSet)
+	ret := &threadSafeFloat32PairSet{s: *unsafeCartProduct}
+	runlockFloat32Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeFloat32Set) ToSlice() []float32 {
-	keys := make([]float32, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]float32, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeFloat32Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeFloat32Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
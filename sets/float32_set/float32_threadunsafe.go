@@ -1,7 +1,10 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
 package mapsetfloat32
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeFloat32Set map[float32]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  float32
-	Second float32
-}
-
 func newThreadUnsafeFloat32Set() threadUnsafeFloat32Set {
 	return make(threadUnsafeFloat32Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeFloat32SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeFloat32SetWithCapacity(n int) threadUnsafeFloat32Set {
+	return make(threadUnsafeFloat32Set, n)
+}
 
-	return false
+// keyFloat32 returns the map key of i.
+func keyFloat32(i float32) float32 {
+	return i
 }
 
 func (set *threadUnsafeFloat32Set) Add(i float32) bool {
+	defer set.guardWrite().done()
+	i = keyFloat32(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeFloat32Set) Add(i float32) bool {
 }
 
 func (set *threadUnsafeFloat32Set) Contains(i ...float32) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyFloat32(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeFloat32Set) Contains(i ...float32) bool {
 
 func (set *threadUnsafeFloat32Set) IsSubset(other Float32Set) bool {
 	_ = other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeFloat32Set) IsSubset(other Float32Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeFloat32Set) IsProperSubset(other Float32Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeFloat32Set) IsSuperset(other Float32Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeFloat32Set) IsSuperset(other Float32Set) bool {
 }
 
 func (set *threadUnsafeFloat32Set) IsProperSuperset(other Float32Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeFloat32Set) Union(other Float32Set) Float32Set {
 	o := other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeFloat32Set()
+	unionedSet := newThreadUnsafeFloat32SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeFloat32Set) Union(other Float32Set) Float32Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackFloat32Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeFloat32Set) Intersect(other Float32Set) Float32Set {
 	o := other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeFloat32Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeFloat32Set) Intersect(other Float32Set) Float32Set {
 			}
 		}
 	}
+	trackFloat32Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeFloat32Set) Difference(other Float32Set) Float32Set {
 	_ = other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeFloat32Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeFloat32Set) Difference(other Float32Set) Float32Set {
 			difference.Add(elem)
 		}
 	}
+	trackFloat32Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeFloat32Set) SymmetricDifference(other Float32Set) Float32Set {
-	_ = other.(*threadUnsafeFloat32Set)
+	o := other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeFloat32Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackFloat32Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeFloat32Set) Clear() {
-	*set = newThreadUnsafeFloat32Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeFloat32Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeFloat32SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeFloat32Set) Remove(i float32) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyFloat32(i))
 }
 
 func (set *threadUnsafeFloat32Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeFloat32Set) Each(cb func(float32) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeFloat32Set) Each(cb func(float32) bool) {
 func (set *threadUnsafeFloat32Set) Iter() <-chan float32 {
 	ch := make(chan float32)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeFloat32Set) Iterator() *Float32Iterator {
 	iterator, ch, stopCh := newFloat32Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeFloat32Set) Iterator() *Float32Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeFloat32Set) Iterator() *Float32Iterator {
 
 func (set *threadUnsafeFloat32Set) Equal(other Float32Set) bool {
 	_ = other.(*threadUnsafeFloat32Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeFloat32Set) Equal(other Float32Set) bool {
 }
 
 func (set *threadUnsafeFloat32Set) Clone() Float32Set {
-	clonedSet := newThreadUnsafeFloat32Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeFloat32SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackFloat32Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeFloat32Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeFloat32Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeFloat32Set) Pop() float32 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeFloat32Set) Pop() float32 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeFloat32Set) PowerSet() Float32Set {
-	powSet := NewThreadUnsafeFloat32Set()
+func (set *threadUnsafeFloat32Set) PowerSet() []Float32Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeFloat32Set()
-	powSet.Add(&nullset)
+	powSet := []Float32Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeFloat32Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeFloat32Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeFloat32Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeFloat32Set) CartesianProduct(other Float32Set) Float32Set {
+func (set *threadUnsafeFloat32Set) CartesianProduct(other Float32Set) Float32PairSet {
 	o := other.(*threadUnsafeFloat32Set)
-	cartProduct := NewThreadUnsafeFloat32Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeFloat32PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Float32Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeFloat32Set) ToSlice() []float32 {
+	defer set.guardRead().done()
 	keys := make([]float32, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeFloat32Set) ToSlice() []float32 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeFloat32Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Float32JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeFloat32Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeFloat32Set) UnmarshalJSON(data []byte) error {
 	var i []float32
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
@@ -0,0 +1,154 @@
+// Code generated by generate_set for float32. DO NOT EDIT.
+
+package mapsetfloat32
+
+import (
+	"encoding/xml"
+)
+
+// DefaultFloat32XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultFloat32XMLItemName = "item"
+
+// Float32XML adapts a Float32Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Float32XML struct {
+	Set Float32Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultFloat32XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Float32XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat32XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Float32XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat32XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Float32XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultFloat32XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeFloat32XML(e *xml.Encoder, start xml.StartElement, s Float32Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortFloat32Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatFloat32Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeFloat32XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeFloat32XML(dec *xml.Decoder, itemName string) ([]float32, error) {
+	var elems []float32
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseFloat32Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultFloat32XMLItemName. Use Float32XML for other
+// names.
+func (set *threadUnsafeFloat32Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat32XML(e, start, set, DefaultFloat32XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeFloat32Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat32XML(d, DefaultFloat32XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeFloat32Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultFloat32XMLItemName. Use Float32XML for other
+// names.
+func (set *threadSafeFloat32Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat32XML(e, start, set, DefaultFloat32XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeFloat32Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat32XML(d, DefaultFloat32XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeFloat32Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Float64Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleFloat64Values[i%len(sampleFloat64Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewFloat64Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeFloat64Set())
+}
+
+func benchContains(b *testing.B, s Float64Set) {
+	for _, v := range sampleFloat64Values[:len(sampleFloat64Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleFloat64Values[i%len(sampleFloat64Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewFloat64Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeFloat64Set())
+}
+
+func benchUnion(b *testing.B, x, y Float64Set) {
+	half := len(sampleFloat64Values) / 2
+	for _, v := range sampleFloat64Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleFloat64Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewFloat64Set(), NewFloat64Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeFloat64Set(), NewThreadUnsafeFloat64Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Float64Set) {
+	for _, v := range sampleFloat64Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewFloat64Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeFloat64Set())
+}
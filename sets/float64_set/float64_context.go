@@ -0,0 +1,164 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"context"
+	"time"
+)
+
+// Float64ContextSet is implemented by the thread-safe sets returned by
+// NewFloat64Set and its variants. Its methods give up instead of
+// blocking forever when the set is held for a long time, for example by a
+// slow Each callback or an abandoned Iter consumer.
+//
+// The Context methods wait for the lock until ctx is done and then
+// return ctx.Err(), such as context.DeadlineExceeded. They poll for the
+// lock instead of queueing on it, so an abandoned wait leaves nothing
+// behind, but the wait is not fair. A waiting AddContext or
+// RemoveContext does not hold off new readers, as a blocked Add does: it
+// gets the lock only if a poll finds no reader holding it. Readers that
+// keep the set held between them, such as overlapping Each calls, make
+// it wait until ctx is done. Polls back off to one every few
+// milliseconds, so a wait may also end that long after the lock is
+// released. Use Add and Remove where writers must not starve.
+//
+// The Try methods do not wait at all and report whether the lock was
+// available.
+type Float64ContextSet interface {
+	Float64Set
+
+	// AddContext adds i to the set and reports whether it was added.
+	AddContext(ctx context.Context, i float64) (bool, error)
+
+	// ContainsContext reports whether all of the given items are in the
+	// set.
+	ContainsContext(ctx context.Context, i ...float64) (bool, error)
+
+	// RemoveContext removes i from the set.
+	RemoveContext(ctx context.Context, i float64) error
+
+	// TryAdd adds i if the set is not locked. ok reports whether the
+	// lock was available; added whether i was new.
+	TryAdd(i float64) (added, ok bool)
+
+	// TryContains reports whether all of the given items are in the set
+	// if the set is not locked for writing. ok reports whether the lock
+	// was available.
+	TryContains(i ...float64) (found, ok bool)
+
+	// TryRemove removes i if the set is not locked, and reports whether
+	// the lock was available.
+	TryRemove(i float64) bool
+}
+
+// lockFloat64Context calls tryLock until it succeeds or ctx is done. It
+// polls rather than waiting in Lock or RLock, which would leave a
+// goroutine blocked on the set once ctx is done and, as a pending writer,
+// hold off new readers. The polling interval doubles up to a few
+// milliseconds, so a long wait costs little. Writers are not queued
+// ahead of readers; see Float64ContextSet for what that costs.
+func lockFloat64Context(ctx context.Context, tryLock func() bool) error {
+	const (
+		minPoll = 50 * time.Microsecond
+		maxPoll = 5 * time.Millisecond
+	)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tryLock() {
+		return nil
+	}
+
+	wait := minPoll
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if tryLock() {
+			return nil
+		}
+		if wait *= 2; wait > maxPoll {
+			wait = maxPoll
+		}
+		timer.Reset(wait)
+	}
+}
+
+func (set *threadSafeFloat64Set) AddContext(ctx context.Context, i float64) (bool, error) {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockFloat64Context(ctx, set.TryLock)
+	st.waited(start)
+	if err != nil {
+		return false, err
+	}
+	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
+	set.Unlock()
+	return ret, nil
+}
+
+func (set *threadSafeFloat64Set) ContainsContext(ctx context.Context, i ...float64) (bool, error) {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockFloat64Context(ctx, set.TryRLock)
+	st.waited(start)
+	if err != nil {
+		return false, err
+	}
+	ret := set.s.Contains(i...)
+	set.RUnlock()
+	st.looked(ret)
+	return ret, nil
+}
+
+func (set *threadSafeFloat64Set) RemoveContext(ctx context.Context, i float64) error {
+	st := set.stats.Load()
+	start := st.now()
+	err := lockFloat64Context(ctx, set.TryLock)
+	st.waited(start)
+	if err != nil {
+		return err
+	}
+	n := len(set.s)
+	delete(set.s, keyFloat64(i))
+	st.removed(len(set.s) < n)
+	set.Unlock()
+	return nil
+}
+
+func (set *threadSafeFloat64Set) TryAdd(i float64) (added, ok bool) {
+	if !set.TryLock() {
+		return false, false
+	}
+	added = set.s.Add(i)
+	set.stats.Load().added(added, len(set.s))
+	set.Unlock()
+	return added, true
+}
+
+func (set *threadSafeFloat64Set) TryContains(i ...float64) (found, ok bool) {
+	if !set.TryRLock() {
+		return false, false
+	}
+	found = set.s.Contains(i...)
+	set.RUnlock()
+	set.stats.Load().looked(found)
+	return found, true
+}
+
+func (set *threadSafeFloat64Set) TryRemove(i float64) bool {
+	if !set.TryLock() {
+		return false
+	}
+	n := len(set.s)
+	delete(set.s, keyFloat64(i))
+	set.stats.Load().removed(len(set.s) < n)
+	set.Unlock()
+	return true
+}
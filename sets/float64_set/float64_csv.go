@@ -0,0 +1,133 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Float64CSVOptions selects the column that holds set elements in
+// a CSV file.
+type Float64CSVOptions struct {
+	// Column is the zero-based index of the column to read. It is
+	// ignored when ColumnName is set.
+	Column int
+
+	// ColumnName selects the column by its name in the header row. When
+	// set, the first row is treated as a header, and WriteCSV writes it.
+	ColumnName string
+
+	// Header skips the first row when reading by Column.
+	Header bool
+
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+}
+
+// ReadCSV reads every row of reader and adds the element in the selected
+// column to set. Nothing is added if a row lacks the column or an element
+// fails to parse.
+func ReadCSV(reader io.Reader, set Float64Set, opts Float64CSVOptions) error {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+
+	column := opts.Column
+	if opts.ColumnName != "" || opts.Header {
+		header, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if opts.ColumnName != "" {
+			if column, err = csvColumnIndex(header, opts.ColumnName); err != nil {
+				return err
+			}
+		}
+	}
+
+	var elems []float64
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if column < 0 || column >= len(record) {
+			line, _ := cr.FieldPos(0)
+			return fmt.Errorf("mapsetfloat64: CSV line %d has no column %d", line, column)
+		}
+		elem, err := parseFloat64Text(record[column])
+		if err != nil {
+			line, _ := cr.FieldPos(column)
+			return fmt.Errorf("mapsetfloat64: CSV line %d: %w", line, err)
+		}
+		elems = append(elems, elem)
+	}
+
+	for _, elem := range elems {
+		set.Add(elem)
+	}
+	return nil
+}
+
+// WriteCSV writes the elements of s to w in sorted order, one per row
+// in a single column, preceded by a header row if opts.ColumnName is
+// set.
+func WriteCSV(w io.Writer, s Float64Set, opts Float64CSVOptions) error {
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+
+	if opts.ColumnName != "" {
+		if err := cw.Write([]string{opts.ColumnName}); err != nil {
+			return err
+		}
+	}
+
+	elems := s.ToSlice()
+	sortFloat64Elements(elems)
+	for _, elem := range elems {
+		text, err := formatFloat64Text(elem)
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			// csv.Writer writes a lone empty field as an empty line,
+			// which csv.Reader skips, so quote it by hand.
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, `""`+"\n"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := cw.Write([]string{text}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvColumnIndex(header []string, name string) (int, error) {
+	for i, field := range header {
+		if field == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("mapsetfloat64: CSV header has no column %q", name)
+}
@@ -0,0 +1,141 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	optionSets := []Float64CSVOptions{
+		{},
+		{ColumnName: "value"},
+		{ColumnName: "value", Comma: ';'},
+	}
+	for name, newSet := range float64SetFactories() {
+		for _, opts := range optionSets {
+			s := newSet()
+			for _, v := range sampleFloat64Values {
+				s.Add(v)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, s, opts); err != nil {
+				t.Fatalf("%s %+v: %v", name, opts, err)
+			}
+			text := buf.String()
+			if opts.ColumnName != "" && !strings.HasPrefix(text, opts.ColumnName+"\n") {
+				t.Errorf("%s %+v: expected a header row, got %q", name, opts, text)
+			}
+
+			decoded := newSet()
+			if err := ReadCSV(&buf, decoded, opts); err != nil {
+				t.Errorf("%s %+v: read %q: %v", name, opts, text, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s %+v: expected %v after a round trip of %q, got %v", name, opts, s, text, decoded)
+			}
+		}
+	}
+}
+
+// float64CSVTable renders the sample values in the middle
+// column of a three-column table, quoting every field, under a header
+// row.
+func float64CSVTable(t *testing.T) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"id", "value", "note"})
+	for i, v := range sampleFloat64Values {
+		text, err := formatFloat64Text(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]string{strings.Repeat("x", i), text, "a \"quoted\", note"})
+	}
+	w.Flush()
+
+	// Quote every field, as spreadsheets often do, including those
+	// csv.Writer leaves bare.
+	var quoted strings.Builder
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		for i, field := range record {
+			if i > 0 {
+				quoted.WriteByte(',')
+			}
+			quoted.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`)
+		}
+		quoted.WriteByte('\n')
+	}
+	return quoted.String()
+}
+
+func TestReadCSVColumns(t *testing.T) {
+	table := float64CSVTable(t)
+	want := NewFloat64Set(sampleFloat64Values...)
+
+	for _, opts := range []Float64CSVOptions{
+		{ColumnName: "value"},
+		{Column: 1, Header: true},
+	} {
+		s := NewFloat64Set()
+		if err := ReadCSV(strings.NewReader(table), s, opts); err != nil {
+			t.Errorf("%+v: %v", opts, err)
+			continue
+		}
+		if !s.Equal(want) {
+			t.Errorf("%+v: expected %v from %q, got %v", opts, want, table, s)
+		}
+	}
+
+	// Without Header, the header row is read as an element.
+	err := ReadCSV(strings.NewReader(table), NewFloat64Set(), Float64CSVOptions{Column: 1})
+	if err == nil {
+		t.Error("expected an error parsing the header row as an element")
+	}
+
+	// An empty input holds no elements, with or without a header.
+	for _, opts := range []Float64CSVOptions{{}, {ColumnName: "value"}} {
+		s := NewFloat64Set()
+		if err := ReadCSV(strings.NewReader(""), s, opts); err != nil || s.Cardinality() != 0 {
+			t.Errorf("%+v: expected no elements from empty input, got %v, %v", opts, s, err)
+		}
+	}
+}
+
+func TestReadCSVMalformed(t *testing.T) {
+	valid, err := formatFloat64Text(sampleFloat64Values[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []struct {
+		name  string
+		input string
+		opts  Float64CSVOptions
+	}{
+		{"missing column", "value\n" + valid + "\n", Float64CSVOptions{Column: 1, Header: true}},
+		{"unknown header", "value\n" + valid + "\n", Float64CSVOptions{ColumnName: "other"}},
+		{"negative column", valid + "\n", Float64CSVOptions{Column: -1}},
+		{"bare quote", valid + "\n\"unterminated\n", Float64CSVOptions{}},
+		{"quote in field", valid + "\na\"b\n", Float64CSVOptions{}},
+		{"unparsable element", valid + "\nnot a value\n", Float64CSVOptions{}},
+	}
+
+	for _, input := range inputs {
+		s := NewFloat64Set()
+		if err := ReadCSV(strings.NewReader(input.input), s, input.opts); err == nil {
+			t.Errorf("%s: expected an error reading %q", input.name, input.input)
+		}
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected nothing to be added on error, got %v", input.name, s)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"testing"
+)
+
+// float64HybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const float64HybridPrecision = 14
+
+// assertFloat64HybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertFloat64HybridCount(t *testing.T, name string, s *Float64HybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleFloat64Values) / 2
+	s := NewFloat64HybridSet(half, float64HybridPrecision)
+	for _, v := range sampleFloat64Values[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertFloat64HybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeFloat64SetFromSlice(sampleFloat64Values[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleFloat64Values[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleFloat64Values[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertFloat64HybridCount(t, "converted", s, half+1)
+	s.Add(sampleFloat64Values[0])
+	assertFloat64HybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleFloat64Values) / 2
+	newHybrid := func(sketched bool, elems []float64) *Float64HybridSet {
+		threshold := len(sampleFloat64Values)
+		if sketched {
+			threshold = 0
+		}
+		s := NewFloat64HybridSet(threshold, float64HybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleFloat64Values[:half+1])
+		other := newHybrid(tc.sketchedO, sampleFloat64Values[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeFloat64SetFromSlice(sampleFloat64Values)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleFloat64Values, s.Exact())
+		}
+		assertFloat64HybridCount(t, tc.name, s, len(sampleFloat64Values))
+		assertFloat64HybridCount(t, tc.name+" leaves other", other, len(sampleFloat64Values)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewFloat64HybridSet(half, float64HybridPrecision)
+	s.Add(sampleFloat64Values[0])
+	if err := s.Merge(newHybrid(false, sampleFloat64Values)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertFloat64HybridCount(t, "merged past the threshold", s, len(sampleFloat64Values))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleFloat64Values) / 2
+	for _, threshold := range []int{len(sampleFloat64Values), 0} {
+		s := NewFloat64HybridSet(threshold, float64HybridPrecision)
+		for _, v := range sampleFloat64Values[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleFloat64Values), 0} {
+			other := NewFloat64HybridSet(otherThreshold, float64HybridPrecision-2)
+			other.Add(sampleFloat64Values[len(sampleFloat64Values)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, float64HybridPrecision, float64HybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeFloat64SetFromSlice(sampleFloat64Values[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
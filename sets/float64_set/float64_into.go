@@ -0,0 +1,195 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeFloat64Set()
+		return &s
+	},
+}
+
+// NewScratchFloat64Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchFloat64Set() Float64Set {
+	return scratchPool.Get().(*threadUnsafeFloat64Set)
+}
+
+// Release empties s and makes it available to NewScratchFloat64Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Float64Set) {
+	u, ok := s.(*threadUnsafeFloat64Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Float64Set) {
+	d, x, y, locks := lockFloat64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllFloat64(d, y)
+	case dst == b:
+		addAllFloat64(d, x)
+	default:
+		clear(d)
+		addAllFloat64(d, x)
+		addAllFloat64(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Float64Set) {
+	d, x, y, locks := lockFloat64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyFloat64(d, y)
+	case dst == b:
+		keepOnlyFloat64(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Float64Set) {
+	d, x, y, locks := lockFloat64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchFloat64Set().(*threadUnsafeFloat64Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllFloat64(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllFloat64(dst, src threadUnsafeFloat64Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyFloat64 removes the elements of dst that are not in other.
+func keepOnlyFloat64(dst, other threadUnsafeFloat64Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoFloat64Locks records the locks taken by lockFloat64Into, or the guards for
+// thread-unsafe sets.
+type intoFloat64Locks struct {
+	sets     [3]*threadSafeFloat64Set
+	distinct int
+	dst      *threadSafeFloat64Set
+	guards   [3]unsafeFloat64Guard
+}
+
+// lockFloat64Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockFloat64Into(dst, a, b Float64Set) (d, x, y threadUnsafeFloat64Set, locks intoFloat64Locks) {
+	w, ok := dst.(*threadSafeFloat64Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeFloat64Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeFloat64Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeFloat64Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeFloat64Set), *b.(*threadUnsafeFloat64Set), locks
+	}
+
+	locks.sets = [3]*threadSafeFloat64Set{w, a.(*threadSafeFloat64Set), b.(*threadSafeFloat64Set)}
+	locks.distinct = len(lockFloat64Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeFloat64Set).s, b.(*threadSafeFloat64Set).s, locks
+}
+
+func (l intoFloat64Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleFloat64Values)
+	aValues, bValues := sampleFloat64Values[:n-1], sampleFloat64Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Float64Set)
+		expected []float64
+	}{
+		{"UnionInto", UnionInto, sampleFloat64Values},
+		{"IntersectInto", IntersectInto, sampleFloat64Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleFloat64Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range float64SetFactories() {
+			expected := fillFloat64Set(newSet(), testCase.expected...)
+
+			dst := fillFloat64Set(newSet(), sampleFloat64Values[n-1])
+			testCase.into(dst, fillFloat64Set(newSet(), aValues...), fillFloat64Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillFloat64Set(newSet(), aValues...)
+			testCase.into(a, a, fillFloat64Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillFloat64Set(newSet(), bValues...)
+			testCase.into(b, fillFloat64Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range float64SetFactories() {
+		s := fillFloat64Set(newSet(), sampleFloat64Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchFloat64Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleFloat64Values[0])
+	Release(s)
+	Release(NewFloat64Set(sampleFloat64Values[0]))
+
+	if again := NewScratchFloat64Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetfloat64
+
+// unsafeFloat64Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeFloat64Guard struct{}
+
+func trackFloat64Set(set *threadUnsafeFloat64Set) {}
+
+func (set *threadUnsafeFloat64Set) guardRead() unsafeFloat64Guard { return unsafeFloat64Guard{} }
+
+func (set *threadUnsafeFloat64Set) guardWrite() unsafeFloat64Guard { return unsafeFloat64Guard{} }
+
+func (set *threadUnsafeFloat64Set) guardIterate() unsafeFloat64Guard { return unsafeFloat64Guard{} }
+
+func (unsafeFloat64Guard) done() {}
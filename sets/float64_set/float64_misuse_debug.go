@@ -0,0 +1,156 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+//go:build mapsetdebug
+
+package mapsetfloat64
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Building with the mapsetdebug tag checks every thread-unsafe set made
+// by NewThreadUnsafeFloat64Set and its variants for use from several goroutines
+// at once. Instead of the runtime's bare "concurrent map writes", misuse
+// panics with a message naming where the set was created:
+//
+//	go test -tags mapsetdebug ./...
+//
+// The checks are best effort: they catch operations that overlap in
+// time, which is also when the runtime would fail, and cost an atomic
+// operation and a map lookup per call. A goroutine may modify a set
+// from its own Each callback, as ranging over a map allows; only other
+// goroutines are caught.
+
+// setFloat64Guard counts the operations in progress on one thread-unsafe set.
+type setFloat64Guard struct {
+	readers atomic.Int32
+	writers atomic.Int32
+	stack   []byte
+
+	// iterating counts the Each calls in progress on each goroutine,
+	// which count as readers but may write to the set themselves.
+	mu        sync.Mutex
+	iterating map[int64]int32
+}
+
+// setFloat64Guards maps the address of each tracked set to its guard. Keys are
+// not pointers, so the table does not keep sets alive; a finalizer
+// removes the entry once a set is collected.
+var setFloat64Guards sync.Map
+
+type unsafeFloat64Guard struct {
+	g     *setFloat64Guard
+	write bool
+	goid  int64 // the iterating goroutine, for Each
+}
+
+// trackFloat64Set starts checking set, recording the caller's stack as
+// the place it was created. set must be heap allocated.
+func trackFloat64Set(set *threadUnsafeFloat64Set) {
+	setFloat64Guards.Store(uintptr(unsafe.Pointer(set)), &setFloat64Guard{stack: debug.Stack()})
+	runtime.SetFinalizer(set, func(set *threadUnsafeFloat64Set) {
+		setFloat64Guards.Delete(uintptr(unsafe.Pointer(set)))
+	})
+}
+
+func (set *threadUnsafeFloat64Set) guard() *setFloat64Guard {
+	g, ok := setFloat64Guards.Load(uintptr(unsafe.Pointer(set)))
+	if !ok {
+		return nil
+	}
+	return g.(*setFloat64Guard)
+}
+
+func (set *threadUnsafeFloat64Set) guardRead() unsafeFloat64Guard {
+	g := set.guard()
+	if g == nil {
+		return unsafeFloat64Guard{}
+	}
+	g.readers.Add(1)
+	if g.writers.Load() != 0 {
+		g.readers.Add(-1)
+		g.misuse("read during a concurrent write")
+	}
+	return unsafeFloat64Guard{g: g}
+}
+
+func (set *threadUnsafeFloat64Set) guardWrite() unsafeFloat64Guard {
+	g := set.guard()
+	if g == nil {
+		return unsafeFloat64Guard{}
+	}
+	if !g.writers.CompareAndSwap(0, 1) {
+		g.misuse("concurrent writes")
+	}
+	if readers := g.readers.Load(); readers != 0 && readers > g.iteratingOn(goroutineFloat64ID()) {
+		g.writers.Add(-1)
+		g.misuse("write during a concurrent read")
+	}
+	return unsafeFloat64Guard{g: g, write: true}
+}
+
+// guardIterate is guardRead for Each, whose callback may write to the
+// set from the same goroutine.
+func (set *threadUnsafeFloat64Set) guardIterate() unsafeFloat64Guard {
+	u := set.guardRead()
+	if u.g == nil {
+		return u
+	}
+	u.goid = goroutineFloat64ID()
+	u.g.mu.Lock()
+	if u.g.iterating == nil {
+		u.g.iterating = map[int64]int32{}
+	}
+	u.g.iterating[u.goid]++
+	u.g.mu.Unlock()
+	return u
+}
+
+// iteratingOn returns the number of Each calls in progress on goroutine
+// goid.
+func (g *setFloat64Guard) iteratingOn(goid int64) int32 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.iterating[goid]
+}
+
+func (u unsafeFloat64Guard) done() {
+	switch {
+	case u.g == nil:
+	case u.write:
+		u.g.writers.Add(-1)
+	default:
+		if u.goid != 0 {
+			u.g.mu.Lock()
+			if u.g.iterating[u.goid]--; u.g.iterating[u.goid] == 0 {
+				delete(u.g.iterating, u.goid)
+			}
+			u.g.mu.Unlock()
+		}
+		u.g.readers.Add(-1)
+	}
+}
+
+// goroutineFloat64ID returns the ID of the calling goroutine, parsed from its
+// stack trace. It is slow, but only needed to tell Each callbacks apart
+// from other goroutines.
+func goroutineFloat64ID() int64 {
+	var buf [64]byte
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
+
+func (g *setFloat64Guard) misuse(what string) {
+	panic(fmt.Sprintf("mapsetfloat64: %s on a thread-unsafe set; use NewFloat64Set for sets shared between goroutines. The set was created at:\n\n%s", what, g.stack))
+}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Float64Set) Float64Set {
+	if len(sets) == 0 {
+		return NewFloat64Set()
+	}
+	maps, unlock := readLockFloat64Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeFloat64Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapFloat64Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Float64Set) Float64Set {
+	if len(sets) == 0 {
+		return NewFloat64Set()
+	}
+	maps, unlock := readLockFloat64Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeFloat64Set()
+	for elem := range maps[smallest] {
+		if containedInAllFloat64(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapFloat64Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Float64Set, sets ...Float64Set) Float64Set {
+	maps, unlock := readLockFloat64Sets(append([]Float64Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeFloat64Set()
+	for elem := range maps[0] {
+		if !containedInAnyFloat64(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapFloat64Like(base, difference)
+}
+
+func containedInAllFloat64(maps []threadUnsafeFloat64Set, skip int, elem float64) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyFloat64(maps []threadUnsafeFloat64Set, elem float64) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapFloat64Like returns s as a Set of the same implementation as like.
+func wrapFloat64Like(like Float64Set, s threadUnsafeFloat64Set) Float64Set {
+	if _, ok := like.(*threadSafeFloat64Set); ok {
+		return &threadSafeFloat64Set{s: s}
+	}
+	trackFloat64Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Float64Pair represents a 2-tuple of values.
+type Float64Pair struct {
+	First  float64
+	Second float64
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Float64Pair) Equal(other Float64Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Float64Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Float64PairSet is an unordered set of Float64Pairs, as
+// returned by CartesianProduct.
+type Float64PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Float64Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Float64Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Float64Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Float64PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Float64Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Float64Pair
+}
+
+type threadUnsafeFloat64PairSet map[Float64Pair]struct{}
+
+func newThreadUnsafeFloat64PairSet() threadUnsafeFloat64PairSet {
+	return make(threadUnsafeFloat64PairSet)
+}
+
+func (set *threadUnsafeFloat64PairSet) Add(p Float64Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeFloat64PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeFloat64PairSet) Contains(p ...Float64Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeFloat64PairSet) Each(cb func(Float64Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeFloat64PairSet) Equal(other Float64PairSet) bool {
+	_ = other.(*threadUnsafeFloat64PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeFloat64PairSet) Remove(p Float64Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeFloat64PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeFloat64PairSet) ToSlice() []Float64Pair {
+	keys := make([]Float64Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeFloat64PairSet struct {
+	s threadUnsafeFloat64PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeFloat64PairSet) Add(p Float64Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeFloat64PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeFloat64PairSet) Contains(p ...Float64Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeFloat64PairSet) Each(cb func(Float64Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockFloat64PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockFloat64Pair does for sets.
+func rlockFloat64PairSets(x, y *threadSafeFloat64PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockFloat64PairSets releases the locks taken by rlockFloat64PairSets.
+func runlockFloat64PairSets(x, y *threadSafeFloat64PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeFloat64PairSet) Equal(other Float64PairSet) bool {
+	o := other.(*threadSafeFloat64PairSet)
+
+	rlockFloat64PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockFloat64PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeFloat64PairSet) Remove(p Float64Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeFloat64PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeFloat64PairSet) ToSlice() []Float64Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
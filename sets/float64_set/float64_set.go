@@ -1,3 +1,5 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
 package mapsetfloat64
 
 // Float64Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Float64Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Float64Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Float64Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() float64
 
-	// Returns all subsets of a given set (Power Float64Set).
-	// Not yet supported
-	// PowerSet() Float64Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Float64Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Float64Set) Float64Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Float64Set) Float64PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []float64
@@ -145,7 +154,7 @@ type Float64Set interface {
 // NewFloat64Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewFloat64Set(s ...float64) Float64Set {
-	set := newThreadSafeFloat64Set()
+	set := threadSafeFloat64Set{s: newThreadUnsafeFloat64SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewFloat64SetFromSlice(s []float64) Float64Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeFloat64Set() Float64Set {
 	set := newThreadUnsafeFloat64Set()
+	trackFloat64Set(&set)
+	return &set
+}
+
+// NewFloat64SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewFloat64SetWithCapacity(n int) Float64Set {
+	return &threadSafeFloat64Set{s: newThreadUnsafeFloat64SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeFloat64SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeFloat64SetWithCapacity(n int) Float64Set {
+	set := newThreadUnsafeFloat64SetWithCapacity(n)
+	trackFloat64Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeFloat64Set() Float64Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeFloat64SetFromSlice(s []float64) Float64Set {
-	a := NewThreadUnsafeFloat64Set()
+	a := NewThreadUnsafeFloat64SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
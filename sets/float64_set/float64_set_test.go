@@ -0,0 +1,105 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleFloat64Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleFloat64Values = []float64{
+	-2.5,
+	-1,
+	0,
+	0.25,
+	1,
+	3.75,
+	1e6,
+	-0.001,
+}
+
+// float64SetFactories returns constructors for both implementations.
+func float64SetFactories() map[string]func() Float64Set {
+	return map[string]func() Float64Set{
+		"safe":   func() Float64Set { return NewFloat64Set() },
+		"unsafe": NewThreadUnsafeFloat64Set,
+	}
+}
+
+// fillFloat64Set adds values to s and returns it.
+func fillFloat64Set(s Float64Set, values ...float64) Float64Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertFloat64RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertFloat64RoundTrip(t *testing.T, marshal func(Float64Set) ([]byte, error), unmarshal func([]byte, Float64Set) error) {
+	t.Helper()
+	contents := [][]float64{sampleFloat64Values, sampleFloat64Values[:1]}
+	for name, newSet := range float64SetFactories() {
+		for _, values := range contents {
+			s := fillFloat64Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range float64SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[float64, Float64Set]{
+				New:   newSet,
+				Elems: sampleFloat64Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range float64SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleFloat64Values[0])
+		a.Add(sampleFloat64Values[1])
+		for _, v := range sampleFloat64Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleFloat64Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Float64Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
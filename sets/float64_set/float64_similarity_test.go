@@ -0,0 +1,47 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	n := len(sampleFloat64Values)
+	for name, newSet := range float64SetFactories() {
+		all := fillFloat64Set(newSet(), sampleFloat64Values...)
+		first := fillFloat64Set(newSet(), sampleFloat64Values[0])
+
+		var testCases = []struct {
+			a, b                           Float64Set
+			jaccard, dice, overlap, cosine float64
+			symmetricDifference            int
+		}{
+			{all, first, 1 / float64(n), 2 / float64(n+1), 1, 1 / math.Sqrt(float64(n)), n - 1},
+			{first, all, 1 / float64(n), 2 / float64(n+1), 1, 1 / math.Sqrt(float64(n)), n - 1},
+			{all, all, 1, 1, 1, 1, 0},
+			{newSet(), newSet(), 1, 1, 1, 1, 0},
+			{all, newSet(), 0, 0, 0, 0, n},
+		}
+
+		near := func(x, y float64) bool { return math.Abs(x-y) < 1e-12 }
+		for i, testCase := range testCases {
+			if got := Jaccard(testCase.a, testCase.b); !near(got, testCase.jaccard) {
+				t.Errorf("%s test %d: expected Jaccard %v, got %v", name, i, testCase.jaccard, got)
+			}
+			if got := SorensenDice(testCase.a, testCase.b); !near(got, testCase.dice) {
+				t.Errorf("%s test %d: expected SorensenDice %v, got %v", name, i, testCase.dice, got)
+			}
+			if got := OverlapCoefficient(testCase.a, testCase.b); !near(got, testCase.overlap) {
+				t.Errorf("%s test %d: expected OverlapCoefficient %v, got %v", name, i, testCase.overlap, got)
+			}
+			if got := Cosine(testCase.a, testCase.b); !near(got, testCase.cosine) {
+				t.Errorf("%s test %d: expected Cosine %v, got %v", name, i, testCase.cosine, got)
+			}
+			if got := SymmetricDifferenceSize(testCase.a, testCase.b); got != testCase.symmetricDifference {
+				t.Errorf("%s test %d: expected SymmetricDifferenceSize %v, got %v", name, i, testCase.symmetricDifference, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewFloat64Set(sampleFloat64Values...)
+	queries := s.ToSlice()
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewFloat64Set(sampleFloat64Values...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleFloat64Values) {
+		t.Errorf("expected %d elements, got %d", len(sampleFloat64Values), f.Len())
+	}
+	for _, v := range sampleFloat64Values {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+
+	// NaNs are distinct keys with equal hashes, which must be added once
+	// rather than grow the filter forever.
+	nans := NewThreadUnsafeFloat64Set()
+	for i := 0; i < 100; i++ {
+		nans.Add(float64(math.NaN()))
+	}
+	if f, err := ToCuckooFilter(nans, 0.001); err != nil || f.Len() != 1 {
+		t.Errorf("expected the NaNs to be added once, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"cmp"
+	"sort"
+)
+
+// lessFloat64 orders elements for output that must be stable,
+// such as sorted encodings. NaN orders before every other number.
+func lessFloat64(a, b float64) bool {
+	return cmp.Less(a, b)
+}
+
+// sortFloat64Elements sorts elems in place using lessFloat64.
+func sortFloat64Elements(elems []float64) {
+	sort.Slice(elems, func(i, j int) bool { return lessFloat64(elems[i], elems[j]) })
+}
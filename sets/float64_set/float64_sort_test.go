@@ -0,0 +1,41 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]float64, 0, len(sampleFloat64Values))
+	for i := len(sampleFloat64Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleFloat64Values[i])
+	}
+	sortFloat64Elements(elems)
+	for i, elem := range elems {
+		if lessFloat64(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessFloat64(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewFloat64Set(elems...).Equal(NewFloat64Set(sampleFloat64Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleFloat64Values, elems)
+	}
+}
+
+func TestSortNaN(t *testing.T) {
+	nan := float64(math.NaN())
+	elems := []float64{1, nan, -1, nan, 0}
+	sortFloat64Elements(elems)
+	if !math.IsNaN(float64(elems[0])) || !math.IsNaN(float64(elems[1])) {
+		t.Fatalf("expected the NaNs first, got %v", elems)
+	}
+	for i, want := range []float64{-1, 0, 1} {
+		if elems[i+2] != want {
+			t.Errorf("expected %v at %d, got %v", want, i+2, elems)
+		}
+	}
+}
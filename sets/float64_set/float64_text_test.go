@@ -0,0 +1,14 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Float64Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Float64Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertFloat64RoundTrip(t, marshal, unmarshal)
+}
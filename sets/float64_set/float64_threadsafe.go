@@ -1,11 +1,16 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
 package mapsetfloat64
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeFloat64Set struct {
-	s threadUnsafeFloat64Set
+	s     threadUnsafeFloat64Set
+	stats atomic.Pointer[Float64SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeFloat64Set() threadSafeFloat64Set {
 	return threadSafeFloat64Set{s: newThreadUnsafeFloat64Set()}
 }
 
+// readLockFloat64Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockFloat64Sets(sets []Float64Set) ([]threadUnsafeFloat64Set, func()) {
+	maps := make([]threadUnsafeFloat64Set, len(sets))
+	if _, ok := sets[0].(*threadSafeFloat64Set); !ok {
+		guards := make([]unsafeFloat64Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeFloat64Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeFloat64Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeFloat64Set))
+	}
+	distinct := lockFloat64Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeFloat64Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockFloat64Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockFloat64Order(sets []*threadSafeFloat64Set) []*threadSafeFloat64Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockFloat64Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockFloat64Pair(x, y *threadSafeFloat64Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockFloat64Pair releases the locks taken by rlockFloat64Pair.
+func runlockFloat64Pair(x, y *threadSafeFloat64Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeFloat64Set) Add(i float64) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeFloat64Set) Contains(i ...float64) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) IsSubset(other Float64Set) bool {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) IsProperSubset(other Float64Set) bool {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockFloat64Pair(set, o)
+	defer runlockFloat64Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeFloat64Set) IsProperSuperset(other Float64Set) bool {
 func (set *threadSafeFloat64Set) Union(other Float64Set) Float64Set {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
+	readFloat64Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeFloat64Set)
 	ret := &threadSafeFloat64Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) Intersect(other Float64Set) Float64Set {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
+	readFloat64Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeFloat64Set)
 	ret := &threadSafeFloat64Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) Difference(other Float64Set) Float64Set {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
+	readFloat64Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeFloat64Set)
 	ret := &threadSafeFloat64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) SymmetricDifference(other Float64Set) Float64Set {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
+	readFloat64Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeFloat64Set)
 	ret := &threadSafeFloat64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeFloat64Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeFloat64Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeFloat64Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeFloat64Set) Remove(i float64) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyFloat64(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeFloat64Set) Cardinality() int {
 }
 
 func (set *threadSafeFloat64Set) Each(cb func(float64) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeFloat64Set) Iterator() *Float64Iterator {
 func (set *threadSafeFloat64Set) Equal(other Float64Set) bool {
 	o := other.(*threadSafeFloat64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockFloat64Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockFloat64Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeFloat64Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeFloat64Set) PowerSet() Float64Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeFloat64Set)
-    set.RUnlock()
+func (set *threadSafeFloat64Set) PowerSet() []Float64Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeFloat64Set{s: newThreadUnsafeFloat64Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeFloat64Set)
-        ret.Add(&threadSafeFloat64Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Float64Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeFloat64Set)
+		ret = append(ret, &threadSafeFloat64Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeFloat64Set) Pop() float64 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeFloat64Set) CartesianProduct(other Float64Set) Float64Set {
-    o := other.(*threadSafeFloat64Set)
+func (set *threadSafeFloat64Set) CartesianProduct(other Float64Set) Float64PairSet {
+	o := other.(*threadSafeFloat64Set)
 
-    set.RLock()
-    o.RLock()
+	rlockFloat64Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeFloat64Set)
-    ret := &threadSafeFloat64Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeFloat64PairSet)
+	ret := &threadSafeFloat64PairSet{s: *unsafeCartProduct}
+	runlockFloat64Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeFloat64Set) ToSlice() []float64 {
-	keys := make([]float64, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]float64, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeFloat64Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeFloat64Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
 package mapsetfloat64
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeFloat64Set map[float64]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  float64
-	Second float64
-}
-
 func newThreadUnsafeFloat64Set() threadUnsafeFloat64Set {
 	return make(threadUnsafeFloat64Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeFloat64SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeFloat64SetWithCapacity(n int) threadUnsafeFloat64Set {
+	return make(threadUnsafeFloat64Set, n)
+}
 
-	return false
+// keyFloat64 returns the map key of i.
+func keyFloat64(i float64) float64 {
+	return i
 }
 
 func (set *threadUnsafeFloat64Set) Add(i float64) bool {
+	defer set.guardWrite().done()
+	i = keyFloat64(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeFloat64Set) Add(i float64) bool {
 }
 
 func (set *threadUnsafeFloat64Set) Contains(i ...float64) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyFloat64(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeFloat64Set) Contains(i ...float64) bool {
 
 func (set *threadUnsafeFloat64Set) IsSubset(other Float64Set) bool {
 	_ = other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeFloat64Set) IsSubset(other Float64Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeFloat64Set) IsProperSubset(other Float64Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeFloat64Set) IsSuperset(other Float64Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeFloat64Set) IsSuperset(other Float64Set) bool {
 }
 
 func (set *threadUnsafeFloat64Set) IsProperSuperset(other Float64Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeFloat64Set) Union(other Float64Set) Float64Set {
 	o := other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeFloat64Set()
+	unionedSet := newThreadUnsafeFloat64SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeFloat64Set) Union(other Float64Set) Float64Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackFloat64Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeFloat64Set) Intersect(other Float64Set) Float64Set {
 	o := other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeFloat64Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeFloat64Set) Intersect(other Float64Set) Float64Set {
 			}
 		}
 	}
+	trackFloat64Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeFloat64Set) Difference(other Float64Set) Float64Set {
 	_ = other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeFloat64Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeFloat64Set) Difference(other Float64Set) Float64Set {
 			difference.Add(elem)
 		}
 	}
+	trackFloat64Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeFloat64Set) SymmetricDifference(other Float64Set) Float64Set {
-	_ = other.(*threadUnsafeFloat64Set)
+	o := other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeFloat64Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackFloat64Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeFloat64Set) Clear() {
-	*set = newThreadUnsafeFloat64Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeFloat64Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeFloat64SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeFloat64Set) Remove(i float64) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyFloat64(i))
 }
 
 func (set *threadUnsafeFloat64Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeFloat64Set) Each(cb func(float64) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeFloat64Set) Each(cb func(float64) bool) {
 func (set *threadUnsafeFloat64Set) Iter() <-chan float64 {
 	ch := make(chan float64)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeFloat64Set) Iterator() *Float64Iterator {
 	iterator, ch, stopCh := newFloat64Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeFloat64Set) Iterator() *Float64Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeFloat64Set) Iterator() *Float64Iterator {
 
 func (set *threadUnsafeFloat64Set) Equal(other Float64Set) bool {
 	_ = other.(*threadUnsafeFloat64Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeFloat64Set) Equal(other Float64Set) bool {
 }
 
 func (set *threadUnsafeFloat64Set) Clone() Float64Set {
-	clonedSet := newThreadUnsafeFloat64Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeFloat64SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackFloat64Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeFloat64Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeFloat64Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeFloat64Set) Pop() float64 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeFloat64Set) Pop() float64 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeFloat64Set) PowerSet() Float64Set {
-	powSet := NewThreadUnsafeFloat64Set()
+func (set *threadUnsafeFloat64Set) PowerSet() []Float64Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeFloat64Set()
-	powSet.Add(&nullset)
+	powSet := []Float64Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeFloat64Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeFloat64Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeFloat64Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeFloat64Set) CartesianProduct(other Float64Set) Float64Set {
+func (set *threadUnsafeFloat64Set) CartesianProduct(other Float64Set) Float64PairSet {
 	o := other.(*threadUnsafeFloat64Set)
-	cartProduct := NewThreadUnsafeFloat64Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeFloat64PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Float64Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeFloat64Set) ToSlice() []float64 {
+	defer set.guardRead().done()
 	keys := make([]float64, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeFloat64Set) ToSlice() []float64 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeFloat64Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Float64JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeFloat64Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeFloat64Set) UnmarshalJSON(data []byte) error {
 	var i []float64
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
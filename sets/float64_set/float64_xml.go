@@ -0,0 +1,154 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"encoding/xml"
+)
+
+// DefaultFloat64XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultFloat64XMLItemName = "item"
+
+// Float64XML adapts a Float64Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Float64XML struct {
+	Set Float64Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultFloat64XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Float64XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat64XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Float64XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat64XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Float64XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultFloat64XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeFloat64XML(e *xml.Encoder, start xml.StartElement, s Float64Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortFloat64Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatFloat64Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeFloat64XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeFloat64XML(dec *xml.Decoder, itemName string) ([]float64, error) {
+	var elems []float64
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseFloat64Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultFloat64XMLItemName. Use Float64XML for other
+// names.
+func (set *threadUnsafeFloat64Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat64XML(e, start, set, DefaultFloat64XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeFloat64Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat64XML(d, DefaultFloat64XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeFloat64Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultFloat64XMLItemName. Use Float64XML for other
+// names.
+func (set *threadSafeFloat64Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeFloat64XML(e, start, set, DefaultFloat64XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeFloat64Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeFloat64XML(d, DefaultFloat64XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeFloat64Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,92 @@
+// Code generated by generate_set for float64. DO NOT EDIT.
+
+package mapsetfloat64
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestXMLRoundTrip(t *testing.T) {
+	marshal := func(s Float64Set) ([]byte, error) { return xml.Marshal(s) }
+	unmarshal := func(b []byte, s Float64Set) error { return xml.Unmarshal(b, s) }
+	assertFloat64RoundTrip(t, marshal, unmarshal)
+
+	for name, newSet := range float64SetFactories() {
+		s := newSet()
+		for _, v := range sampleFloat64Values {
+			s.Add(v)
+		}
+
+		b, err := xml.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := strings.Count(string(b), "<"+DefaultFloat64XMLItemName+">"); got != len(sampleFloat64Values) {
+			t.Errorf("%s: expected %d item elements in %s, got %d", name, len(sampleFloat64Values), b, got)
+		}
+
+		decoded := newSet()
+		decoded.Add(sampleFloat64Values[0])
+		if err := xml.Unmarshal(b, decoded); err != nil {
+			t.Errorf("%s: unmarshal %s: %v", name, b, err)
+			continue
+		}
+		if !decoded.Equal(s) {
+			t.Errorf("%s: expected %v after a round trip of %s, got %v", name, s, b, decoded)
+		}
+	}
+}
+
+func TestXMLItemName(t *testing.T) {
+	type config struct {
+		XMLName xml.Name   `xml:"config"`
+		Allow   Float64XML `xml:"allow"`
+	}
+
+	in := config{Allow: Float64XML{Set: NewFloat64Set(sampleFloat64Values...), ItemName: "host"}}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "<allow><host>") {
+		t.Errorf("expected items named host in %s", b)
+	}
+
+	// Children with other names are skipped, and elements are added to
+	// the set already present.
+	text := strings.Replace(string(b), "<allow>", "<allow><comment>skipped</comment>", 1)
+	out := config{Allow: Float64XML{Set: NewFloat64Set(), ItemName: "host"}}
+	if err := xml.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Allow.Set.Equal(in.Allow.Set) {
+		t.Errorf("expected %v from %s, got %v", in.Allow.Set, text, out.Allow.Set)
+	}
+}
+
+func TestUnmarshalXMLMalformed(t *testing.T) {
+	valid, err := formatFloat64Text(sampleFloat64Values[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []string{
+		"<set><item>" + valid + "</item>",
+		"<set><item>" + valid + "</set>",
+		"<set><item>" + valid + "</item><item>not a value</item></set>",
+	}
+
+	for name, newSet := range float64SetFactories() {
+		for _, input := range inputs {
+			s := newSet()
+			s.Add(sampleFloat64Values[1])
+			if err := xml.Unmarshal([]byte(input), s); err == nil {
+				t.Errorf("%s: expected an error unmarshaling %q", name, input)
+			}
+			if s.Cardinality() != 1 || !s.Contains(sampleFloat64Values[1]) {
+				t.Errorf("%s: expected the set to be left untouched on error, got %v", name, s)
+			}
+		}
+	}
+}
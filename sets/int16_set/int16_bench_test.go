@@ -0,0 +1,86 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Int16Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleInt16Values[i%len(sampleInt16Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewInt16Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeInt16Set())
+}
+
+func benchContains(b *testing.B, s Int16Set) {
+	for _, v := range sampleInt16Values[:len(sampleInt16Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleInt16Values[i%len(sampleInt16Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewInt16Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeInt16Set())
+}
+
+func benchUnion(b *testing.B, x, y Int16Set) {
+	half := len(sampleInt16Values) / 2
+	for _, v := range sampleInt16Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleInt16Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewInt16Set(), NewInt16Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeInt16Set(), NewThreadUnsafeInt16Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Int16Set) {
+	for _, v := range sampleInt16Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewInt16Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeInt16Set())
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s Int16Set) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s Int16Set) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertInt16RoundTrip(t, marshal, unmarshal)
+}
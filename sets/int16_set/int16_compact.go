@@ -0,0 +1,176 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// compactFormatVersion is the first byte of every compact encoding,
+// versioned as described at binaryFormatVersion.
+const compactFormatVersion byte = 1
+
+// compactChunkSize is how many encoded bytes WriteCompact buffers
+// between writes.
+const compactChunkSize = 4096
+
+var errCompactUnsorted = errors.New("mapsetint16: compact encoding is not strictly increasing")
+
+// AppendCompact appends a compact encoding of s to b and returns the
+// extended buffer. After a version byte and the element count, the
+// elements are written in ascending order: the smallest as a
+// zig-zag varint, then each following one as the uvarint delta from
+// its predecessor.
+func AppendCompact(b []byte, s Int16Set) []byte {
+	keys := sortedCompactElements(s)
+	b = appendCompactHeader(b, len(keys))
+	for i := range keys {
+		b = appendCompactElement(b, keys, i)
+	}
+	return b
+}
+
+// WriteCompact writes the encoding produced by AppendCompact to w.
+func WriteCompact(w io.Writer, s Int16Set) error {
+	keys := sortedCompactElements(s)
+	buf := appendCompactHeader(make([]byte, 0, compactChunkSize+binary.MaxVarintLen64), len(keys))
+	for i := range keys {
+		buf = appendCompactElement(buf, keys, i)
+		if len(buf) >= compactChunkSize {
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// DecodeCompact adds the elements of an encoding produced by
+// AppendCompact to s. Nothing is added if b is not a valid encoding.
+func DecodeCompact(b []byte, s Int16Set) error {
+	r := bytes.NewReader(b)
+	elems, err := readCompact(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("mapsetint16: %d bytes of trailing compact data", r.Len())
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+// ReadCompact reads one encoding produced by WriteCompact from r and
+// adds its elements to s. Nothing is added if the encoding is invalid.
+// If r is not an io.ByteReader it is wrapped in a bufio.Reader, which
+// may consume bytes past the end of the encoding.
+func ReadCompact(r io.Reader, s Int16Set) error {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	elems, err := readCompact(br)
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func sortedCompactElements(s Int16Set) []int16 {
+	keys := s.ToSlice()
+	sortInt16Elements(keys)
+	return keys
+}
+
+func appendCompactHeader(b []byte, count int) []byte {
+	b = append(b, compactFormatVersion)
+	return binary.AppendUvarint(b, uint64(count))
+}
+
+// appendCompactElement appends the encoding of keys[i], which must be
+// sorted in ascending order.
+func appendCompactElement(b []byte, keys []int16, i int) []byte {
+	if i == 0 {
+		return binary.AppendVarint(b, int64(keys[0]))
+	}
+	return binary.AppendUvarint(b, toCompactKey(keys[i])-toCompactKey(keys[i-1]))
+}
+
+// toCompactKey maps elem onto a uint64 while preserving order, so that
+// the delta between two sorted elements is never negative.
+func toCompactKey(elem int16) uint64 {
+	return uint64(int64(elem)) ^ (1 << 63)
+}
+
+func fromCompactKey(key uint64) (int16, error) {
+	x := int64(key ^ (1 << 63))
+	elem := int16(x)
+	if int64(elem) != x {
+		return 0, fmt.Errorf("mapsetint16: value %v overflows int16", x)
+	}
+	return elem, nil
+}
+
+func readCompact(r io.ByteReader) ([]int16, error) {
+	version, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != compactFormatVersion {
+		return nil, fmt.Errorf("mapsetint16: unsupported compact format version %d", version)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	var elems []int16
+	var key uint64
+	for i := uint64(0); i < count; i++ {
+		if i == 0 {
+			x, err := binary.ReadVarint(r)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			key = uint64(x) ^ (1 << 63)
+		} else {
+			delta, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if delta == 0 || key+delta < key {
+				return nil, errCompactUnsorted
+			}
+			key += delta
+		}
+
+		elem, err := fromCompactKey(key)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// unexpectedEOF reports a clean end of input in the middle of an
+// encoding as io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContextTimedOutWriteKeepsReads(t *testing.T) {
+	s := NewInt16Set(sampleInt16Values[0]).(Int16ContextSet)
+	release := make(chan struct{})
+	held := make(chan struct{})
+	go s.Each(func(int16) bool {
+		close(held)
+		<-release
+		return true
+	})
+	<-held
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := s.AddContext(ctx, sampleInt16Values[1]); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if found, ok := s.TryContains(sampleInt16Values[0]); !ok || !found {
+		t.Errorf("expected TryContains to succeed after a timed out add, got %v, %v", found, ok)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if found, err := s.ContainsContext(ctx, sampleInt16Values[0]); err != nil || !found {
+		t.Errorf("expected ContainsContext to succeed after a timed out add, got %v, %v", found, err)
+	}
+}
+
+func TestContextWriterProgress(t *testing.T) {
+	s := NewInt16Set(sampleInt16Values[0]).(Int16ContextSet)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				s.Contains(sampleInt16Values[0])
+				time.Sleep(10 * time.Microsecond)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(stop)
+
+	// Writers are not queued ahead of readers, but busy readers that
+	// leave the set free now and then must not starve them.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, elem := range sampleInt16Values[1:] {
+		if _, err := s.AddContext(ctx, elem); err != nil {
+			t.Fatalf("expected to add %v alongside readers, got %v", elem, err)
+		}
+	}
+	if err := s.RemoveContext(ctx, sampleInt16Values[1]); err != nil {
+		t.Fatalf("expected to remove %v alongside readers, got %v", sampleInt16Values[1], err)
+	}
+}
@@ -0,0 +1,129 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// fuzzInt16Set builds a set from the sample values whose bit is set in
+// mask.
+func fuzzInt16Set(mask uint64) Int16Set {
+	s := NewThreadUnsafeInt16Set()
+	for i, v := range sampleInt16Values {
+		if mask&(1<<uint(i)) != 0 {
+			s.Add(v)
+		}
+	}
+	return s
+}
+
+// canonicalInt16String prints the elements of s in order, so that
+// sets can be compared even when equal elements are not ==, like times
+// in equal but distinct locations.
+func canonicalInt16String(s Int16Set) string {
+	elems := s.ToSlice()
+	sortInt16Elements(elems)
+	return fmt.Sprint(elems)
+}
+
+func FuzzSetOperations(f *testing.F) {
+	f.Add(uint64(0), uint64(0))
+	f.Add(uint64(0b1011), uint64(0b0110))
+	f.Add(^uint64(0), uint64(1))
+
+	f.Fuzz(func(t *testing.T, x, y uint64) {
+		a, b := fuzzInt16Set(x), fuzzInt16Set(y)
+		union, intersection := a.Union(b), a.Intersect(b)
+		difference, symmetric := a.Difference(b), a.SymmetricDifference(b)
+
+		subset := true
+		for i, v := range sampleInt16Values {
+			inA, inB := x&(1<<uint(i)) != 0, y&(1<<uint(i)) != 0
+			if inA && !inB {
+				subset = false
+			}
+			if union.Contains(v) != (inA || inB) {
+				t.Errorf("Union of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if intersection.Contains(v) != (inA && inB) {
+				t.Errorf("Intersect of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if difference.Contains(v) != (inA && !inB) {
+				t.Errorf("Difference of %v and %v: wrong membership of %v", a, b, v)
+			}
+			if symmetric.Contains(v) != (inA != inB) {
+				t.Errorf("SymmetricDifference of %v and %v: wrong membership of %v", a, b, v)
+			}
+		}
+		if a.IsSubset(b) != subset || b.IsSuperset(a) != subset {
+			t.Errorf("IsSubset and IsSuperset of %v and %v: expected %v", a, b, subset)
+		}
+		if union.Cardinality() != intersection.Cardinality()+symmetric.Cardinality() {
+			t.Errorf("expected |A ∪ B| = |A ∩ B| + |A △ B| for %v and %v", a, b)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for _, v := range sampleInt16Values {
+		b, err := json.Marshal(NewInt16Set(v))
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte(`[]`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`[1, "a", true, null]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := NewThreadUnsafeInt16Set()
+		if err := json.Unmarshal(data, s); err != nil {
+			return
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("marshaling %v decoded from %q: %v", s, data, err)
+		}
+		again := NewThreadUnsafeInt16Set()
+		if err := json.Unmarshal(b, again); err != nil {
+			t.Fatalf("unmarshaling %q: %v", b, err)
+		}
+		if want, got := canonicalInt16String(s), canonicalInt16String(again); want != got {
+			t.Fatalf("round trip of %q: expected %s, got %s", data, want, got)
+		}
+	})
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	for i := range sampleInt16Values {
+		b, err := fuzzInt16Set(1 << uint(i)).(*threadUnsafeInt16Set).MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := newThreadUnsafeInt16Set()
+		if err := s.UnmarshalBinary(data); err != nil {
+			return
+		}
+		b, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("marshaling %v decoded from %v: %v", &s, data, err)
+		}
+		again := newThreadUnsafeInt16Set()
+		if err := again.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unmarshaling %v: %v", b, err)
+		}
+		if want, got := canonicalInt16String(&s), canonicalInt16String(&again); want != got {
+			t.Fatalf("round trip of %v: expected %s, got %s", data, want, got)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"testing"
+)
+
+// int16HybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const int16HybridPrecision = 14
+
+// assertInt16HybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertInt16HybridCount(t *testing.T, name string, s *Int16HybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleInt16Values) / 2
+	s := NewInt16HybridSet(half, int16HybridPrecision)
+	for _, v := range sampleInt16Values[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertInt16HybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeInt16SetFromSlice(sampleInt16Values[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleInt16Values[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleInt16Values[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertInt16HybridCount(t, "converted", s, half+1)
+	s.Add(sampleInt16Values[0])
+	assertInt16HybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleInt16Values) / 2
+	newHybrid := func(sketched bool, elems []int16) *Int16HybridSet {
+		threshold := len(sampleInt16Values)
+		if sketched {
+			threshold = 0
+		}
+		s := NewInt16HybridSet(threshold, int16HybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleInt16Values[:half+1])
+		other := newHybrid(tc.sketchedO, sampleInt16Values[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeInt16SetFromSlice(sampleInt16Values)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleInt16Values, s.Exact())
+		}
+		assertInt16HybridCount(t, tc.name, s, len(sampleInt16Values))
+		assertInt16HybridCount(t, tc.name+" leaves other", other, len(sampleInt16Values)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewInt16HybridSet(half, int16HybridPrecision)
+	s.Add(sampleInt16Values[0])
+	if err := s.Merge(newHybrid(false, sampleInt16Values)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertInt16HybridCount(t, "merged past the threshold", s, len(sampleInt16Values))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleInt16Values) / 2
+	for _, threshold := range []int{len(sampleInt16Values), 0} {
+		s := NewInt16HybridSet(threshold, int16HybridPrecision)
+		for _, v := range sampleInt16Values[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleInt16Values), 0} {
+			other := NewInt16HybridSet(otherThreshold, int16HybridPrecision-2)
+			other.Add(sampleInt16Values[len(sampleInt16Values)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, int16HybridPrecision, int16HybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeInt16SetFromSlice(sampleInt16Values[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
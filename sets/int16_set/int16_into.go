@@ -0,0 +1,195 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeInt16Set()
+		return &s
+	},
+}
+
+// NewScratchInt16Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchInt16Set() Int16Set {
+	return scratchPool.Get().(*threadUnsafeInt16Set)
+}
+
+// Release empties s and makes it available to NewScratchInt16Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Int16Set) {
+	u, ok := s.(*threadUnsafeInt16Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Int16Set) {
+	d, x, y, locks := lockInt16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllInt16(d, y)
+	case dst == b:
+		addAllInt16(d, x)
+	default:
+		clear(d)
+		addAllInt16(d, x)
+		addAllInt16(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Int16Set) {
+	d, x, y, locks := lockInt16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyInt16(d, y)
+	case dst == b:
+		keepOnlyInt16(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Int16Set) {
+	d, x, y, locks := lockInt16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchInt16Set().(*threadUnsafeInt16Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllInt16(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllInt16(dst, src threadUnsafeInt16Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyInt16 removes the elements of dst that are not in other.
+func keepOnlyInt16(dst, other threadUnsafeInt16Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoInt16Locks records the locks taken by lockInt16Into, or the guards for
+// thread-unsafe sets.
+type intoInt16Locks struct {
+	sets     [3]*threadSafeInt16Set
+	distinct int
+	dst      *threadSafeInt16Set
+	guards   [3]unsafeInt16Guard
+}
+
+// lockInt16Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockInt16Into(dst, a, b Int16Set) (d, x, y threadUnsafeInt16Set, locks intoInt16Locks) {
+	w, ok := dst.(*threadSafeInt16Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeInt16Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeInt16Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeInt16Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeInt16Set), *b.(*threadUnsafeInt16Set), locks
+	}
+
+	locks.sets = [3]*threadSafeInt16Set{w, a.(*threadSafeInt16Set), b.(*threadSafeInt16Set)}
+	locks.distinct = len(lockInt16Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeInt16Set).s, b.(*threadSafeInt16Set).s, locks
+}
+
+func (l intoInt16Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
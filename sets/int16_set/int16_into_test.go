@@ -0,0 +1,74 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleInt16Values)
+	aValues, bValues := sampleInt16Values[:n-1], sampleInt16Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Int16Set)
+		expected []int16
+	}{
+		{"UnionInto", UnionInto, sampleInt16Values},
+		{"IntersectInto", IntersectInto, sampleInt16Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleInt16Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range int16SetFactories() {
+			expected := fillInt16Set(newSet(), testCase.expected...)
+
+			dst := fillInt16Set(newSet(), sampleInt16Values[n-1])
+			testCase.into(dst, fillInt16Set(newSet(), aValues...), fillInt16Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillInt16Set(newSet(), aValues...)
+			testCase.into(a, a, fillInt16Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillInt16Set(newSet(), bValues...)
+			testCase.into(b, fillInt16Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range int16SetFactories() {
+		s := fillInt16Set(newSet(), sampleInt16Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchInt16Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleInt16Values[0])
+	Release(s)
+	Release(NewInt16Set(sampleInt16Values[0]))
+
+	if again := NewScratchInt16Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
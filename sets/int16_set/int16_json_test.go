@@ -0,0 +1,49 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Int16Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Int16Set) error { return json.Unmarshal(b, s) }
+	assertInt16RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewInt16Set(sampleInt16Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewInt16Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleInt16Values[i%len(sampleInt16Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewInt16Set(sampleInt16Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleInt16Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetint16
+
+// unsafeInt16Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeInt16Guard struct{}
+
+func trackInt16Set(set *threadUnsafeInt16Set) {}
+
+func (set *threadUnsafeInt16Set) guardRead() unsafeInt16Guard { return unsafeInt16Guard{} }
+
+func (set *threadUnsafeInt16Set) guardWrite() unsafeInt16Guard { return unsafeInt16Guard{} }
+
+func (set *threadUnsafeInt16Set) guardIterate() unsafeInt16Guard { return unsafeInt16Guard{} }
+
+func (unsafeInt16Guard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Int16Set) Int16Set {
+	if len(sets) == 0 {
+		return NewInt16Set()
+	}
+	maps, unlock := readLockInt16Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeInt16Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapInt16Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Int16Set) Int16Set {
+	if len(sets) == 0 {
+		return NewInt16Set()
+	}
+	maps, unlock := readLockInt16Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeInt16Set()
+	for elem := range maps[smallest] {
+		if containedInAllInt16(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapInt16Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Int16Set, sets ...Int16Set) Int16Set {
+	maps, unlock := readLockInt16Sets(append([]Int16Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeInt16Set()
+	for elem := range maps[0] {
+		if !containedInAnyInt16(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapInt16Like(base, difference)
+}
+
+func containedInAllInt16(maps []threadUnsafeInt16Set, skip int, elem int16) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyInt16(maps []threadUnsafeInt16Set, elem int16) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapInt16Like returns s as a Set of the same implementation as like.
+func wrapInt16Like(like Int16Set, s threadUnsafeInt16Set) Int16Set {
+	if _, ok := like.(*threadSafeInt16Set); ok {
+		return &threadSafeInt16Set{s: s}
+	}
+	trackInt16Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Int16Pair represents a 2-tuple of values.
+type Int16Pair struct {
+	First  int16
+	Second int16
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Int16Pair) Equal(other Int16Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Int16Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Int16PairSet is an unordered set of Int16Pairs, as
+// returned by CartesianProduct.
+type Int16PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Int16Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Int16Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Int16Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Int16PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Int16Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Int16Pair
+}
+
+type threadUnsafeInt16PairSet map[Int16Pair]struct{}
+
+func newThreadUnsafeInt16PairSet() threadUnsafeInt16PairSet {
+	return make(threadUnsafeInt16PairSet)
+}
+
+func (set *threadUnsafeInt16PairSet) Add(p Int16Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeInt16PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeInt16PairSet) Contains(p ...Int16Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt16PairSet) Each(cb func(Int16Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeInt16PairSet) Equal(other Int16PairSet) bool {
+	_ = other.(*threadUnsafeInt16PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt16PairSet) Remove(p Int16Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeInt16PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeInt16PairSet) ToSlice() []Int16Pair {
+	keys := make([]Int16Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeInt16PairSet struct {
+	s threadUnsafeInt16PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeInt16PairSet) Add(p Int16Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeInt16PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeInt16PairSet) Contains(p ...Int16Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt16PairSet) Each(cb func(Int16Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockInt16PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockInt16Pair does for sets.
+func rlockInt16PairSets(x, y *threadSafeInt16PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt16PairSets releases the locks taken by rlockInt16PairSets.
+func runlockInt16PairSets(x, y *threadSafeInt16PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeInt16PairSet) Equal(other Int16PairSet) bool {
+	o := other.(*threadSafeInt16PairSet)
+
+	rlockInt16PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockInt16PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeInt16PairSet) Remove(p Int16Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeInt16PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt16PairSet) ToSlice() []Int16Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
 package mapsetint16
 
 // Int16Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Int16Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Int16Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Int16Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() int16
 
-	// Returns all subsets of a given set (Power Int16Set).
-	// Not yet supported
-	// PowerSet() Int16Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Int16Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Int16Set) Int16Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Int16Set) Int16PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []int16
@@ -145,7 +154,7 @@ type Int16Set interface {
 // NewInt16Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewInt16Set(s ...int16) Int16Set {
-	set := newThreadSafeInt16Set()
+	set := threadSafeInt16Set{s: newThreadUnsafeInt16SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewInt16SetFromSlice(s []int16) Int16Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeInt16Set() Int16Set {
 	set := newThreadUnsafeInt16Set()
+	trackInt16Set(&set)
+	return &set
+}
+
+// NewInt16SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewInt16SetWithCapacity(n int) Int16Set {
+	return &threadSafeInt16Set{s: newThreadUnsafeInt16SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeInt16SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeInt16SetWithCapacity(n int) Int16Set {
+	set := newThreadUnsafeInt16SetWithCapacity(n)
+	trackInt16Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeInt16Set() Int16Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeInt16SetFromSlice(s []int16) Int16Set {
-	a := NewThreadUnsafeInt16Set()
+	a := NewThreadUnsafeInt16SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
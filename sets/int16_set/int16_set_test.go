@@ -0,0 +1,105 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleInt16Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleInt16Values = []int16{
+	-128,
+	-1,
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+}
+
+// int16SetFactories returns constructors for both implementations.
+func int16SetFactories() map[string]func() Int16Set {
+	return map[string]func() Int16Set{
+		"safe":   func() Int16Set { return NewInt16Set() },
+		"unsafe": NewThreadUnsafeInt16Set,
+	}
+}
+
+// fillInt16Set adds values to s and returns it.
+func fillInt16Set(s Int16Set, values ...int16) Int16Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertInt16RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertInt16RoundTrip(t *testing.T, marshal func(Int16Set) ([]byte, error), unmarshal func([]byte, Int16Set) error) {
+	t.Helper()
+	contents := [][]int16{sampleInt16Values, sampleInt16Values[:1]}
+	for name, newSet := range int16SetFactories() {
+		for _, values := range contents {
+			s := fillInt16Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range int16SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[int16, Int16Set]{
+				New:   newSet,
+				Elems: sampleInt16Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range int16SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleInt16Values[0])
+		a.Add(sampleInt16Values[1])
+		for _, v := range sampleInt16Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleInt16Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Int16Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
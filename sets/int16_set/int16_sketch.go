@@ -0,0 +1,162 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// hashInt16Element returns a hash of elem that is stable across
+// processes, using buf as scratch space, and the grown buffer. Elements
+// without a binary encoding are hashed by their formatted value. elem
+// is hashed as the set stores it, so that filters built from a set find
+// every value the set contains.
+func hashInt16Element(buf []byte, elem int16) (uint64, []byte) {
+	elem = keyInt16(elem)
+	b, err := appendInt16Element(buf, elem)
+	if err != nil {
+		b = fmt.Appendf(buf, "%#v", elem)
+	}
+	return sketch.Hash64(b, 0), b
+}
+
+// MinHashSignature returns the MinHash signature of set under hasher.
+// Elements are hashed by their binary encoding, so signatures of
+// Int16Sets are comparable with each other but not with those of
+// other set types.
+func MinHashSignature(set Int16Set, hasher *sketch.MinHasher) sketch.Signature {
+	sig := hasher.NewSignature()
+	var buf []byte
+	set.Each(func(elem int16) bool {
+		var h uint64
+		h, buf = hashInt16Element(buf[:0], elem)
+		hasher.Push(sig, h)
+		return false
+	})
+	return sig
+}
+
+// ToHyperLogLog returns a HyperLogLog sketch of set with the given
+// precision, hashing elements as MinHashSignature does, so that it can
+// be merged with sketches of other Int16Sets.
+func ToHyperLogLog(set Int16Set, precision uint8) *sketch.HyperLogLog {
+	hll := sketch.NewHyperLogLog(precision)
+	var buf []byte
+	set.Each(func(elem int16) bool {
+		var x uint64
+		x, buf = hashInt16Element(buf[:0], elem)
+		hll.AddHash(x)
+		return false
+	})
+	return hll
+}
+
+// Int16BloomFilter is a sketch.BloomFilter over int16 elements, which hashes
+// elements as MinHashSignature does. A decoded filter answers queries
+// the same way as the one that was encoded.
+type Int16BloomFilter struct {
+	sketch.BloomFilter
+}
+
+// ToBloomFilter returns a Bloom filter holding the elements of set,
+// sized for a false positive rate of about fpRate.
+func ToBloomFilter(set Int16Set, fpRate float64) *Int16BloomFilter {
+	filter := &Int16BloomFilter{*sketch.NewBloomFilter(set.Cardinality(), fpRate)}
+	var buf []byte
+	set.Each(func(elem int16) bool {
+		var h uint64
+		h, buf = hashInt16Element(buf[:0], elem)
+		filter.AddHash(h)
+		return false
+	})
+	return filter
+}
+
+// Add adds elem to the filter.
+func (f *Int16BloomFilter) Add(elem int16) {
+	h, _ := hashInt16Element(nil, elem)
+	f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Int16BloomFilter) MightContain(elem int16) bool {
+	h, _ := hashInt16Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Int16CuckooFilter is a sketch.CuckooFilter over int16 elements, which hashes
+// elements as MinHashSignature does.
+type Int16CuckooFilter struct {
+	sketch.CuckooFilter
+}
+
+// ToCuckooFilter returns a cuckoo filter holding the elements of set,
+// sized for a false positive rate of about fpRate. Elements with equal
+// hashes, such as NaNs, are added once. The filter is grown until every
+// element fits, up to a limit, past which an error is returned.
+func ToCuckooFilter(set Int16Set, fpRate float64) (*Int16CuckooFilter, error) {
+	var hashes []uint64
+	var buf []byte
+	set.Each(func(elem int16) bool {
+		var h uint64
+		h, buf = hashInt16Element(buf[:0], elem)
+		hashes = append(hashes, h)
+		return false
+	})
+	f, err := newCuckooFilterFromHashes(hashes, fpRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Int16CuckooFilter{*f}, nil
+}
+
+// newCuckooFilterFromHashes returns a filter holding the distinct hashes,
+// doubling its capacity at most cuckooMaxGrowths times.
+func newCuckooFilterFromHashes(hashes []uint64, fpRate float64) (*sketch.CuckooFilter, error) {
+	// A bucket pair holds eight fingerprints, so repeats of one hash
+	// would collide however large the filter grows.
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	const cuckooMaxGrowths = 8
+	n := len(hashes)
+	for i := 0; i <= cuckooMaxGrowths; i++ {
+		f := sketch.NewCuckooFilter(n, fpRate)
+		if addAllHashes(f, hashes) {
+			return f, nil
+		}
+		n *= 2
+	}
+	return nil, fmt.Errorf("mapsetint16: cannot fit %d distinct hashes in a cuckoo filter", len(hashes))
+}
+
+func addAllHashes(f *sketch.CuckooFilter, hashes []uint64) bool {
+	for _, h := range hashes {
+		if !f.AddHash(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds elem to the filter and reports whether there was room.
+func (f *Int16CuckooFilter) Add(elem int16) bool {
+	h, _ := hashInt16Element(nil, elem)
+	return f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Int16CuckooFilter) MightContain(elem int16) bool {
+	h, _ := hashInt16Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Delete removes elem from the filter and reports whether it was found.
+// Only delete elements that were added.
+func (f *Int16CuckooFilter) Delete(elem int16) bool {
+	h, _ := hashInt16Element(nil, elem)
+	return f.DeleteHash(h)
+}
@@ -0,0 +1,41 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"testing"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewInt16Set(sampleInt16Values...)
+	queries := s.ToSlice()
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewInt16Set(sampleInt16Values...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleInt16Values) {
+		t.Errorf("expected %d elements, got %d", len(sampleInt16Values), f.Len())
+	}
+	for _, v := range sampleInt16Values {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+}
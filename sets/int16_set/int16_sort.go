@@ -0,0 +1,18 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"sort"
+)
+
+// lessInt16 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessInt16(a, b int16) bool {
+	return a < b
+}
+
+// sortInt16Elements sorts elems in place using lessInt16.
+func sortInt16Elements(elems []int16) {
+	sort.Slice(elems, func(i, j int) bool { return lessInt16(elems[i], elems[j]) })
+}
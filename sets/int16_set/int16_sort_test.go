@@ -0,0 +1,26 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]int16, 0, len(sampleInt16Values))
+	for i := len(sampleInt16Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleInt16Values[i])
+	}
+	sortInt16Elements(elems)
+	for i, elem := range elems {
+		if lessInt16(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessInt16(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewInt16Set(elems...).Equal(NewInt16Set(sampleInt16Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleInt16Values, elems)
+	}
+}
@@ -0,0 +1,254 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Int16SQLFormat selects how a set is stored in a database column.
+type Int16SQLFormat int
+
+const (
+	// Int16SQLArray stores sets as Postgres array literals such as
+	// {a,b}, for array columns.
+	Int16SQLArray Int16SQLFormat = iota
+
+	// Int16SQLJSON stores sets as JSON arrays, for json, jsonb and
+	// text columns.
+	Int16SQLJSON
+)
+
+var errSQLMultidimensional = errors.New("mapsetint16: multidimensional arrays are not supported")
+
+// Int16SQLValue adapts a Int16Set for use as a query argument or a
+// scan destination with database/sql, in a chosen format.
+//
+// A NULL column scans as an empty set. Scanning replaces the contents
+// of the set and detects the format of the column, so either format
+// can be read regardless of Format.
+type Int16SQLValue struct {
+	set    Int16Set
+	Format Int16SQLFormat
+}
+
+// NewInt16SQLValue returns a Int16SQLValue that reads and writes s
+// in format.
+func NewInt16SQLValue(s Int16Set, format Int16SQLFormat) *Int16SQLValue {
+	return &Int16SQLValue{set: s, Format: format}
+}
+
+// Value implements driver.Valuer.
+func (v *Int16SQLValue) Value() (driver.Value, error) {
+	if v.set == nil {
+		return nil, nil
+	}
+	if v.Format == Int16SQLJSON {
+		b, err := json.Marshal(v.set)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return formatInt16SQLArray(v.set)
+}
+
+// Scan implements sql.Scanner.
+func (v *Int16SQLValue) Scan(src interface{}) error {
+	elems, err := scanInt16SQL(src)
+	if err != nil {
+		return err
+	}
+
+	v.set.Clear()
+	for _, elem := range elems {
+		v.set.Add(elem)
+	}
+	return nil
+}
+
+// formatInt16SQLArray renders s as a Postgres array literal, in
+// sorted order.
+func formatInt16SQLArray(s Int16Set) (string, error) {
+	elems := s.ToSlice()
+	sortInt16Elements(elems)
+
+	items := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		item, err := formatInt16Text(elem)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, item)
+	}
+	return "{" + strings.Join(items, ",") + "}", nil
+}
+
+// scanInt16SQL decodes a column holding either a JSON array or a
+// Postgres array literal. NULL decodes as no elements.
+func scanInt16SQL(src interface{}) ([]int16, error) {
+	var text []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		text = v
+	case string:
+		text = []byte(v)
+	default:
+		return nil, fmt.Errorf("mapsetint16: cannot scan %T into a set", src)
+	}
+
+	text = bytes.TrimSpace(text)
+	switch {
+	case bytes.HasPrefix(text, []byte("[")):
+		var elems []int16
+		if err := json.Unmarshal(text, &elems); err != nil {
+			return nil, err
+		}
+		return elems, nil
+	case bytes.HasPrefix(text, []byte("{")):
+		items, err := parseSQLArray(string(text))
+		if err != nil {
+			return nil, err
+		}
+
+		elems := make([]int16, 0, len(items))
+		for _, item := range items {
+			if item.null {
+				return nil, errors.New("mapsetint16: cannot scan a NULL array element")
+			}
+			elem, err := parseInt16Text(item.text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("mapsetint16: cannot scan %q into a set", text)
+	}
+}
+
+// sqlArrayItem is a single element of a Postgres array literal.
+type sqlArrayItem struct {
+	text string
+	null bool
+}
+
+// parseSQLArray parses a one-dimensional Postgres array literal such
+// as {a,"b c",NULL}.
+func parseSQLArray(s string) ([]sqlArrayItem, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("mapsetint16: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var items []sqlArrayItem
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+
+		var item sqlArrayItem
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("mapsetint16: unterminated quote in array literal")
+			}
+			item.text = b.String()
+			s = strings.TrimLeft(s[i+1:], " \t\n\r")
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			item.text = strings.TrimSpace(s[:end])
+			if item.text == "" {
+				return nil, fmt.Errorf("mapsetint16: empty unquoted element in array literal")
+			}
+			if strings.ContainsAny(item.text, `{}"\`) {
+				if strings.HasPrefix(item.text, "{") {
+					return nil, errSQLMultidimensional
+				}
+				return nil, fmt.Errorf("mapsetint16: invalid array element %q", item.text)
+			}
+			item.null = strings.EqualFold(item.text, "NULL")
+			s = s[end:]
+		}
+		items = append(items, item)
+
+		if s == "" {
+			return items, nil
+		}
+		if s[0] != ',' {
+			return nil, fmt.Errorf("mapsetint16: expected ',' in array literal, found %q", s)
+		}
+		s = s[1:]
+	}
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewInt16SQLValue for JSON columns.
+func (set *threadUnsafeInt16Set) Value() (driver.Value, error) {
+	return formatInt16SQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. NULL scans as an empty set.
+// The set is left untouched on error.
+func (set *threadUnsafeInt16Set) Scan(src interface{}) error {
+	elems, err := scanInt16SQL(src)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeInt16Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewInt16SQLValue for JSON columns.
+func (set *threadSafeInt16Set) Value() (driver.Value, error) {
+	return formatInt16SQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. NULL scans as an empty set.
+// The set is left untouched on error.
+func (set *threadSafeInt16Set) Scan(src interface{}) error {
+	elems, err := scanInt16SQL(src)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeInt16Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
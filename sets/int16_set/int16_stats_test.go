@@ -0,0 +1,57 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	s := NewInt16Set(sampleInt16Values[0])
+	st := EnableStats(s)
+	if EnableStats(s) != st {
+		t.Error("expected EnableStats to return the existing stats")
+	}
+
+	s.Add(sampleInt16Values[1])
+	s.Add(sampleInt16Values[1])
+	s.Contains(sampleInt16Values[0])
+	s.Contains(sampleInt16Values[0], sampleInt16Values[1])
+	s.Remove(sampleInt16Values[1])
+	s.Remove(sampleInt16Values[1])
+	s.Contains(sampleInt16Values[1])
+	s.Pop()
+
+	want := Int16StatsSnapshot{
+		Adds:           1,
+		Removes:        2,
+		Hits:           2,
+		Misses:         1,
+		MaxCardinality: 2,
+	}
+	got := st.Snapshot()
+	if got.LockWaits != 8 {
+		t.Errorf("expected 8 timed lock acquisitions, got %d", got.LockWaits)
+	}
+	got.LockWaits, got.LockWait, got.MaxLockWait = 0, 0, 0
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	var decoded Int16StatsSnapshot
+	if err := json.Unmarshal([]byte(st.String()), &decoded); err != nil || decoded.Adds != 1 {
+		t.Errorf("expected String to hold the snapshot as JSON, got %s, %v", st.String(), err)
+	}
+
+	st.Reset()
+	if got := st.Snapshot(); got != (Int16StatsSnapshot{}) {
+		t.Errorf("expected zero stats after Reset, got %+v", got)
+	}
+
+	DisableStats(s)
+	s.Add(sampleInt16Values[1])
+	if got := st.Snapshot(); got.Adds != 0 {
+		t.Errorf("expected no adds recorded after DisableStats, got %d", got.Adds)
+	}
+}
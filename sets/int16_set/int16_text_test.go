@@ -0,0 +1,14 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Int16Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Int16Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertInt16RoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
 package mapsetint16
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeInt16Set struct {
-	s threadUnsafeInt16Set
+	s     threadUnsafeInt16Set
+	stats atomic.Pointer[Int16SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeInt16Set() threadSafeInt16Set {
 	return threadSafeInt16Set{s: newThreadUnsafeInt16Set()}
 }
 
+// readLockInt16Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockInt16Sets(sets []Int16Set) ([]threadUnsafeInt16Set, func()) {
+	maps := make([]threadUnsafeInt16Set, len(sets))
+	if _, ok := sets[0].(*threadSafeInt16Set); !ok {
+		guards := make([]unsafeInt16Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeInt16Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeInt16Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeInt16Set))
+	}
+	distinct := lockInt16Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeInt16Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockInt16Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockInt16Order(sets []*threadSafeInt16Set) []*threadSafeInt16Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockInt16Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockInt16Pair(x, y *threadSafeInt16Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt16Pair releases the locks taken by rlockInt16Pair.
+func runlockInt16Pair(x, y *threadSafeInt16Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeInt16Set) Add(i int16) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeInt16Set) Contains(i ...int16) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeInt16Set) IsSubset(other Int16Set) bool {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt16Set) IsProperSubset(other Int16Set) bool {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockInt16Pair(set, o)
+	defer runlockInt16Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeInt16Set) IsProperSuperset(other Int16Set) bool {
 func (set *threadSafeInt16Set) Union(other Int16Set) Int16Set {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
+	readInt16Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeInt16Set)
 	ret := &threadSafeInt16Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt16Set) Intersect(other Int16Set) Int16Set {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
+	readInt16Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeInt16Set)
 	ret := &threadSafeInt16Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt16Set) Difference(other Int16Set) Int16Set {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
+	readInt16Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeInt16Set)
 	ret := &threadSafeInt16Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt16Set) SymmetricDifference(other Int16Set) Int16Set {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
+	readInt16Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeInt16Set)
 	ret := &threadSafeInt16Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt16Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeInt16Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeInt16Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeInt16Set) Remove(i int16) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyInt16(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeInt16Set) Cardinality() int {
 }
 
 func (set *threadSafeInt16Set) Each(cb func(int16) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeInt16Set) Iterator() *Int16Iterator {
 func (set *threadSafeInt16Set) Equal(other Int16Set) bool {
 	o := other.(*threadSafeInt16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt16Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt16Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeInt16Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt16Set) PowerSet() Int16Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeInt16Set)
-    set.RUnlock()
+func (set *threadSafeInt16Set) PowerSet() []Int16Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeInt16Set{s: newThreadUnsafeInt16Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeInt16Set)
-        ret.Add(&threadSafeInt16Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Int16Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeInt16Set)
+		ret = append(ret, &threadSafeInt16Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeInt16Set) Pop() int16 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt16Set) CartesianProduct(other Int16Set) Int16Set {
-    o := other.(*threadSafeInt16Set)
+func (set *threadSafeInt16Set) CartesianProduct(other Int16Set) Int16PairSet {
+	o := other.(*threadSafeInt16Set)
 
-    set.RLock()
-    o.RLock()
+	rlockInt16Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt16Set)
-    ret := &threadSafeInt16Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt16PairSet)
+	ret := &threadSafeInt16PairSet{s: *unsafeCartProduct}
+	runlockInt16Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeInt16Set) ToSlice() []int16 {
-	keys := make([]int16, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]int16, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeInt16Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeInt16Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
 package mapsetint16
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeInt16Set map[int16]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  int16
-	Second int16
-}
-
 func newThreadUnsafeInt16Set() threadUnsafeInt16Set {
 	return make(threadUnsafeInt16Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeInt16SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeInt16SetWithCapacity(n int) threadUnsafeInt16Set {
+	return make(threadUnsafeInt16Set, n)
+}
 
-	return false
+// keyInt16 returns the map key of i.
+func keyInt16(i int16) int16 {
+	return i
 }
 
 func (set *threadUnsafeInt16Set) Add(i int16) bool {
+	defer set.guardWrite().done()
+	i = keyInt16(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeInt16Set) Add(i int16) bool {
 }
 
 func (set *threadUnsafeInt16Set) Contains(i ...int16) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyInt16(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeInt16Set) Contains(i ...int16) bool {
 
 func (set *threadUnsafeInt16Set) IsSubset(other Int16Set) bool {
 	_ = other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeInt16Set) IsSubset(other Int16Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeInt16Set) IsProperSubset(other Int16Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeInt16Set) IsSuperset(other Int16Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeInt16Set) IsSuperset(other Int16Set) bool {
 }
 
 func (set *threadUnsafeInt16Set) IsProperSuperset(other Int16Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeInt16Set) Union(other Int16Set) Int16Set {
 	o := other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeInt16Set()
+	unionedSet := newThreadUnsafeInt16SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeInt16Set) Union(other Int16Set) Int16Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackInt16Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeInt16Set) Intersect(other Int16Set) Int16Set {
 	o := other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeInt16Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeInt16Set) Intersect(other Int16Set) Int16Set {
 			}
 		}
 	}
+	trackInt16Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeInt16Set) Difference(other Int16Set) Int16Set {
 	_ = other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeInt16Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeInt16Set) Difference(other Int16Set) Int16Set {
 			difference.Add(elem)
 		}
 	}
+	trackInt16Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeInt16Set) SymmetricDifference(other Int16Set) Int16Set {
-	_ = other.(*threadUnsafeInt16Set)
+	o := other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeInt16Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackInt16Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeInt16Set) Clear() {
-	*set = newThreadUnsafeInt16Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeInt16Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeInt16SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeInt16Set) Remove(i int16) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyInt16(i))
 }
 
 func (set *threadUnsafeInt16Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeInt16Set) Each(cb func(int16) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeInt16Set) Each(cb func(int16) bool) {
 func (set *threadUnsafeInt16Set) Iter() <-chan int16 {
 	ch := make(chan int16)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeInt16Set) Iterator() *Int16Iterator {
 	iterator, ch, stopCh := newInt16Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeInt16Set) Iterator() *Int16Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeInt16Set) Iterator() *Int16Iterator {
 
 func (set *threadUnsafeInt16Set) Equal(other Int16Set) bool {
 	_ = other.(*threadUnsafeInt16Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeInt16Set) Equal(other Int16Set) bool {
 }
 
 func (set *threadUnsafeInt16Set) Clone() Int16Set {
-	clonedSet := newThreadUnsafeInt16Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeInt16SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackInt16Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeInt16Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeInt16Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeInt16Set) Pop() int16 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeInt16Set) Pop() int16 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt16Set) PowerSet() Int16Set {
-	powSet := NewThreadUnsafeInt16Set()
+func (set *threadUnsafeInt16Set) PowerSet() []Int16Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeInt16Set()
-	powSet.Add(&nullset)
+	powSet := []Int16Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeInt16Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeInt16Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeInt16Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt16Set) CartesianProduct(other Int16Set) Int16Set {
+func (set *threadUnsafeInt16Set) CartesianProduct(other Int16Set) Int16PairSet {
 	o := other.(*threadUnsafeInt16Set)
-	cartProduct := NewThreadUnsafeInt16Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeInt16PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Int16Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeInt16Set) ToSlice() []int16 {
+	defer set.guardRead().done()
 	keys := make([]int16, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeInt16Set) ToSlice() []int16 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeInt16Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Int16JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeInt16Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeInt16Set) UnmarshalJSON(data []byte) error {
 	var i []int16
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
@@ -0,0 +1,92 @@
+// Code generated by generate_set for int16. DO NOT EDIT.
+
+package mapsetint16
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestXMLRoundTrip(t *testing.T) {
+	marshal := func(s Int16Set) ([]byte, error) { return xml.Marshal(s) }
+	unmarshal := func(b []byte, s Int16Set) error { return xml.Unmarshal(b, s) }
+	assertInt16RoundTrip(t, marshal, unmarshal)
+
+	for name, newSet := range int16SetFactories() {
+		s := newSet()
+		for _, v := range sampleInt16Values {
+			s.Add(v)
+		}
+
+		b, err := xml.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := strings.Count(string(b), "<"+DefaultInt16XMLItemName+">"); got != len(sampleInt16Values) {
+			t.Errorf("%s: expected %d item elements in %s, got %d", name, len(sampleInt16Values), b, got)
+		}
+
+		decoded := newSet()
+		decoded.Add(sampleInt16Values[0])
+		if err := xml.Unmarshal(b, decoded); err != nil {
+			t.Errorf("%s: unmarshal %s: %v", name, b, err)
+			continue
+		}
+		if !decoded.Equal(s) {
+			t.Errorf("%s: expected %v after a round trip of %s, got %v", name, s, b, decoded)
+		}
+	}
+}
+
+func TestXMLItemName(t *testing.T) {
+	type config struct {
+		XMLName xml.Name `xml:"config"`
+		Allow   Int16XML `xml:"allow"`
+	}
+
+	in := config{Allow: Int16XML{Set: NewInt16Set(sampleInt16Values...), ItemName: "host"}}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "<allow><host>") {
+		t.Errorf("expected items named host in %s", b)
+	}
+
+	// Children with other names are skipped, and elements are added to
+	// the set already present.
+	text := strings.Replace(string(b), "<allow>", "<allow><comment>skipped</comment>", 1)
+	out := config{Allow: Int16XML{Set: NewInt16Set(), ItemName: "host"}}
+	if err := xml.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Allow.Set.Equal(in.Allow.Set) {
+		t.Errorf("expected %v from %s, got %v", in.Allow.Set, text, out.Allow.Set)
+	}
+}
+
+func TestUnmarshalXMLMalformed(t *testing.T) {
+	valid, err := formatInt16Text(sampleInt16Values[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []string{
+		"<set><item>" + valid + "</item>",
+		"<set><item>" + valid + "</set>",
+		"<set><item>" + valid + "</item><item>not a value</item></set>",
+	}
+
+	for name, newSet := range int16SetFactories() {
+		for _, input := range inputs {
+			s := newSet()
+			s.Add(sampleInt16Values[1])
+			if err := xml.Unmarshal([]byte(input), s); err == nil {
+				t.Errorf("%s: expected an error unmarshaling %q", name, input)
+			}
+			if s.Cardinality() != 1 || !s.Contains(sampleInt16Values[1]) {
+				t.Errorf("%s: expected the set to be left untouched on error, got %v", name, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Int32Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleInt32Values[i%len(sampleInt32Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewInt32Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeInt32Set())
+}
+
+func benchContains(b *testing.B, s Int32Set) {
+	for _, v := range sampleInt32Values[:len(sampleInt32Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleInt32Values[i%len(sampleInt32Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewInt32Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeInt32Set())
+}
+
+func benchUnion(b *testing.B, x, y Int32Set) {
+	half := len(sampleInt32Values) / 2
+	for _, v := range sampleInt32Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleInt32Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewInt32Set(), NewInt32Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeInt32Set(), NewThreadUnsafeInt32Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Int32Set) {
+	for _, v := range sampleInt32Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewInt32Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeInt32Set())
+}
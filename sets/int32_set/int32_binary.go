@@ -0,0 +1,149 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryFormatVersion is the first byte of every binary encoding.
+// Encodings in this package start with a version byte that decoders
+// check, so any change to a format must bump its version.
+const binaryFormatVersion byte = 1
+
+var errBinaryTruncated = errors.New("mapsetint32: truncated binary data")
+
+// appendInt32Element appends the length-prefixed binary encoding
+// of elem to b.
+func appendInt32Element(b []byte, elem int32) ([]byte, error) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], int64(elem))
+	b = binary.AppendUvarint(b, uint64(n))
+	return append(b, buf[:n]...), nil
+}
+
+// decodeInt32Element decodes a single element payload written by
+// appendInt32Element, without its length prefix.
+func decodeInt32Element(payload []byte) (int32, error) {
+	x, n := binary.Varint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, fmt.Errorf("mapsetint32: invalid varint payload %v", payload)
+	}
+	elem := int32(x)
+	if int64(elem) != x {
+		return 0, fmt.Errorf("mapsetint32: value %v overflows int32", x)
+	}
+	return elem, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadUnsafeInt32Set) MarshalBinary() ([]byte, error) {
+	defer set.guardRead().done()
+	b := []byte{binaryFormatVersion}
+	b = binary.AppendUvarint(b, uint64(len(*set)))
+
+	var err error
+	for elem := range *set {
+		b, err = appendInt32Element(b, elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadUnsafeInt32Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalInt32Binary(data)
+	if err != nil {
+		return err
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadUnsafeInt32Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadUnsafeInt32Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+func unmarshalInt32Binary(data []byte) (threadUnsafeInt32Set, error) {
+	if len(data) == 0 {
+		return nil, errBinaryTruncated
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, fmt.Errorf("mapsetint32: unsupported binary format version %d", data[0])
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errBinaryTruncated
+	}
+	data = data[n:]
+
+	set := newThreadUnsafeInt32Set()
+	for i := uint64(0); i < count; i++ {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, errBinaryTruncated
+		}
+		data = data[n:]
+
+		elem, err := decodeInt32Element(data[:size])
+		if err != nil {
+			return nil, err
+		}
+		set.Add(elem)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("mapsetint32: %d bytes of trailing binary data", len(data))
+	}
+	return set, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadSafeInt32Set) MarshalBinary() ([]byte, error) {
+	set.RLock()
+	b, err := set.s.MarshalBinary()
+	set.RUnlock()
+
+	return b, err
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadSafeInt32Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalInt32Binary(data)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = decoded
+	set.Unlock()
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadSafeInt32Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadSafeInt32Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
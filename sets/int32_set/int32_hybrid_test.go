@@ -0,0 +1,127 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"testing"
+)
+
+// int32HybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const int32HybridPrecision = 14
+
+// assertInt32HybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertInt32HybridCount(t *testing.T, name string, s *Int32HybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleInt32Values) / 2
+	s := NewInt32HybridSet(half, int32HybridPrecision)
+	for _, v := range sampleInt32Values[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertInt32HybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeInt32SetFromSlice(sampleInt32Values[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleInt32Values[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleInt32Values[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertInt32HybridCount(t, "converted", s, half+1)
+	s.Add(sampleInt32Values[0])
+	assertInt32HybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleInt32Values) / 2
+	newHybrid := func(sketched bool, elems []int32) *Int32HybridSet {
+		threshold := len(sampleInt32Values)
+		if sketched {
+			threshold = 0
+		}
+		s := NewInt32HybridSet(threshold, int32HybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleInt32Values[:half+1])
+		other := newHybrid(tc.sketchedO, sampleInt32Values[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeInt32SetFromSlice(sampleInt32Values)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleInt32Values, s.Exact())
+		}
+		assertInt32HybridCount(t, tc.name, s, len(sampleInt32Values))
+		assertInt32HybridCount(t, tc.name+" leaves other", other, len(sampleInt32Values)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewInt32HybridSet(half, int32HybridPrecision)
+	s.Add(sampleInt32Values[0])
+	if err := s.Merge(newHybrid(false, sampleInt32Values)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertInt32HybridCount(t, "merged past the threshold", s, len(sampleInt32Values))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleInt32Values) / 2
+	for _, threshold := range []int{len(sampleInt32Values), 0} {
+		s := NewInt32HybridSet(threshold, int32HybridPrecision)
+		for _, v := range sampleInt32Values[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleInt32Values), 0} {
+			other := NewInt32HybridSet(otherThreshold, int32HybridPrecision-2)
+			other.Add(sampleInt32Values[len(sampleInt32Values)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, int32HybridPrecision, int32HybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeInt32SetFromSlice(sampleInt32Values[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeInt32Set()
+		return &s
+	},
+}
+
+// NewScratchInt32Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchInt32Set() Int32Set {
+	return scratchPool.Get().(*threadUnsafeInt32Set)
+}
+
+// Release empties s and makes it available to NewScratchInt32Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Int32Set) {
+	u, ok := s.(*threadUnsafeInt32Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Int32Set) {
+	d, x, y, locks := lockInt32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllInt32(d, y)
+	case dst == b:
+		addAllInt32(d, x)
+	default:
+		clear(d)
+		addAllInt32(d, x)
+		addAllInt32(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Int32Set) {
+	d, x, y, locks := lockInt32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyInt32(d, y)
+	case dst == b:
+		keepOnlyInt32(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Int32Set) {
+	d, x, y, locks := lockInt32Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchInt32Set().(*threadUnsafeInt32Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllInt32(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllInt32(dst, src threadUnsafeInt32Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyInt32 removes the elements of dst that are not in other.
+func keepOnlyInt32(dst, other threadUnsafeInt32Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoInt32Locks records the locks taken by lockInt32Into, or the guards for
+// thread-unsafe sets.
+type intoInt32Locks struct {
+	sets     [3]*threadSafeInt32Set
+	distinct int
+	dst      *threadSafeInt32Set
+	guards   [3]unsafeInt32Guard
+}
+
+// lockInt32Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockInt32Into(dst, a, b Int32Set) (d, x, y threadUnsafeInt32Set, locks intoInt32Locks) {
+	w, ok := dst.(*threadSafeInt32Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeInt32Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeInt32Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeInt32Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeInt32Set), *b.(*threadUnsafeInt32Set), locks
+	}
+
+	locks.sets = [3]*threadSafeInt32Set{w, a.(*threadSafeInt32Set), b.(*threadSafeInt32Set)}
+	locks.distinct = len(lockInt32Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeInt32Set).s, b.(*threadSafeInt32Set).s, locks
+}
+
+func (l intoInt32Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
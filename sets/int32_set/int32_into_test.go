@@ -0,0 +1,74 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleInt32Values)
+	aValues, bValues := sampleInt32Values[:n-1], sampleInt32Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Int32Set)
+		expected []int32
+	}{
+		{"UnionInto", UnionInto, sampleInt32Values},
+		{"IntersectInto", IntersectInto, sampleInt32Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleInt32Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range int32SetFactories() {
+			expected := fillInt32Set(newSet(), testCase.expected...)
+
+			dst := fillInt32Set(newSet(), sampleInt32Values[n-1])
+			testCase.into(dst, fillInt32Set(newSet(), aValues...), fillInt32Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillInt32Set(newSet(), aValues...)
+			testCase.into(a, a, fillInt32Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillInt32Set(newSet(), bValues...)
+			testCase.into(b, fillInt32Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range int32SetFactories() {
+		s := fillInt32Set(newSet(), sampleInt32Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchInt32Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleInt32Values[0])
+	Release(s)
+	Release(NewInt32Set(sampleInt32Values[0]))
+
+	if again := NewScratchInt32Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
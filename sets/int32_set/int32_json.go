@@ -0,0 +1,106 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Int32JSONEncodeOptions controls how EncodeJSON writes a set.
+type Int32JSONEncodeOptions struct {
+	// Sorted writes elements in ascending order instead of map order.
+	// Sorting needs a slice holding every element of the set.
+	Sorted bool
+}
+
+// EncodeJSON writes set to writer as a JSON array, one element at a
+// time, so that memory use does not grow with the size of the set
+// unless opts.Sorted is set. Encoding stops with ctx.Err() once ctx is
+// done.
+//
+// A thread-safe set stays read-locked while unsorted output is written.
+func EncodeJSON(ctx context.Context, writer io.Writer, set Int32Set, opts Int32JSONEncodeOptions) error {
+	bw := bufio.NewWriter(writer)
+	if err := bw.WriteByte('['); err != nil {
+		return err
+	}
+
+	first := true
+	var err error
+	write := func(elem int32) bool {
+		if err = ctx.Err(); err != nil {
+			return true
+		}
+		if !first {
+			if err = bw.WriteByte(','); err != nil {
+				return true
+			}
+		}
+		first = false
+
+		var b []byte
+		if b, err = json.Marshal(elem); err != nil {
+			return true
+		}
+		_, err = bw.Write(b)
+		return err != nil
+	}
+
+	if opts.Sorted {
+		elems := set.ToSlice()
+		sortInt32Elements(elems)
+		for _, elem := range elems {
+			if write(elem) {
+				break
+			}
+		}
+	} else {
+		set.Each(write)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := bw.WriteByte(']'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// DecodeJSON reads a JSON array from reader and adds its elements to
+// set as they are decoded, without buffering the whole input. A JSON null adds
+// nothing. Decoding stops with ctx.Err() once ctx is done; elements
+// decoded before an error stay in set.
+func DecodeJSON(ctx context.Context, reader io.Reader, set Int32Set) error {
+	dec := json.NewDecoder(reader)
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("mapsetint32: expected a JSON array, found %v", tok)
+	}
+
+	for dec.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var elem int32
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		set.Add(elem)
+	}
+
+	// Consume the closing bracket.
+	_, err = dec.Token()
+	return err
+}
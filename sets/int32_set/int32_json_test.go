@@ -0,0 +1,49 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Int32Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Int32Set) error { return json.Unmarshal(b, s) }
+	assertInt32RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewInt32Set(sampleInt32Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewInt32Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleInt32Values[i%len(sampleInt32Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewInt32Set(sampleInt32Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleInt32Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetint32
+
+// unsafeInt32Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeInt32Guard struct{}
+
+func trackInt32Set(set *threadUnsafeInt32Set) {}
+
+func (set *threadUnsafeInt32Set) guardRead() unsafeInt32Guard { return unsafeInt32Guard{} }
+
+func (set *threadUnsafeInt32Set) guardWrite() unsafeInt32Guard { return unsafeInt32Guard{} }
+
+func (set *threadUnsafeInt32Set) guardIterate() unsafeInt32Guard { return unsafeInt32Guard{} }
+
+func (unsafeInt32Guard) done() {}
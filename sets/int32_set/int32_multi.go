@@ -0,0 +1,110 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Int32Set) Int32Set {
+	if len(sets) == 0 {
+		return NewInt32Set()
+	}
+	maps, unlock := readLockInt32Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeInt32Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapInt32Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Int32Set) Int32Set {
+	if len(sets) == 0 {
+		return NewInt32Set()
+	}
+	maps, unlock := readLockInt32Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeInt32Set()
+	for elem := range maps[smallest] {
+		if containedInAllInt32(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapInt32Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Int32Set, sets ...Int32Set) Int32Set {
+	maps, unlock := readLockInt32Sets(append([]Int32Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeInt32Set()
+	for elem := range maps[0] {
+		if !containedInAnyInt32(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapInt32Like(base, difference)
+}
+
+func containedInAllInt32(maps []threadUnsafeInt32Set, skip int, elem int32) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyInt32(maps []threadUnsafeInt32Set, elem int32) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapInt32Like returns s as a Set of the same implementation as like.
+func wrapInt32Like(like Int32Set, s threadUnsafeInt32Set) Int32Set {
+	if _, ok := like.(*threadSafeInt32Set); ok {
+		return &threadSafeInt32Set{s: s}
+	}
+	trackInt32Set(&s)
+	return &s
+}
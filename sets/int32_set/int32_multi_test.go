@@ -0,0 +1,42 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import "testing"
+
+func TestMultiSetOperations(t *testing.T) {
+	for name, newSet := range int32SetFactories() {
+		all := fillInt32Set(newSet(), sampleInt32Values...)
+		first := fillInt32Set(newSet(), sampleInt32Values[0])
+		rest := fillInt32Set(newSet(), sampleInt32Values[1:]...)
+
+		var testCases = []struct {
+			op            string
+			got, expected Int32Set
+		}{
+			{"UnionAll", UnionAll(first, rest), all},
+			{"UnionAll of one set", UnionAll(rest), rest},
+			{"IntersectAll", IntersectAll(all, rest, all), rest},
+			{"IntersectAll of disjoint sets", IntersectAll(all, first, rest), newSet()},
+			{"DifferenceAll", DifferenceAll(all, rest), first},
+			{"DifferenceAll of every set", DifferenceAll(all, first, rest), newSet()},
+			{"DifferenceAll of no sets", DifferenceAll(rest), rest},
+		}
+		for _, testCase := range testCases {
+			// Equal also checks that the result shares the implementation
+			// of the inputs.
+			if !testCase.got.Equal(testCase.expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.op, testCase.expected, testCase.got)
+			}
+		}
+		if all.Cardinality() != len(sampleInt32Values) || rest.Cardinality() != len(sampleInt32Values)-1 {
+			t.Errorf("%s: expected the inputs to be left untouched, got %v and %v", name, all, rest)
+		}
+	}
+
+	for op, s := range map[string]Int32Set{"UnionAll": UnionAll(), "IntersectAll": IntersectAll()} {
+		if _, ok := s.(*threadSafeInt32Set); !ok || s.Cardinality() != 0 {
+			t.Errorf("%s: expected an empty thread-safe set with no inputs, got %T %v", op, s, s)
+		}
+	}
+}
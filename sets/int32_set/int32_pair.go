@@ -0,0 +1,224 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Int32Pair represents a 2-tuple of values.
+type Int32Pair struct {
+	First  int32
+	Second int32
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Int32Pair) Equal(other Int32Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Int32Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Int32PairSet is an unordered set of Int32Pairs, as
+// returned by CartesianProduct.
+type Int32PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Int32Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Int32Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Int32Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Int32PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Int32Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Int32Pair
+}
+
+type threadUnsafeInt32PairSet map[Int32Pair]struct{}
+
+func newThreadUnsafeInt32PairSet() threadUnsafeInt32PairSet {
+	return make(threadUnsafeInt32PairSet)
+}
+
+func (set *threadUnsafeInt32PairSet) Add(p Int32Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeInt32PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeInt32PairSet) Contains(p ...Int32Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt32PairSet) Each(cb func(Int32Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeInt32PairSet) Equal(other Int32PairSet) bool {
+	_ = other.(*threadUnsafeInt32PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt32PairSet) Remove(p Int32Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeInt32PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeInt32PairSet) ToSlice() []Int32Pair {
+	keys := make([]Int32Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeInt32PairSet struct {
+	s threadUnsafeInt32PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeInt32PairSet) Add(p Int32Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeInt32PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeInt32PairSet) Contains(p ...Int32Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt32PairSet) Each(cb func(Int32Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockInt32PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockInt32Pair does for sets.
+func rlockInt32PairSets(x, y *threadSafeInt32PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt32PairSets releases the locks taken by rlockInt32PairSets.
+func runlockInt32PairSets(x, y *threadSafeInt32PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeInt32PairSet) Equal(other Int32PairSet) bool {
+	o := other.(*threadSafeInt32PairSet)
+
+	rlockInt32PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockInt32PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeInt32PairSet) Remove(p Int32Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeInt32PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt32PairSet) ToSlice() []Int32Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
 package mapsetint32
 
 // Int32Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Int32Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Int32Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Int32Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() int32
 
-	// Returns all subsets of a given set (Power Int32Set).
-	// Not yet supported
-	// PowerSet() Int32Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Int32Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Int32Set) Int32Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Int32Set) Int32PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []int32
@@ -145,7 +154,7 @@ type Int32Set interface {
 // NewInt32Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewInt32Set(s ...int32) Int32Set {
-	set := newThreadSafeInt32Set()
+	set := threadSafeInt32Set{s: newThreadUnsafeInt32SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewInt32SetFromSlice(s []int32) Int32Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeInt32Set() Int32Set {
 	set := newThreadUnsafeInt32Set()
+	trackInt32Set(&set)
+	return &set
+}
+
+// NewInt32SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewInt32SetWithCapacity(n int) Int32Set {
+	return &threadSafeInt32Set{s: newThreadUnsafeInt32SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeInt32SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeInt32SetWithCapacity(n int) Int32Set {
+	set := newThreadUnsafeInt32SetWithCapacity(n)
+	trackInt32Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeInt32Set() Int32Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeInt32SetFromSlice(s []int32) Int32Set {
-	a := NewThreadUnsafeInt32Set()
+	a := NewThreadUnsafeInt32SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
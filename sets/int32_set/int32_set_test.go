@@ -0,0 +1,105 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleInt32Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleInt32Values = []int32{
+	-128,
+	-1,
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+}
+
+// int32SetFactories returns constructors for both implementations.
+func int32SetFactories() map[string]func() Int32Set {
+	return map[string]func() Int32Set{
+		"safe":   func() Int32Set { return NewInt32Set() },
+		"unsafe": NewThreadUnsafeInt32Set,
+	}
+}
+
+// fillInt32Set adds values to s and returns it.
+func fillInt32Set(s Int32Set, values ...int32) Int32Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertInt32RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertInt32RoundTrip(t *testing.T, marshal func(Int32Set) ([]byte, error), unmarshal func([]byte, Int32Set) error) {
+	t.Helper()
+	contents := [][]int32{sampleInt32Values, sampleInt32Values[:1]}
+	for name, newSet := range int32SetFactories() {
+		for _, values := range contents {
+			s := fillInt32Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range int32SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[int32, Int32Set]{
+				New:   newSet,
+				Elems: sampleInt32Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range int32SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleInt32Values[0])
+		a.Add(sampleInt32Values[1])
+		for _, v := range sampleInt32Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleInt32Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Int32Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
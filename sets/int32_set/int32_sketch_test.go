@@ -0,0 +1,41 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"testing"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewInt32Set(sampleInt32Values...)
+	queries := s.ToSlice()
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewInt32Set(sampleInt32Values...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleInt32Values) {
+		t.Errorf("expected %d elements, got %d", len(sampleInt32Values), f.Len())
+	}
+	for _, v := range sampleInt32Values {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"sort"
+)
+
+// lessInt32 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessInt32(a, b int32) bool {
+	return a < b
+}
+
+// sortInt32Elements sorts elems in place using lessInt32.
+func sortInt32Elements(elems []int32) {
+	sort.Slice(elems, func(i, j int) bool { return lessInt32(elems[i], elems[j]) })
+}
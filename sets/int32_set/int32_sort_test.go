@@ -0,0 +1,26 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]int32, 0, len(sampleInt32Values))
+	for i := len(sampleInt32Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleInt32Values[i])
+	}
+	sortInt32Elements(elems)
+	for i, elem := range elems {
+		if lessInt32(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessInt32(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewInt32Set(elems...).Equal(NewInt32Set(sampleInt32Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleInt32Values, elems)
+	}
+}
@@ -0,0 +1,77 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+func TestSQLRoundTrip(t *testing.T) {
+	for name, newSet := range int32SetFactories() {
+		// Scanning must also drop the stale first sample.
+		s := fillInt32Set(newSet(), sampleInt32Values[1:]...)
+		for formatName, format := range map[string]Int32SQLFormat{"array": Int32SQLArray, "JSON": Int32SQLJSON} {
+			v, err := NewInt32SQLValue(s, format).Value()
+			if err != nil {
+				t.Errorf("%s %s: value of %v: %v", name, formatName, s, err)
+				continue
+			}
+			decoded := fillInt32Set(newSet(), sampleInt32Values[0])
+			if err := NewInt32SQLValue(decoded, format).Scan(v); err != nil {
+				t.Errorf("%s %s: scan %q: %v", name, formatName, v, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s %s: expected %v after a round trip of %q, got %v", name, formatName, s, v, decoded)
+			}
+		}
+
+		// Sets are valuers and scanners themselves, storing arrays.
+		v, err := s.(driver.Valuer).Value()
+		if err != nil {
+			t.Errorf("%s: value of %v: %v", name, s, err)
+			continue
+		}
+		decoded := fillInt32Set(newSet(), sampleInt32Values[0])
+		if err := decoded.(sql.Scanner).Scan([]byte(v.(string))); err != nil {
+			t.Errorf("%s: scan %q: %v", name, v, err)
+			continue
+		}
+		if !decoded.Equal(s) {
+			t.Errorf("%s: expected %v after a round trip of %q, got %v", name, s, v, decoded)
+		}
+	}
+}
+
+func TestSQLNull(t *testing.T) {
+	if v, err := NewInt32SQLValue(nil, Int32SQLArray).Value(); v != nil || err != nil {
+		t.Errorf("expected a nil set to store NULL, got %v, %v", v, err)
+	}
+	for name, newSet := range int32SetFactories() {
+		s := fillInt32Set(newSet(), sampleInt32Values...)
+		if err := s.(sql.Scanner).Scan(nil); err != nil || s.Cardinality() != 0 {
+			t.Errorf("%s: expected NULL to scan as an empty set, got %v, %v", name, s, err)
+		}
+	}
+}
+
+func TestSQLScanMalformed(t *testing.T) {
+	for name, newSet := range int32SetFactories() {
+		for _, src := range []interface{}{`{{1,2},{3,4}}`, `{"a}`, `{a,,b}`, `{NULL}`, `[`, `1,2`, 42} {
+			s := fillInt32Set(newSet(), sampleInt32Values[0])
+			err := s.(sql.Scanner).Scan(src)
+			if err == nil {
+				t.Errorf("%s: expected an error scanning %v", name, src)
+			}
+			if src == `{{1,2},{3,4}}` && !errors.Is(err, errSQLMultidimensional) {
+				t.Errorf("%s: expected errSQLMultidimensional, got %v", name, err)
+			}
+			if s.Cardinality() != 1 || !s.Contains(sampleInt32Values[0]) {
+				t.Errorf("%s: expected the set to be left untouched scanning %v, got %v", name, src, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+)
+
+// Int32SetStats counts the operations on a thread-safe set and the time they
+// spend waiting for its lock. It is collected only after EnableStats, so
+// sets without statistics pay a single pointer load per operation. Only
+// the operations named in Int32StatsSnapshot are counted; the others, such as
+// Cardinality, Iter or the encodings, are not.
+//
+// A *Int32SetStats is an expvar.Var and can be published with expvar.Publish;
+// Snapshot returns plain values for exporting elsewhere, for example as
+// runtime/metrics-style gauges.
+type Int32SetStats struct {
+	adds           atomic.Uint64
+	removes        atomic.Uint64
+	hits           atomic.Uint64
+	misses         atomic.Uint64
+	reads          atomic.Uint64
+	lockWaits      atomic.Uint64
+	lockWaitNanos  atomic.Int64
+	maxLockWait    atomic.Int64
+	maxCardinality atomic.Int64
+}
+
+// Int32StatsSnapshot holds the statistics of a set at one point in time.
+type Int32StatsSnapshot struct {
+	// Adds counts the elements added, not counting ones already present.
+	Adds uint64 `json:"adds"`
+	// Removes counts the elements removed by Remove, Pop and Clear.
+	Removes uint64 `json:"removes"`
+	// Hits and Misses count the Contains calls returning true and false.
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// Reads counts the calls to Each, Union, Intersect, Difference and
+	// SymmetricDifference, once for each set they read.
+	Reads uint64 `json:"reads"`
+	// LockWaits counts the lock acquisitions of Add, Remove, Pop, Clear,
+	// Contains and Each and their Context forms, and LockWait is the
+	// total time they spent waiting.
+	LockWaits   uint64        `json:"lock_waits"`
+	LockWait    time.Duration `json:"lock_wait_ns"`
+	MaxLockWait time.Duration `json:"max_lock_wait_ns"`
+	// MaxCardinality is the size of the set when the statistics were
+	// enabled, or the largest size an Add has since taken it to.
+	MaxCardinality int `json:"max_cardinality"`
+}
+
+// EnableStats starts collecting statistics for s and returns them. If s
+// already collects statistics, the existing Int32SetStats is returned. s must
+// be a thread-safe set, otherwise EnableStats will panic.
+func EnableStats(s Int32Set) *Int32SetStats {
+	set := s.(*threadSafeInt32Set)
+	st := &Int32SetStats{}
+	st.maxCardinality.Store(int64(set.Cardinality()))
+	if !set.stats.CompareAndSwap(nil, st) {
+		return set.stats.Load()
+	}
+	return st
+}
+
+// DisableStats stops collecting statistics for s. s must be a
+// thread-safe set, otherwise DisableStats will panic.
+func DisableStats(s Int32Set) {
+	s.(*threadSafeInt32Set).stats.Store(nil)
+}
+
+// Snapshot returns the current values of the statistics.
+func (st *Int32SetStats) Snapshot() Int32StatsSnapshot {
+	return Int32StatsSnapshot{
+		Adds:           st.adds.Load(),
+		Removes:        st.removes.Load(),
+		Hits:           st.hits.Load(),
+		Misses:         st.misses.Load(),
+		Reads:          st.reads.Load(),
+		LockWaits:      st.lockWaits.Load(),
+		LockWait:       time.Duration(st.lockWaitNanos.Load()),
+		MaxLockWait:    time.Duration(st.maxLockWait.Load()),
+		MaxCardinality: int(st.maxCardinality.Load()),
+	}
+}
+
+// Reset sets every counter back to zero.
+func (st *Int32SetStats) Reset() {
+	st.adds.Store(0)
+	st.removes.Store(0)
+	st.hits.Store(0)
+	st.misses.Store(0)
+	st.reads.Store(0)
+	st.lockWaits.Store(0)
+	st.lockWaitNanos.Store(0)
+	st.maxLockWait.Store(0)
+	st.maxCardinality.Store(0)
+}
+
+// String returns the snapshot as a JSON object, as expvar expects.
+func (st *Int32SetStats) String() string {
+	b, _ := json.Marshal(st.Snapshot())
+	return string(b)
+}
+
+// The recording methods below accept a nil receiver, so that sets
+// without statistics can call them unconditionally.
+
+func (st *Int32SetStats) now() time.Time {
+	if st == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (st *Int32SetStats) waited(start time.Time) {
+	if st == nil {
+		return
+	}
+	d := int64(time.Since(start))
+	st.lockWaits.Add(1)
+	st.lockWaitNanos.Add(d)
+	storeInt32Max(&st.maxLockWait, d)
+}
+
+func (st *Int32SetStats) added(ok bool, n int) {
+	if st == nil || !ok {
+		return
+	}
+	st.adds.Add(1)
+	storeInt32Max(&st.maxCardinality, int64(n))
+}
+
+func (st *Int32SetStats) removed(ok bool) {
+	if st == nil || !ok {
+		return
+	}
+	st.removes.Add(1)
+}
+
+func (st *Int32SetStats) cleared(n int) {
+	if st == nil {
+		return
+	}
+	st.removes.Add(uint64(n))
+}
+
+func (st *Int32SetStats) looked(found bool) {
+	if st == nil {
+		return
+	}
+	if found {
+		st.hits.Add(1)
+	} else {
+		st.misses.Add(1)
+	}
+}
+
+func (st *Int32SetStats) read() {
+	if st == nil {
+		return
+	}
+	st.reads.Add(1)
+}
+
+// readInt32Pair records a read of x and y, once if they are the same set.
+func readInt32Pair(x, y *threadSafeInt32Set) {
+	x.stats.Load().read()
+	if y != x {
+		y.stats.Load().read()
+	}
+}
+
+// storeInt32Max raises v to n if n is larger.
+func storeInt32Max(v *atomic.Int64, n int64) {
+	for {
+		old := v.Load()
+		if n <= old || v.CompareAndSwap(old, n) {
+			return
+		}
+	}
+}
+
+// lock takes the write lock, timing the wait if statistics are enabled,
+// and returns the statistics to record into.
+func (set *threadSafeInt32Set) lock() *Int32SetStats {
+	st := set.stats.Load()
+	start := st.now()
+	set.Lock()
+	st.waited(start)
+	return st
+}
+
+// rlock is lock for the read lock.
+func (set *threadSafeInt32Set) rlock() *Int32SetStats {
+	st := set.stats.Load()
+	start := st.now()
+	set.RLock()
+	st.waited(start)
+	return st
+}
@@ -0,0 +1,269 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Int32TextFormat describes how a set is written to and read from a
+// single line of text, such as a command line flag or an environment
+// variable.
+//
+// Elements are joined by Separator. Occurrences of the separator, of a
+// double quote or of a backslash inside an element are escaped with a
+// backslash, and an empty element is written as "" so that a set of
+// just the empty element is not read back as an empty set.
+type Int32TextFormat struct {
+	// Separator delimits elements. Defaults to ",".
+	Separator string
+
+	// Quote writes string elements using strconv.Quote.
+	// It has no effect on int32 elements.
+	Quote bool
+}
+
+// DefaultInt32TextFormat is the format used by MarshalText,
+// UnmarshalText and NewInt32FlagValue.
+var DefaultInt32TextFormat = Int32TextFormat{Separator: ","}
+
+var errTextEscape = errors.New("mapsetint32: text ends with an unfinished escape")
+var errTextQuote = errors.New("mapsetint32: text has an unterminated quote")
+
+// Marshal renders the elements of s as text, in sorted order.
+func (format Int32TextFormat) Marshal(s Int32Set) ([]byte, error) {
+	elems := s.ToSlice()
+	sortInt32Elements(elems)
+
+	items := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		item, err := formatInt32Text(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, format.escape(item))
+	}
+	return []byte(strings.Join(items, format.separator())), nil
+}
+
+// Unmarshal adds the elements read from text to s. Nothing is added if
+// any element fails to parse. Empty text holds no elements.
+func (format Int32TextFormat) Unmarshal(text []byte, s Int32Set) error {
+	elems, err := format.parse(string(text))
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func (format Int32TextFormat) separator() string {
+	if format.Separator == "" {
+		return ","
+	}
+	return format.Separator
+}
+
+func (format Int32TextFormat) parse(text string) ([]int32, error) {
+	fields, err := format.split(text)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]int32, 0, len(fields))
+	for _, field := range fields {
+		item, err := format.unescape(field)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := parseInt32Text(item)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// split cuts text at every separator that is neither escaped nor, when
+// quoting, inside quotes. Escapes and quotes are kept in the fields.
+func (format Int32TextFormat) split(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	sep := format.separator()
+	var fields []string
+	var field strings.Builder
+	inQuote := false
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\':
+			if i+1 == len(text) {
+				return nil, errTextEscape
+			}
+			field.WriteString(text[i : i+2])
+			i += 2
+			continue
+		case format.Quote && c == '"':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(text[i:], sep):
+			fields = append(fields, field.String())
+			field.Reset()
+			i += len(sep)
+			continue
+		}
+		field.WriteByte(text[i])
+		i++
+	}
+	if inQuote {
+		return nil, errTextQuote
+	}
+	return append(fields, field.String()), nil
+}
+
+func (format Int32TextFormat) escape(item string) string {
+	if item == "" {
+		return `""`
+	}
+	sep := format.separator()
+	if !strings.ContainsAny(item, `\"`) && !strings.Contains(item, sep) {
+		return item
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(item); {
+		if strings.HasPrefix(item[i:], sep) {
+			for j := 0; j < len(sep); j++ {
+				b.WriteByte('\\')
+				b.WriteByte(sep[j])
+			}
+			i += len(sep)
+			continue
+		}
+		if c := item[i]; c == '\\' || c == '"' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(item[i])
+		i++
+	}
+	return b.String()
+}
+
+func (format Int32TextFormat) unescape(field string) (string, error) {
+	if format.Quote {
+		if quoted := strings.TrimSpace(field); strings.HasPrefix(quoted, `"`) {
+			return strconv.Unquote(quoted)
+		}
+	}
+	if field == `""` {
+		return "", nil
+	}
+	if !strings.Contains(field, `\`) {
+		return field, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' {
+			i++
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String(), nil
+}
+
+// formatInt32Text renders a single element as text.
+func formatInt32Text(elem int32) (string, error) {
+	return strconv.FormatInt(int64(elem), 10), nil
+}
+
+// parseInt32Text parses a single element rendered by
+// formatInt32Text. Surrounding white space is ignored.
+func parseInt32Text(item string) (int32, error) {
+	i, err := strconv.ParseInt(strings.TrimSpace(item), 10, 32)
+	return int32(i), err
+}
+
+// Int32FlagValue adapts a Int32Set to the flag.Value interface.
+// Every occurrence of the flag adds its elements to the set, so both
+// -flag=a,b and -flag=a -flag=b collect the same elements.
+type Int32FlagValue struct {
+	set    Int32Set
+	Format Int32TextFormat
+}
+
+// NewInt32FlagValue returns a flag.Value that adds elements to s using
+// DefaultInt32TextFormat.
+func NewInt32FlagValue(s Int32Set) *Int32FlagValue {
+	return &Int32FlagValue{set: s, Format: DefaultInt32TextFormat}
+}
+
+// String renders the set as text, as required by flag.Value.
+func (v *Int32FlagValue) String() string {
+	if v == nil || v.set == nil {
+		return ""
+	}
+	b, err := v.Format.Marshal(v.set)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set adds the elements read from text to the set, as required by
+// flag.Value.
+func (v *Int32FlagValue) Set(text string) error {
+	return v.Format.Unmarshal([]byte(text), v.set)
+}
+
+// MarshalText renders the set using DefaultInt32TextFormat.
+func (set *threadUnsafeInt32Set) MarshalText() ([]byte, error) {
+	return DefaultInt32TextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultInt32TextFormat. The set is left untouched
+// on error.
+func (set *threadUnsafeInt32Set) UnmarshalText(text []byte) error {
+	elems, err := DefaultInt32TextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeInt32Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalText renders the set using DefaultInt32TextFormat.
+func (set *threadSafeInt32Set) MarshalText() ([]byte, error) {
+	return DefaultInt32TextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultInt32TextFormat. The set is left untouched
+// on error.
+func (set *threadSafeInt32Set) UnmarshalText(text []byte) error {
+	elems, err := DefaultInt32TextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeInt32Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
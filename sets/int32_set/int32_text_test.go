@@ -0,0 +1,14 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Int32Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Int32Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertInt32RoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
 package mapsetint32
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeInt32Set struct {
-	s threadUnsafeInt32Set
+	s     threadUnsafeInt32Set
+	stats atomic.Pointer[Int32SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeInt32Set() threadSafeInt32Set {
 	return threadSafeInt32Set{s: newThreadUnsafeInt32Set()}
 }
 
+// readLockInt32Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockInt32Sets(sets []Int32Set) ([]threadUnsafeInt32Set, func()) {
+	maps := make([]threadUnsafeInt32Set, len(sets))
+	if _, ok := sets[0].(*threadSafeInt32Set); !ok {
+		guards := make([]unsafeInt32Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeInt32Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeInt32Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeInt32Set))
+	}
+	distinct := lockInt32Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeInt32Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockInt32Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockInt32Order(sets []*threadSafeInt32Set) []*threadSafeInt32Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockInt32Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockInt32Pair(x, y *threadSafeInt32Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt32Pair releases the locks taken by rlockInt32Pair.
+func runlockInt32Pair(x, y *threadSafeInt32Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeInt32Set) Add(i int32) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeInt32Set) Contains(i ...int32) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeInt32Set) IsSubset(other Int32Set) bool {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt32Set) IsProperSubset(other Int32Set) bool {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockInt32Pair(set, o)
+	defer runlockInt32Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeInt32Set) IsProperSuperset(other Int32Set) bool {
 func (set *threadSafeInt32Set) Union(other Int32Set) Int32Set {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
+	readInt32Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeInt32Set)
 	ret := &threadSafeInt32Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt32Set) Intersect(other Int32Set) Int32Set {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
+	readInt32Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeInt32Set)
 	ret := &threadSafeInt32Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt32Set) Difference(other Int32Set) Int32Set {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
+	readInt32Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeInt32Set)
 	ret := &threadSafeInt32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt32Set) SymmetricDifference(other Int32Set) Int32Set {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
+	readInt32Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeInt32Set)
 	ret := &threadSafeInt32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt32Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeInt32Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeInt32Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeInt32Set) Remove(i int32) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyInt32(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeInt32Set) Cardinality() int {
 }
 
 func (set *threadSafeInt32Set) Each(cb func(int32) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeInt32Set) Iterator() *Int32Iterator {
 func (set *threadSafeInt32Set) Equal(other Int32Set) bool {
 	o := other.(*threadSafeInt32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt32Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt32Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeInt32Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt32Set) PowerSet() Int32Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeInt32Set)
-    set.RUnlock()
+func (set *threadSafeInt32Set) PowerSet() []Int32Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeInt32Set{s: newThreadUnsafeInt32Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeInt32Set)
-        ret.Add(&threadSafeInt32Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Int32Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeInt32Set)
+		ret = append(ret, &threadSafeInt32Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeInt32Set) Pop() int32 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt32Set) CartesianProduct(other Int32Set) Int32Set {
-    o := other.(*threadSafeInt32Set)
+func (set *threadSafeInt32Set) CartesianProduct(other Int32Set) Int32PairSet {
+	o := other.(*threadSafeInt32Set)
 
-    set.RLock()
-    o.RLock()
+	rlockInt32Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt32Set)
-    ret := &threadSafeInt32Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt32PairSet)
+	ret := &threadSafeInt32PairSet{s: *unsafeCartProduct}
+	runlockInt32Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeInt32Set) ToSlice() []int32 {
-	keys := make([]int32, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]int32, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeInt32Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeInt32Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
 package mapsetint32
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeInt32Set map[int32]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  int32
-	Second int32
-}
-
 func newThreadUnsafeInt32Set() threadUnsafeInt32Set {
 	return make(threadUnsafeInt32Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeInt32SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeInt32SetWithCapacity(n int) threadUnsafeInt32Set {
+	return make(threadUnsafeInt32Set, n)
+}
 
-	return false
+// keyInt32 returns the map key of i.
+func keyInt32(i int32) int32 {
+	return i
 }
 
 func (set *threadUnsafeInt32Set) Add(i int32) bool {
+	defer set.guardWrite().done()
+	i = keyInt32(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeInt32Set) Add(i int32) bool {
 }
 
 func (set *threadUnsafeInt32Set) Contains(i ...int32) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyInt32(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeInt32Set) Contains(i ...int32) bool {
 
 func (set *threadUnsafeInt32Set) IsSubset(other Int32Set) bool {
 	_ = other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeInt32Set) IsSubset(other Int32Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeInt32Set) IsProperSubset(other Int32Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeInt32Set) IsSuperset(other Int32Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeInt32Set) IsSuperset(other Int32Set) bool {
 }
 
 func (set *threadUnsafeInt32Set) IsProperSuperset(other Int32Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeInt32Set) Union(other Int32Set) Int32Set {
 	o := other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeInt32Set()
+	unionedSet := newThreadUnsafeInt32SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeInt32Set) Union(other Int32Set) Int32Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackInt32Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeInt32Set) Intersect(other Int32Set) Int32Set {
 	o := other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeInt32Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeInt32Set) Intersect(other Int32Set) Int32Set {
 			}
 		}
 	}
+	trackInt32Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeInt32Set) Difference(other Int32Set) Int32Set {
 	_ = other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeInt32Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeInt32Set) Difference(other Int32Set) Int32Set {
 			difference.Add(elem)
 		}
 	}
+	trackInt32Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeInt32Set) SymmetricDifference(other Int32Set) Int32Set {
-	_ = other.(*threadUnsafeInt32Set)
+	o := other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeInt32Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackInt32Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeInt32Set) Clear() {
-	*set = newThreadUnsafeInt32Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeInt32Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeInt32SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeInt32Set) Remove(i int32) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyInt32(i))
 }
 
 func (set *threadUnsafeInt32Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeInt32Set) Each(cb func(int32) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeInt32Set) Each(cb func(int32) bool) {
 func (set *threadUnsafeInt32Set) Iter() <-chan int32 {
 	ch := make(chan int32)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeInt32Set) Iterator() *Int32Iterator {
 	iterator, ch, stopCh := newInt32Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeInt32Set) Iterator() *Int32Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeInt32Set) Iterator() *Int32Iterator {
 
 func (set *threadUnsafeInt32Set) Equal(other Int32Set) bool {
 	_ = other.(*threadUnsafeInt32Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeInt32Set) Equal(other Int32Set) bool {
 }
 
 func (set *threadUnsafeInt32Set) Clone() Int32Set {
-	clonedSet := newThreadUnsafeInt32Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeInt32SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackInt32Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeInt32Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeInt32Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeInt32Set) Pop() int32 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeInt32Set) Pop() int32 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt32Set) PowerSet() Int32Set {
-	powSet := NewThreadUnsafeInt32Set()
+func (set *threadUnsafeInt32Set) PowerSet() []Int32Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeInt32Set()
-	powSet.Add(&nullset)
+	powSet := []Int32Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeInt32Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeInt32Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeInt32Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt32Set) CartesianProduct(other Int32Set) Int32Set {
+func (set *threadUnsafeInt32Set) CartesianProduct(other Int32Set) Int32PairSet {
 	o := other.(*threadUnsafeInt32Set)
-	cartProduct := NewThreadUnsafeInt32Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeInt32PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Int32Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeInt32Set) ToSlice() []int32 {
+	defer set.guardRead().done()
 	keys := make([]int32, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeInt32Set) ToSlice() []int32 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeInt32Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Int32JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeInt32Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeInt32Set) UnmarshalJSON(data []byte) error {
 	var i []int32
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
@@ -0,0 +1,92 @@
+// Code generated by generate_set for int32. DO NOT EDIT.
+
+package mapsetint32
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestXMLRoundTrip(t *testing.T) {
+	marshal := func(s Int32Set) ([]byte, error) { return xml.Marshal(s) }
+	unmarshal := func(b []byte, s Int32Set) error { return xml.Unmarshal(b, s) }
+	assertInt32RoundTrip(t, marshal, unmarshal)
+
+	for name, newSet := range int32SetFactories() {
+		s := newSet()
+		for _, v := range sampleInt32Values {
+			s.Add(v)
+		}
+
+		b, err := xml.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got := strings.Count(string(b), "<"+DefaultInt32XMLItemName+">"); got != len(sampleInt32Values) {
+			t.Errorf("%s: expected %d item elements in %s, got %d", name, len(sampleInt32Values), b, got)
+		}
+
+		decoded := newSet()
+		decoded.Add(sampleInt32Values[0])
+		if err := xml.Unmarshal(b, decoded); err != nil {
+			t.Errorf("%s: unmarshal %s: %v", name, b, err)
+			continue
+		}
+		if !decoded.Equal(s) {
+			t.Errorf("%s: expected %v after a round trip of %s, got %v", name, s, b, decoded)
+		}
+	}
+}
+
+func TestXMLItemName(t *testing.T) {
+	type config struct {
+		XMLName xml.Name `xml:"config"`
+		Allow   Int32XML `xml:"allow"`
+	}
+
+	in := config{Allow: Int32XML{Set: NewInt32Set(sampleInt32Values...), ItemName: "host"}}
+	b, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "<allow><host>") {
+		t.Errorf("expected items named host in %s", b)
+	}
+
+	// Children with other names are skipped, and elements are added to
+	// the set already present.
+	text := strings.Replace(string(b), "<allow>", "<allow><comment>skipped</comment>", 1)
+	out := config{Allow: Int32XML{Set: NewInt32Set(), ItemName: "host"}}
+	if err := xml.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Allow.Set.Equal(in.Allow.Set) {
+		t.Errorf("expected %v from %s, got %v", in.Allow.Set, text, out.Allow.Set)
+	}
+}
+
+func TestUnmarshalXMLMalformed(t *testing.T) {
+	valid, err := formatInt32Text(sampleInt32Values[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []string{
+		"<set><item>" + valid + "</item>",
+		"<set><item>" + valid + "</set>",
+		"<set><item>" + valid + "</item><item>not a value</item></set>",
+	}
+
+	for name, newSet := range int32SetFactories() {
+		for _, input := range inputs {
+			s := newSet()
+			s.Add(sampleInt32Values[1])
+			if err := xml.Unmarshal([]byte(input), s); err == nil {
+				t.Errorf("%s: expected an error unmarshaling %q", name, input)
+			}
+			if s.Cardinality() != 1 || !s.Contains(sampleInt32Values[1]) {
+				t.Errorf("%s: expected the set to be left untouched on error, got %v", name, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Int64Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleInt64Values[i%len(sampleInt64Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewInt64Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeInt64Set())
+}
+
+func benchContains(b *testing.B, s Int64Set) {
+	for _, v := range sampleInt64Values[:len(sampleInt64Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleInt64Values[i%len(sampleInt64Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewInt64Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeInt64Set())
+}
+
+func benchUnion(b *testing.B, x, y Int64Set) {
+	half := len(sampleInt64Values) / 2
+	for _, v := range sampleInt64Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleInt64Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewInt64Set(), NewInt64Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeInt64Set(), NewThreadUnsafeInt64Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Int64Set) {
+	for _, v := range sampleInt64Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewInt64Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeInt64Set())
+}
@@ -0,0 +1,149 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryFormatVersion is the first byte of every binary encoding.
+// Encodings in this package start with a version byte that decoders
+// check, so any change to a format must bump its version.
+const binaryFormatVersion byte = 1
+
+var errBinaryTruncated = errors.New("mapsetint64: truncated binary data")
+
+// appendInt64Element appends the length-prefixed binary encoding
+// of elem to b.
+func appendInt64Element(b []byte, elem int64) ([]byte, error) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], int64(elem))
+	b = binary.AppendUvarint(b, uint64(n))
+	return append(b, buf[:n]...), nil
+}
+
+// decodeInt64Element decodes a single element payload written by
+// appendInt64Element, without its length prefix.
+func decodeInt64Element(payload []byte) (int64, error) {
+	x, n := binary.Varint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, fmt.Errorf("mapsetint64: invalid varint payload %v", payload)
+	}
+	elem := int64(x)
+	if int64(elem) != x {
+		return 0, fmt.Errorf("mapsetint64: value %v overflows int64", x)
+	}
+	return elem, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadUnsafeInt64Set) MarshalBinary() ([]byte, error) {
+	defer set.guardRead().done()
+	b := []byte{binaryFormatVersion}
+	b = binary.AppendUvarint(b, uint64(len(*set)))
+
+	var err error
+	for elem := range *set {
+		b, err = appendInt64Element(b, elem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadUnsafeInt64Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalInt64Binary(data)
+	if err != nil {
+		return err
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadUnsafeInt64Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadUnsafeInt64Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+func unmarshalInt64Binary(data []byte) (threadUnsafeInt64Set, error) {
+	if len(data) == 0 {
+		return nil, errBinaryTruncated
+	}
+	if data[0] != binaryFormatVersion {
+		return nil, fmt.Errorf("mapsetint64: unsupported binary format version %d", data[0])
+	}
+	data = data[1:]
+
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, errBinaryTruncated
+	}
+	data = data[n:]
+
+	set := newThreadUnsafeInt64Set()
+	for i := uint64(0); i < count; i++ {
+		size, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < size {
+			return nil, errBinaryTruncated
+		}
+		data = data[n:]
+
+		elem, err := decodeInt64Element(data[:size])
+		if err != nil {
+			return nil, err
+		}
+		set.Add(elem)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("mapsetint64: %d bytes of trailing binary data", len(data))
+	}
+	return set, nil
+}
+
+// MarshalBinary encodes the set as a version byte, the number of
+// elements and then each length-prefixed element.
+func (set *threadSafeInt64Set) MarshalBinary() ([]byte, error) {
+	set.RLock()
+	b, err := set.s.MarshalBinary()
+	set.RUnlock()
+
+	return b, err
+}
+
+// UnmarshalBinary replaces the contents of the set with the elements
+// encoded by MarshalBinary. The set is left untouched on error.
+func (set *threadSafeInt64Set) UnmarshalBinary(data []byte) error {
+	decoded, err := unmarshalInt64Binary(data)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = decoded
+	set.Unlock()
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (set *threadSafeInt64Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the binary encoding.
+func (set *threadSafeInt64Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
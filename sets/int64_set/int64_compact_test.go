@@ -0,0 +1,64 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// compactFuzzSet builds a set from data, reading one element per eight
+// bytes so that the fuzzer can reach the full range of the type.
+func compactFuzzSet(data []byte) Int64Set {
+	s := NewThreadUnsafeInt64Set()
+	for ; len(data) >= 8; data = data[8:] {
+		s.Add(int64(binary.LittleEndian.Uint64(data)))
+	}
+	return s
+}
+
+func FuzzCompactRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0, 0})
+	f.Add([]byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0, 0x80, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := compactFuzzSet(data)
+
+		encoded := AppendCompact(nil, s)
+		decoded := NewThreadUnsafeInt64Set()
+		if err := DecodeCompact(encoded, decoded); err != nil {
+			t.Fatalf("DecodeCompact(%v): %v", encoded, err)
+		}
+		if !s.Equal(decoded) {
+			t.Fatalf("round trip mismatch: %v != %v", s, decoded)
+		}
+
+		var buf bytes.Buffer
+		if err := WriteCompact(&buf, s); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), encoded) {
+			t.Fatalf("WriteCompact wrote %v, AppendCompact %v", buf.Bytes(), encoded)
+		}
+		streamed := NewThreadUnsafeInt64Set()
+		if err := ReadCompact(&buf, streamed); err != nil {
+			t.Fatal(err)
+		}
+		if !s.Equal(streamed) {
+			t.Fatalf("streamed round trip mismatch: %v != %v", s, streamed)
+		}
+
+		// Arbitrary input must either be rejected cleanly or decode into
+		// a set that survives another round trip.
+		arbitrary := NewThreadUnsafeInt64Set()
+		if err := DecodeCompact(data, arbitrary); err == nil {
+			again := NewThreadUnsafeInt64Set()
+			if err := DecodeCompact(AppendCompact(nil, arbitrary), again); err != nil || !arbitrary.Equal(again) {
+				t.Fatalf("re-encoding %v failed: %v, %v", arbitrary, again, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeInt64Set()
+		return &s
+	},
+}
+
+// NewScratchInt64Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchInt64Set() Int64Set {
+	return scratchPool.Get().(*threadUnsafeInt64Set)
+}
+
+// Release empties s and makes it available to NewScratchInt64Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Int64Set) {
+	u, ok := s.(*threadUnsafeInt64Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Int64Set) {
+	d, x, y, locks := lockInt64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllInt64(d, y)
+	case dst == b:
+		addAllInt64(d, x)
+	default:
+		clear(d)
+		addAllInt64(d, x)
+		addAllInt64(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Int64Set) {
+	d, x, y, locks := lockInt64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyInt64(d, y)
+	case dst == b:
+		keepOnlyInt64(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Int64Set) {
+	d, x, y, locks := lockInt64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchInt64Set().(*threadUnsafeInt64Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllInt64(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllInt64(dst, src threadUnsafeInt64Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyInt64 removes the elements of dst that are not in other.
+func keepOnlyInt64(dst, other threadUnsafeInt64Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoInt64Locks records the locks taken by lockInt64Into, or the guards for
+// thread-unsafe sets.
+type intoInt64Locks struct {
+	sets     [3]*threadSafeInt64Set
+	distinct int
+	dst      *threadSafeInt64Set
+	guards   [3]unsafeInt64Guard
+}
+
+// lockInt64Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockInt64Into(dst, a, b Int64Set) (d, x, y threadUnsafeInt64Set, locks intoInt64Locks) {
+	w, ok := dst.(*threadSafeInt64Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeInt64Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeInt64Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeInt64Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeInt64Set), *b.(*threadUnsafeInt64Set), locks
+	}
+
+	locks.sets = [3]*threadSafeInt64Set{w, a.(*threadSafeInt64Set), b.(*threadSafeInt64Set)}
+	locks.distinct = len(lockInt64Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeInt64Set).s, b.(*threadSafeInt64Set).s, locks
+}
+
+func (l intoInt64Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
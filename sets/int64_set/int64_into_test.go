@@ -0,0 +1,74 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleInt64Values)
+	aValues, bValues := sampleInt64Values[:n-1], sampleInt64Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Int64Set)
+		expected []int64
+	}{
+		{"UnionInto", UnionInto, sampleInt64Values},
+		{"IntersectInto", IntersectInto, sampleInt64Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleInt64Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range int64SetFactories() {
+			expected := fillInt64Set(newSet(), testCase.expected...)
+
+			dst := fillInt64Set(newSet(), sampleInt64Values[n-1])
+			testCase.into(dst, fillInt64Set(newSet(), aValues...), fillInt64Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillInt64Set(newSet(), aValues...)
+			testCase.into(a, a, fillInt64Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillInt64Set(newSet(), bValues...)
+			testCase.into(b, fillInt64Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range int64SetFactories() {
+		s := fillInt64Set(newSet(), sampleInt64Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchInt64Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleInt64Values[0])
+	Release(s)
+	Release(NewInt64Set(sampleInt64Values[0]))
+
+	if again := NewScratchInt64Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Int64Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Int64Set) error { return json.Unmarshal(b, s) }
+	assertInt64RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewInt64Set(sampleInt64Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewInt64Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleInt64Values[i%len(sampleInt64Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewInt64Set(sampleInt64Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleInt64Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetint64
+
+// unsafeInt64Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeInt64Guard struct{}
+
+func trackInt64Set(set *threadUnsafeInt64Set) {}
+
+func (set *threadUnsafeInt64Set) guardRead() unsafeInt64Guard { return unsafeInt64Guard{} }
+
+func (set *threadUnsafeInt64Set) guardWrite() unsafeInt64Guard { return unsafeInt64Guard{} }
+
+func (set *threadUnsafeInt64Set) guardIterate() unsafeInt64Guard { return unsafeInt64Guard{} }
+
+func (unsafeInt64Guard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Int64Set) Int64Set {
+	if len(sets) == 0 {
+		return NewInt64Set()
+	}
+	maps, unlock := readLockInt64Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeInt64Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapInt64Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Int64Set) Int64Set {
+	if len(sets) == 0 {
+		return NewInt64Set()
+	}
+	maps, unlock := readLockInt64Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeInt64Set()
+	for elem := range maps[smallest] {
+		if containedInAllInt64(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapInt64Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Int64Set, sets ...Int64Set) Int64Set {
+	maps, unlock := readLockInt64Sets(append([]Int64Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeInt64Set()
+	for elem := range maps[0] {
+		if !containedInAnyInt64(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapInt64Like(base, difference)
+}
+
+func containedInAllInt64(maps []threadUnsafeInt64Set, skip int, elem int64) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyInt64(maps []threadUnsafeInt64Set, elem int64) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapInt64Like returns s as a Set of the same implementation as like.
+func wrapInt64Like(like Int64Set, s threadUnsafeInt64Set) Int64Set {
+	if _, ok := like.(*threadSafeInt64Set); ok {
+		return &threadSafeInt64Set{s: s}
+	}
+	trackInt64Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Int64Pair represents a 2-tuple of values.
+type Int64Pair struct {
+	First  int64
+	Second int64
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Int64Pair) Equal(other Int64Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Int64Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Int64PairSet is an unordered set of Int64Pairs, as
+// returned by CartesianProduct.
+type Int64PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Int64Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Int64Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Int64Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Int64PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Int64Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Int64Pair
+}
+
+type threadUnsafeInt64PairSet map[Int64Pair]struct{}
+
+func newThreadUnsafeInt64PairSet() threadUnsafeInt64PairSet {
+	return make(threadUnsafeInt64PairSet)
+}
+
+func (set *threadUnsafeInt64PairSet) Add(p Int64Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeInt64PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeInt64PairSet) Contains(p ...Int64Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt64PairSet) Each(cb func(Int64Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeInt64PairSet) Equal(other Int64PairSet) bool {
+	_ = other.(*threadUnsafeInt64PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt64PairSet) Remove(p Int64Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeInt64PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeInt64PairSet) ToSlice() []Int64Pair {
+	keys := make([]Int64Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeInt64PairSet struct {
+	s threadUnsafeInt64PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeInt64PairSet) Add(p Int64Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeInt64PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeInt64PairSet) Contains(p ...Int64Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt64PairSet) Each(cb func(Int64Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockInt64PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockInt64Pair does for sets.
+func rlockInt64PairSets(x, y *threadSafeInt64PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt64PairSets releases the locks taken by rlockInt64PairSets.
+func runlockInt64PairSets(x, y *threadSafeInt64PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeInt64PairSet) Equal(other Int64PairSet) bool {
+	o := other.(*threadSafeInt64PairSet)
+
+	rlockInt64PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockInt64PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeInt64PairSet) Remove(p Int64Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeInt64PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt64PairSet) ToSlice() []Int64Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
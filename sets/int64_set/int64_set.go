@@ -1,3 +1,5 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
 package mapsetint64
 
 // Int64Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Int64Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Int64Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Int64Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() int64
 
-	// Returns all subsets of a given set (Power Int64Set).
-	// Not yet supported
-	// PowerSet() Int64Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Int64Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Int64Set) Int64Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Int64Set) Int64PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []int64
@@ -145,7 +154,7 @@ type Int64Set interface {
 // NewInt64Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewInt64Set(s ...int64) Int64Set {
-	set := newThreadSafeInt64Set()
+	set := threadSafeInt64Set{s: newThreadUnsafeInt64SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewInt64SetFromSlice(s []int64) Int64Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeInt64Set() Int64Set {
 	set := newThreadUnsafeInt64Set()
+	trackInt64Set(&set)
+	return &set
+}
+
+// NewInt64SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewInt64SetWithCapacity(n int) Int64Set {
+	return &threadSafeInt64Set{s: newThreadUnsafeInt64SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeInt64SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeInt64SetWithCapacity(n int) Int64Set {
+	set := newThreadUnsafeInt64SetWithCapacity(n)
+	trackInt64Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeInt64Set() Int64Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeInt64SetFromSlice(s []int64) Int64Set {
-	a := NewThreadUnsafeInt64Set()
+	a := NewThreadUnsafeInt64SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
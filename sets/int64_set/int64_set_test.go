@@ -0,0 +1,105 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleInt64Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleInt64Values = []int64{
+	-128,
+	-1,
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+}
+
+// int64SetFactories returns constructors for both implementations.
+func int64SetFactories() map[string]func() Int64Set {
+	return map[string]func() Int64Set{
+		"safe":   func() Int64Set { return NewInt64Set() },
+		"unsafe": NewThreadUnsafeInt64Set,
+	}
+}
+
+// fillInt64Set adds values to s and returns it.
+func fillInt64Set(s Int64Set, values ...int64) Int64Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertInt64RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertInt64RoundTrip(t *testing.T, marshal func(Int64Set) ([]byte, error), unmarshal func([]byte, Int64Set) error) {
+	t.Helper()
+	contents := [][]int64{sampleInt64Values, sampleInt64Values[:1]}
+	for name, newSet := range int64SetFactories() {
+		for _, values := range contents {
+			s := fillInt64Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range int64SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[int64, Int64Set]{
+				New:   newSet,
+				Elems: sampleInt64Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range int64SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleInt64Values[0])
+		a.Add(sampleInt64Values[1])
+		for _, v := range sampleInt64Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleInt64Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Int64Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// hashInt64Element returns a hash of elem that is stable across
+// processes, using buf as scratch space, and the grown buffer. Elements
+// without a binary encoding are hashed by their formatted value. elem
+// is hashed as the set stores it, so that filters built from a set find
+// every value the set contains.
+func hashInt64Element(buf []byte, elem int64) (uint64, []byte) {
+	elem = keyInt64(elem)
+	b, err := appendInt64Element(buf, elem)
+	if err != nil {
+		b = fmt.Appendf(buf, "%#v", elem)
+	}
+	return sketch.Hash64(b, 0), b
+}
+
+// MinHashSignature returns the MinHash signature of set under hasher.
+// Elements are hashed by their binary encoding, so signatures of
+// Int64Sets are comparable with each other but not with those of
+// other set types.
+func MinHashSignature(set Int64Set, hasher *sketch.MinHasher) sketch.Signature {
+	sig := hasher.NewSignature()
+	var buf []byte
+	set.Each(func(elem int64) bool {
+		var h uint64
+		h, buf = hashInt64Element(buf[:0], elem)
+		hasher.Push(sig, h)
+		return false
+	})
+	return sig
+}
+
+// ToHyperLogLog returns a HyperLogLog sketch of set with the given
+// precision, hashing elements as MinHashSignature does, so that it can
+// be merged with sketches of other Int64Sets.
+func ToHyperLogLog(set Int64Set, precision uint8) *sketch.HyperLogLog {
+	hll := sketch.NewHyperLogLog(precision)
+	var buf []byte
+	set.Each(func(elem int64) bool {
+		var x uint64
+		x, buf = hashInt64Element(buf[:0], elem)
+		hll.AddHash(x)
+		return false
+	})
+	return hll
+}
+
+// Int64BloomFilter is a sketch.BloomFilter over int64 elements, which hashes
+// elements as MinHashSignature does. A decoded filter answers queries
+// the same way as the one that was encoded.
+type Int64BloomFilter struct {
+	sketch.BloomFilter
+}
+
+// ToBloomFilter returns a Bloom filter holding the elements of set,
+// sized for a false positive rate of about fpRate.
+func ToBloomFilter(set Int64Set, fpRate float64) *Int64BloomFilter {
+	filter := &Int64BloomFilter{*sketch.NewBloomFilter(set.Cardinality(), fpRate)}
+	var buf []byte
+	set.Each(func(elem int64) bool {
+		var h uint64
+		h, buf = hashInt64Element(buf[:0], elem)
+		filter.AddHash(h)
+		return false
+	})
+	return filter
+}
+
+// Add adds elem to the filter.
+func (f *Int64BloomFilter) Add(elem int64) {
+	h, _ := hashInt64Element(nil, elem)
+	f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Int64BloomFilter) MightContain(elem int64) bool {
+	h, _ := hashInt64Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Int64CuckooFilter is a sketch.CuckooFilter over int64 elements, which hashes
+// elements as MinHashSignature does.
+type Int64CuckooFilter struct {
+	sketch.CuckooFilter
+}
+
+// ToCuckooFilter returns a cuckoo filter holding the elements of set,
+// sized for a false positive rate of about fpRate. Elements with equal
+// hashes, such as NaNs, are added once. The filter is grown until every
+// element fits, up to a limit, past which an error is returned.
+func ToCuckooFilter(set Int64Set, fpRate float64) (*Int64CuckooFilter, error) {
+	var hashes []uint64
+	var buf []byte
+	set.Each(func(elem int64) bool {
+		var h uint64
+		h, buf = hashInt64Element(buf[:0], elem)
+		hashes = append(hashes, h)
+		return false
+	})
+	f, err := newCuckooFilterFromHashes(hashes, fpRate)
+	if err != nil {
+		return nil, err
+	}
+	return &Int64CuckooFilter{*f}, nil
+}
+
+// newCuckooFilterFromHashes returns a filter holding the distinct hashes,
+// doubling its capacity at most cuckooMaxGrowths times.
+func newCuckooFilterFromHashes(hashes []uint64, fpRate float64) (*sketch.CuckooFilter, error) {
+	// A bucket pair holds eight fingerprints, so repeats of one hash
+	// would collide however large the filter grows.
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	const cuckooMaxGrowths = 8
+	n := len(hashes)
+	for i := 0; i <= cuckooMaxGrowths; i++ {
+		f := sketch.NewCuckooFilter(n, fpRate)
+		if addAllHashes(f, hashes) {
+			return f, nil
+		}
+		n *= 2
+	}
+	return nil, fmt.Errorf("mapsetint64: cannot fit %d distinct hashes in a cuckoo filter", len(hashes))
+}
+
+func addAllHashes(f *sketch.CuckooFilter, hashes []uint64) bool {
+	for _, h := range hashes {
+		if !f.AddHash(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds elem to the filter and reports whether there was room.
+func (f *Int64CuckooFilter) Add(elem int64) bool {
+	h, _ := hashInt64Element(nil, elem)
+	return f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *Int64CuckooFilter) MightContain(elem int64) bool {
+	h, _ := hashInt64Element(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Delete removes elem from the filter and reports whether it was found.
+// Only delete elements that were added.
+func (f *Int64CuckooFilter) Delete(elem int64) bool {
+	h, _ := hashInt64Element(nil, elem)
+	return f.DeleteHash(h)
+}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"sort"
+)
+
+// lessInt64 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessInt64(a, b int64) bool {
+	return a < b
+}
+
+// sortInt64Elements sorts elems in place using lessInt64.
+func sortInt64Elements(elems []int64) {
+	sort.Slice(elems, func(i, j int) bool { return lessInt64(elems[i], elems[j]) })
+}
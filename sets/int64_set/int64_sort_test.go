@@ -0,0 +1,26 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]int64, 0, len(sampleInt64Values))
+	for i := len(sampleInt64Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleInt64Values[i])
+	}
+	sortInt64Elements(elems)
+	for i, elem := range elems {
+		if lessInt64(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessInt64(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewInt64Set(elems...).Equal(NewInt64Set(sampleInt64Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleInt64Values, elems)
+	}
+}
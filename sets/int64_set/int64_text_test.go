@@ -0,0 +1,14 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Int64Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Int64Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertInt64RoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
 package mapsetint64
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeInt64Set struct {
-	s threadUnsafeInt64Set
+	s     threadUnsafeInt64Set
+	stats atomic.Pointer[Int64SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeInt64Set() threadSafeInt64Set {
 	return threadSafeInt64Set{s: newThreadUnsafeInt64Set()}
 }
 
+// readLockInt64Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockInt64Sets(sets []Int64Set) ([]threadUnsafeInt64Set, func()) {
+	maps := make([]threadUnsafeInt64Set, len(sets))
+	if _, ok := sets[0].(*threadSafeInt64Set); !ok {
+		guards := make([]unsafeInt64Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeInt64Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeInt64Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeInt64Set))
+	}
+	distinct := lockInt64Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeInt64Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockInt64Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockInt64Order(sets []*threadSafeInt64Set) []*threadSafeInt64Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockInt64Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockInt64Pair(x, y *threadSafeInt64Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt64Pair releases the locks taken by rlockInt64Pair.
+func runlockInt64Pair(x, y *threadSafeInt64Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeInt64Set) Add(i int64) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeInt64Set) Contains(i ...int64) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeInt64Set) IsSubset(other Int64Set) bool {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt64Set) IsProperSubset(other Int64Set) bool {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockInt64Pair(set, o)
+	defer runlockInt64Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeInt64Set) IsProperSuperset(other Int64Set) bool {
 func (set *threadSafeInt64Set) Union(other Int64Set) Int64Set {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
+	readInt64Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeInt64Set)
 	ret := &threadSafeInt64Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt64Set) Intersect(other Int64Set) Int64Set {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
+	readInt64Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeInt64Set)
 	ret := &threadSafeInt64Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt64Set) Difference(other Int64Set) Int64Set {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
+	readInt64Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeInt64Set)
 	ret := &threadSafeInt64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt64Set) SymmetricDifference(other Int64Set) Int64Set {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
+	readInt64Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeInt64Set)
 	ret := &threadSafeInt64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt64Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeInt64Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeInt64Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeInt64Set) Remove(i int64) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyInt64(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeInt64Set) Cardinality() int {
 }
 
 func (set *threadSafeInt64Set) Each(cb func(int64) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeInt64Set) Iterator() *Int64Iterator {
 func (set *threadSafeInt64Set) Equal(other Int64Set) bool {
 	o := other.(*threadSafeInt64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt64Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt64Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeInt64Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt64Set) PowerSet() Int64Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeInt64Set)
-    set.RUnlock()
+func (set *threadSafeInt64Set) PowerSet() []Int64Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeInt64Set{s: newThreadUnsafeInt64Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeInt64Set)
-        ret.Add(&threadSafeInt64Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Int64Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeInt64Set)
+		ret = append(ret, &threadSafeInt64Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeInt64Set) Pop() int64 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt64Set) CartesianProduct(other Int64Set) Int64Set {
-    o := other.(*threadSafeInt64Set)
+func (set *threadSafeInt64Set) CartesianProduct(other Int64Set) Int64PairSet {
+	o := other.(*threadSafeInt64Set)
 
-    set.RLock()
-    o.RLock()
+	rlockInt64Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt64Set)
-    ret := &threadSafeInt64Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt64PairSet)
+	ret := &threadSafeInt64PairSet{s: *unsafeCartProduct}
+	runlockInt64Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeInt64Set) ToSlice() []int64 {
-	keys := make([]int64, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]int64, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeInt64Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeInt64Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
 package mapsetint64
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeInt64Set map[int64]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  int64
-	Second int64
-}
-
 func newThreadUnsafeInt64Set() threadUnsafeInt64Set {
 	return make(threadUnsafeInt64Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeInt64SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeInt64SetWithCapacity(n int) threadUnsafeInt64Set {
+	return make(threadUnsafeInt64Set, n)
+}
 
-	return false
+// keyInt64 returns the map key of i.
+func keyInt64(i int64) int64 {
+	return i
 }
 
 func (set *threadUnsafeInt64Set) Add(i int64) bool {
+	defer set.guardWrite().done()
+	i = keyInt64(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeInt64Set) Add(i int64) bool {
 }
 
 func (set *threadUnsafeInt64Set) Contains(i ...int64) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyInt64(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeInt64Set) Contains(i ...int64) bool {
 
 func (set *threadUnsafeInt64Set) IsSubset(other Int64Set) bool {
 	_ = other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeInt64Set) IsSubset(other Int64Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeInt64Set) IsProperSubset(other Int64Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeInt64Set) IsSuperset(other Int64Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeInt64Set) IsSuperset(other Int64Set) bool {
 }
 
 func (set *threadUnsafeInt64Set) IsProperSuperset(other Int64Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeInt64Set) Union(other Int64Set) Int64Set {
 	o := other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeInt64Set()
+	unionedSet := newThreadUnsafeInt64SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeInt64Set) Union(other Int64Set) Int64Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackInt64Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeInt64Set) Intersect(other Int64Set) Int64Set {
 	o := other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeInt64Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeInt64Set) Intersect(other Int64Set) Int64Set {
 			}
 		}
 	}
+	trackInt64Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeInt64Set) Difference(other Int64Set) Int64Set {
 	_ = other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeInt64Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeInt64Set) Difference(other Int64Set) Int64Set {
 			difference.Add(elem)
 		}
 	}
+	trackInt64Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeInt64Set) SymmetricDifference(other Int64Set) Int64Set {
-	_ = other.(*threadUnsafeInt64Set)
+	o := other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeInt64Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackInt64Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeInt64Set) Clear() {
-	*set = newThreadUnsafeInt64Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeInt64Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeInt64SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeInt64Set) Remove(i int64) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyInt64(i))
 }
 
 func (set *threadUnsafeInt64Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeInt64Set) Each(cb func(int64) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeInt64Set) Each(cb func(int64) bool) {
 func (set *threadUnsafeInt64Set) Iter() <-chan int64 {
 	ch := make(chan int64)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeInt64Set) Iterator() *Int64Iterator {
 	iterator, ch, stopCh := newInt64Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeInt64Set) Iterator() *Int64Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeInt64Set) Iterator() *Int64Iterator {
 
 func (set *threadUnsafeInt64Set) Equal(other Int64Set) bool {
 	_ = other.(*threadUnsafeInt64Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeInt64Set) Equal(other Int64Set) bool {
 }
 
 func (set *threadUnsafeInt64Set) Clone() Int64Set {
-	clonedSet := newThreadUnsafeInt64Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeInt64SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackInt64Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeInt64Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeInt64Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeInt64Set) Pop() int64 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeInt64Set) Pop() int64 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt64Set) PowerSet() Int64Set {
-	powSet := NewThreadUnsafeInt64Set()
+func (set *threadUnsafeInt64Set) PowerSet() []Int64Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeInt64Set()
-	powSet.Add(&nullset)
+	powSet := []Int64Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeInt64Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeInt64Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeInt64Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt64Set) CartesianProduct(other Int64Set) Int64Set {
+func (set *threadUnsafeInt64Set) CartesianProduct(other Int64Set) Int64PairSet {
 	o := other.(*threadUnsafeInt64Set)
-	cartProduct := NewThreadUnsafeInt64Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeInt64PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Int64Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeInt64Set) ToSlice() []int64 {
+	defer set.guardRead().done()
 	keys := make([]int64, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeInt64Set) ToSlice() []int64 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeInt64Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Int64JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeInt64Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeInt64Set) UnmarshalJSON(data []byte) error {
 	var i []int64
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
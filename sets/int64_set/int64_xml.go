@@ -0,0 +1,154 @@
+// Code generated by generate_set for int64. DO NOT EDIT.
+
+package mapsetint64
+
+import (
+	"encoding/xml"
+)
+
+// DefaultInt64XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultInt64XMLItemName = "item"
+
+// Int64XML adapts a Int64Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Int64XML struct {
+	Set Int64Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultInt64XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Int64XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt64XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Int64XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt64XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Int64XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultInt64XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeInt64XML(e *xml.Encoder, start xml.StartElement, s Int64Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortInt64Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatInt64Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeInt64XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeInt64XML(dec *xml.Decoder, itemName string) ([]int64, error) {
+	var elems []int64
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseInt64Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultInt64XMLItemName. Use Int64XML for other
+// names.
+func (set *threadUnsafeInt64Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt64XML(e, start, set, DefaultInt64XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeInt64Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt64XML(d, DefaultInt64XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeInt64Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultInt64XMLItemName. Use Int64XML for other
+// names.
+func (set *threadSafeInt64Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt64XML(e, start, set, DefaultInt64XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeInt64Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt64XML(d, DefaultInt64XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeInt64Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Int8Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleInt8Values[i%len(sampleInt8Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewInt8Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeInt8Set())
+}
+
+func benchContains(b *testing.B, s Int8Set) {
+	for _, v := range sampleInt8Values[:len(sampleInt8Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleInt8Values[i%len(sampleInt8Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewInt8Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeInt8Set())
+}
+
+func benchUnion(b *testing.B, x, y Int8Set) {
+	half := len(sampleInt8Values) / 2
+	for _, v := range sampleInt8Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleInt8Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewInt8Set(), NewInt8Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeInt8Set(), NewThreadUnsafeInt8Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Int8Set) {
+	for _, v := range sampleInt8Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewInt8Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeInt8Set())
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeInt8Set()
+		return &s
+	},
+}
+
+// NewScratchInt8Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchInt8Set() Int8Set {
+	return scratchPool.Get().(*threadUnsafeInt8Set)
+}
+
+// Release empties s and makes it available to NewScratchInt8Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Int8Set) {
+	u, ok := s.(*threadUnsafeInt8Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Int8Set) {
+	d, x, y, locks := lockInt8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllInt8(d, y)
+	case dst == b:
+		addAllInt8(d, x)
+	default:
+		clear(d)
+		addAllInt8(d, x)
+		addAllInt8(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Int8Set) {
+	d, x, y, locks := lockInt8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyInt8(d, y)
+	case dst == b:
+		keepOnlyInt8(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Int8Set) {
+	d, x, y, locks := lockInt8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchInt8Set().(*threadUnsafeInt8Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllInt8(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllInt8(dst, src threadUnsafeInt8Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyInt8 removes the elements of dst that are not in other.
+func keepOnlyInt8(dst, other threadUnsafeInt8Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoInt8Locks records the locks taken by lockInt8Into, or the guards for
+// thread-unsafe sets.
+type intoInt8Locks struct {
+	sets     [3]*threadSafeInt8Set
+	distinct int
+	dst      *threadSafeInt8Set
+	guards   [3]unsafeInt8Guard
+}
+
+// lockInt8Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockInt8Into(dst, a, b Int8Set) (d, x, y threadUnsafeInt8Set, locks intoInt8Locks) {
+	w, ok := dst.(*threadSafeInt8Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeInt8Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeInt8Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeInt8Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeInt8Set), *b.(*threadUnsafeInt8Set), locks
+	}
+
+	locks.sets = [3]*threadSafeInt8Set{w, a.(*threadSafeInt8Set), b.(*threadSafeInt8Set)}
+	locks.distinct = len(lockInt8Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeInt8Set).s, b.(*threadSafeInt8Set).s, locks
+}
+
+func (l intoInt8Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
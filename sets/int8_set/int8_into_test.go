@@ -0,0 +1,74 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleInt8Values)
+	aValues, bValues := sampleInt8Values[:n-1], sampleInt8Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Int8Set)
+		expected []int8
+	}{
+		{"UnionInto", UnionInto, sampleInt8Values},
+		{"IntersectInto", IntersectInto, sampleInt8Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleInt8Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range int8SetFactories() {
+			expected := fillInt8Set(newSet(), testCase.expected...)
+
+			dst := fillInt8Set(newSet(), sampleInt8Values[n-1])
+			testCase.into(dst, fillInt8Set(newSet(), aValues...), fillInt8Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillInt8Set(newSet(), aValues...)
+			testCase.into(a, a, fillInt8Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillInt8Set(newSet(), bValues...)
+			testCase.into(b, fillInt8Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range int8SetFactories() {
+		s := fillInt8Set(newSet(), sampleInt8Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchInt8Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleInt8Values[0])
+	Release(s)
+	Release(NewInt8Set(sampleInt8Values[0]))
+
+	if again := NewScratchInt8Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
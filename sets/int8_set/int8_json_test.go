@@ -0,0 +1,49 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Int8Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Int8Set) error { return json.Unmarshal(b, s) }
+	assertInt8RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewInt8Set(sampleInt8Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewInt8Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleInt8Values[i%len(sampleInt8Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewInt8Set(sampleInt8Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleInt8Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetint8
+
+// unsafeInt8Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeInt8Guard struct{}
+
+func trackInt8Set(set *threadUnsafeInt8Set) {}
+
+func (set *threadUnsafeInt8Set) guardRead() unsafeInt8Guard { return unsafeInt8Guard{} }
+
+func (set *threadUnsafeInt8Set) guardWrite() unsafeInt8Guard { return unsafeInt8Guard{} }
+
+func (set *threadUnsafeInt8Set) guardIterate() unsafeInt8Guard { return unsafeInt8Guard{} }
+
+func (unsafeInt8Guard) done() {}
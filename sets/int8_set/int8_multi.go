@@ -0,0 +1,110 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Int8Set) Int8Set {
+	if len(sets) == 0 {
+		return NewInt8Set()
+	}
+	maps, unlock := readLockInt8Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeInt8Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapInt8Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Int8Set) Int8Set {
+	if len(sets) == 0 {
+		return NewInt8Set()
+	}
+	maps, unlock := readLockInt8Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeInt8Set()
+	for elem := range maps[smallest] {
+		if containedInAllInt8(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapInt8Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Int8Set, sets ...Int8Set) Int8Set {
+	maps, unlock := readLockInt8Sets(append([]Int8Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeInt8Set()
+	for elem := range maps[0] {
+		if !containedInAnyInt8(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapInt8Like(base, difference)
+}
+
+func containedInAllInt8(maps []threadUnsafeInt8Set, skip int, elem int8) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyInt8(maps []threadUnsafeInt8Set, elem int8) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapInt8Like returns s as a Set of the same implementation as like.
+func wrapInt8Like(like Int8Set, s threadUnsafeInt8Set) Int8Set {
+	if _, ok := like.(*threadSafeInt8Set); ok {
+		return &threadSafeInt8Set{s: s}
+	}
+	trackInt8Set(&s)
+	return &s
+}
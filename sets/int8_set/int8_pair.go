@@ -0,0 +1,224 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Int8Pair represents a 2-tuple of values.
+type Int8Pair struct {
+	First  int8
+	Second int8
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Int8Pair) Equal(other Int8Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Int8Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Int8PairSet is an unordered set of Int8Pairs, as
+// returned by CartesianProduct.
+type Int8PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Int8Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Int8Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Int8Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Int8PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Int8Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Int8Pair
+}
+
+type threadUnsafeInt8PairSet map[Int8Pair]struct{}
+
+func newThreadUnsafeInt8PairSet() threadUnsafeInt8PairSet {
+	return make(threadUnsafeInt8PairSet)
+}
+
+func (set *threadUnsafeInt8PairSet) Add(p Int8Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeInt8PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeInt8PairSet) Contains(p ...Int8Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt8PairSet) Each(cb func(Int8Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeInt8PairSet) Equal(other Int8PairSet) bool {
+	_ = other.(*threadUnsafeInt8PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeInt8PairSet) Remove(p Int8Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeInt8PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeInt8PairSet) ToSlice() []Int8Pair {
+	keys := make([]Int8Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeInt8PairSet struct {
+	s threadUnsafeInt8PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeInt8PairSet) Add(p Int8Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeInt8PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeInt8PairSet) Contains(p ...Int8Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt8PairSet) Each(cb func(Int8Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockInt8PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockInt8Pair does for sets.
+func rlockInt8PairSets(x, y *threadSafeInt8PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt8PairSets releases the locks taken by rlockInt8PairSets.
+func runlockInt8PairSets(x, y *threadSafeInt8PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeInt8PairSet) Equal(other Int8PairSet) bool {
+	o := other.(*threadSafeInt8PairSet)
+
+	rlockInt8PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockInt8PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeInt8PairSet) Remove(p Int8Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeInt8PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeInt8PairSet) ToSlice() []Int8Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
 package mapsetint8
 
 // Int8Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Int8Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Int8Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Int8Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() int8
 
-	// Returns all subsets of a given set (Power Int8Set).
-	// Not yet supported
-	// PowerSet() Int8Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Int8Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Int8Set) Int8Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Int8Set) Int8PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []int8
@@ -145,7 +154,7 @@ type Int8Set interface {
 // NewInt8Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewInt8Set(s ...int8) Int8Set {
-	set := newThreadSafeInt8Set()
+	set := threadSafeInt8Set{s: newThreadUnsafeInt8SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewInt8SetFromSlice(s []int8) Int8Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeInt8Set() Int8Set {
 	set := newThreadUnsafeInt8Set()
+	trackInt8Set(&set)
+	return &set
+}
+
+// NewInt8SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewInt8SetWithCapacity(n int) Int8Set {
+	return &threadSafeInt8Set{s: newThreadUnsafeInt8SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeInt8SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeInt8SetWithCapacity(n int) Int8Set {
+	set := newThreadUnsafeInt8SetWithCapacity(n)
+	trackInt8Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeInt8Set() Int8Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeInt8SetFromSlice(s []int8) Int8Set {
-	a := NewThreadUnsafeInt8Set()
+	a := NewThreadUnsafeInt8SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,105 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleInt8Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleInt8Values = []int8{
+	-128,
+	-1,
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+}
+
+// int8SetFactories returns constructors for both implementations.
+func int8SetFactories() map[string]func() Int8Set {
+	return map[string]func() Int8Set{
+		"safe":   func() Int8Set { return NewInt8Set() },
+		"unsafe": NewThreadUnsafeInt8Set,
+	}
+}
+
+// fillInt8Set adds values to s and returns it.
+func fillInt8Set(s Int8Set, values ...int8) Int8Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertInt8RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertInt8RoundTrip(t *testing.T, marshal func(Int8Set) ([]byte, error), unmarshal func([]byte, Int8Set) error) {
+	t.Helper()
+	contents := [][]int8{sampleInt8Values, sampleInt8Values[:1]}
+	for name, newSet := range int8SetFactories() {
+		for _, values := range contents {
+			s := fillInt8Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range int8SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[int8, Int8Set]{
+				New:   newSet,
+				Elems: sampleInt8Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range int8SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleInt8Values[0])
+		a.Add(sampleInt8Values[1])
+		for _, v := range sampleInt8Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleInt8Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Int8Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
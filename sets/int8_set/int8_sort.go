@@ -0,0 +1,18 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"sort"
+)
+
+// lessInt8 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessInt8(a, b int8) bool {
+	return a < b
+}
+
+// sortInt8Elements sorts elems in place using lessInt8.
+func sortInt8Elements(elems []int8) {
+	sort.Slice(elems, func(i, j int) bool { return lessInt8(elems[i], elems[j]) })
+}
@@ -0,0 +1,26 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]int8, 0, len(sampleInt8Values))
+	for i := len(sampleInt8Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleInt8Values[i])
+	}
+	sortInt8Elements(elems)
+	for i, elem := range elems {
+		if lessInt8(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessInt8(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewInt8Set(elems...).Equal(NewInt8Set(sampleInt8Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleInt8Values, elems)
+	}
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Int8Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Int8Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertInt8RoundTrip(t, marshal, unmarshal)
+}
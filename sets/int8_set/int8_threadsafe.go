@@ -1,11 +1,16 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
 package mapsetint8
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeInt8Set struct {
-	s threadUnsafeInt8Set
+	s     threadUnsafeInt8Set
+	stats atomic.Pointer[Int8SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeInt8Set() threadSafeInt8Set {
 	return threadSafeInt8Set{s: newThreadUnsafeInt8Set()}
 }
 
+// readLockInt8Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockInt8Sets(sets []Int8Set) ([]threadUnsafeInt8Set, func()) {
+	maps := make([]threadUnsafeInt8Set, len(sets))
+	if _, ok := sets[0].(*threadSafeInt8Set); !ok {
+		guards := make([]unsafeInt8Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeInt8Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeInt8Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeInt8Set))
+	}
+	distinct := lockInt8Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeInt8Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockInt8Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockInt8Order(sets []*threadSafeInt8Set) []*threadSafeInt8Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockInt8Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockInt8Pair(x, y *threadSafeInt8Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockInt8Pair releases the locks taken by rlockInt8Pair.
+func runlockInt8Pair(x, y *threadSafeInt8Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeInt8Set) Add(i int8) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeInt8Set) Contains(i ...int8) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeInt8Set) IsSubset(other Int8Set) bool {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt8Set) IsProperSubset(other Int8Set) bool {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockInt8Pair(set, o)
+	defer runlockInt8Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeInt8Set) IsProperSuperset(other Int8Set) bool {
 func (set *threadSafeInt8Set) Union(other Int8Set) Int8Set {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
+	readInt8Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeInt8Set)
 	ret := &threadSafeInt8Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt8Set) Intersect(other Int8Set) Int8Set {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
+	readInt8Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeInt8Set)
 	ret := &threadSafeInt8Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt8Set) Difference(other Int8Set) Int8Set {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
+	readInt8Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeInt8Set)
 	ret := &threadSafeInt8Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt8Set) SymmetricDifference(other Int8Set) Int8Set {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
+	readInt8Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeInt8Set)
 	ret := &threadSafeInt8Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeInt8Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeInt8Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeInt8Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeInt8Set) Remove(i int8) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyInt8(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeInt8Set) Cardinality() int {
 }
 
 func (set *threadSafeInt8Set) Each(cb func(int8) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeInt8Set) Iterator() *Int8Iterator {
 func (set *threadSafeInt8Set) Equal(other Int8Set) bool {
 	o := other.(*threadSafeInt8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockInt8Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockInt8Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeInt8Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt8Set) PowerSet() Int8Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeInt8Set)
-    set.RUnlock()
+func (set *threadSafeInt8Set) PowerSet() []Int8Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeInt8Set{s: newThreadUnsafeInt8Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeInt8Set)
-        ret.Add(&threadSafeInt8Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Int8Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeInt8Set)
+		ret = append(ret, &threadSafeInt8Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeInt8Set) Pop() int8 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeInt8Set) CartesianProduct(other Int8Set) Int8Set {
-    o := other.(*threadSafeInt8Set)
+func (set *threadSafeInt8Set) CartesianProduct(other Int8Set) Int8PairSet {
+	o := other.(*threadSafeInt8Set)
 
-    set.RLock()
-    o.RLock()
+	rlockInt8Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt8Set)
-    ret := &threadSafeInt8Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeInt8PairSet)
+	ret := &threadSafeInt8PairSet{s: *unsafeCartProduct}
+	runlockInt8Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeInt8Set) ToSlice() []int8 {
-	keys := make([]int8, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]int8, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeInt8Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeInt8Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
 package mapsetint8
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeInt8Set map[int8]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  int8
-	Second int8
-}
-
 func newThreadUnsafeInt8Set() threadUnsafeInt8Set {
 	return make(threadUnsafeInt8Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeInt8SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeInt8SetWithCapacity(n int) threadUnsafeInt8Set {
+	return make(threadUnsafeInt8Set, n)
+}
 
-	return false
+// keyInt8 returns the map key of i.
+func keyInt8(i int8) int8 {
+	return i
 }
 
 func (set *threadUnsafeInt8Set) Add(i int8) bool {
+	defer set.guardWrite().done()
+	i = keyInt8(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeInt8Set) Add(i int8) bool {
 }
 
 func (set *threadUnsafeInt8Set) Contains(i ...int8) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyInt8(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeInt8Set) Contains(i ...int8) bool {
 
 func (set *threadUnsafeInt8Set) IsSubset(other Int8Set) bool {
 	_ = other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeInt8Set) IsSubset(other Int8Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeInt8Set) IsProperSubset(other Int8Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeInt8Set) IsSuperset(other Int8Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeInt8Set) IsSuperset(other Int8Set) bool {
 }
 
 func (set *threadUnsafeInt8Set) IsProperSuperset(other Int8Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeInt8Set) Union(other Int8Set) Int8Set {
 	o := other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeInt8Set()
+	unionedSet := newThreadUnsafeInt8SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeInt8Set) Union(other Int8Set) Int8Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackInt8Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeInt8Set) Intersect(other Int8Set) Int8Set {
 	o := other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeInt8Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeInt8Set) Intersect(other Int8Set) Int8Set {
 			}
 		}
 	}
+	trackInt8Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeInt8Set) Difference(other Int8Set) Int8Set {
 	_ = other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeInt8Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeInt8Set) Difference(other Int8Set) Int8Set {
 			difference.Add(elem)
 		}
 	}
+	trackInt8Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeInt8Set) SymmetricDifference(other Int8Set) Int8Set {
-	_ = other.(*threadUnsafeInt8Set)
+	o := other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeInt8Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackInt8Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeInt8Set) Clear() {
-	*set = newThreadUnsafeInt8Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeInt8Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeInt8SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeInt8Set) Remove(i int8) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyInt8(i))
 }
 
 func (set *threadUnsafeInt8Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeInt8Set) Each(cb func(int8) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeInt8Set) Each(cb func(int8) bool) {
 func (set *threadUnsafeInt8Set) Iter() <-chan int8 {
 	ch := make(chan int8)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeInt8Set) Iterator() *Int8Iterator {
 	iterator, ch, stopCh := newInt8Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeInt8Set) Iterator() *Int8Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeInt8Set) Iterator() *Int8Iterator {
 
 func (set *threadUnsafeInt8Set) Equal(other Int8Set) bool {
 	_ = other.(*threadUnsafeInt8Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeInt8Set) Equal(other Int8Set) bool {
 }
 
 func (set *threadUnsafeInt8Set) Clone() Int8Set {
-	clonedSet := newThreadUnsafeInt8Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeInt8SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackInt8Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeInt8Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeInt8Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeInt8Set) Pop() int8 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeInt8Set) Pop() int8 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt8Set) PowerSet() Int8Set {
-	powSet := NewThreadUnsafeInt8Set()
+func (set *threadUnsafeInt8Set) PowerSet() []Int8Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeInt8Set()
-	powSet.Add(&nullset)
+	powSet := []Int8Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeInt8Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeInt8Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeInt8Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeInt8Set) CartesianProduct(other Int8Set) Int8Set {
+func (set *threadUnsafeInt8Set) CartesianProduct(other Int8Set) Int8PairSet {
 	o := other.(*threadUnsafeInt8Set)
-	cartProduct := NewThreadUnsafeInt8Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeInt8PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Int8Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeInt8Set) ToSlice() []int8 {
+	defer set.guardRead().done()
 	keys := make([]int8, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeInt8Set) ToSlice() []int8 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeInt8Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Int8JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeInt8Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeInt8Set) UnmarshalJSON(data []byte) error {
 	var i []int8
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
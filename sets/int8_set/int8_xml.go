@@ -0,0 +1,154 @@
+// Code generated by generate_set for int8. DO NOT EDIT.
+
+package mapsetint8
+
+import (
+	"encoding/xml"
+)
+
+// DefaultInt8XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultInt8XMLItemName = "item"
+
+// Int8XML adapts a Int8Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Int8XML struct {
+	Set Int8Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultInt8XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Int8XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt8XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Int8XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt8XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Int8XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultInt8XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeInt8XML(e *xml.Encoder, start xml.StartElement, s Int8Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortInt8Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatInt8Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeInt8XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeInt8XML(dec *xml.Decoder, itemName string) ([]int8, error) {
+	var elems []int8
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseInt8Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultInt8XMLItemName. Use Int8XML for other
+// names.
+func (set *threadUnsafeInt8Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt8XML(e, start, set, DefaultInt8XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeInt8Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt8XML(d, DefaultInt8XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeInt8Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultInt8XMLItemName. Use Int8XML for other
+// names.
+func (set *threadSafeInt8Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeInt8XML(e, start, set, DefaultInt8XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeInt8Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeInt8XML(d, DefaultInt8XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeInt8Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s IntSet) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleIntValues[i%len(sampleIntValues)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewIntSet())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeIntSet())
+}
+
+func benchContains(b *testing.B, s IntSet) {
+	for _, v := range sampleIntValues[:len(sampleIntValues)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleIntValues[i%len(sampleIntValues)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewIntSet())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeIntSet())
+}
+
+func benchUnion(b *testing.B, x, y IntSet) {
+	half := len(sampleIntValues) / 2
+	for _, v := range sampleIntValues[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleIntValues[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewIntSet(), NewIntSet())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeIntSet(), NewThreadUnsafeIntSet())
+}
+
+func benchMarshalJSON(b *testing.B, s IntSet) {
+	for _, v := range sampleIntValues {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewIntSet())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeIntSet())
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s IntSet) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s IntSet) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertIntRoundTrip(t, marshal, unmarshal)
+}
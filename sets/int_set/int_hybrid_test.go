@@ -0,0 +1,127 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"testing"
+)
+
+// intHybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const intHybridPrecision = 14
+
+// assertIntHybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertIntHybridCount(t *testing.T, name string, s *IntHybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleIntValues) / 2
+	s := NewIntHybridSet(half, intHybridPrecision)
+	for _, v := range sampleIntValues[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertIntHybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeIntSetFromSlice(sampleIntValues[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleIntValues[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleIntValues[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertIntHybridCount(t, "converted", s, half+1)
+	s.Add(sampleIntValues[0])
+	assertIntHybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleIntValues) / 2
+	newHybrid := func(sketched bool, elems []int) *IntHybridSet {
+		threshold := len(sampleIntValues)
+		if sketched {
+			threshold = 0
+		}
+		s := NewIntHybridSet(threshold, intHybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleIntValues[:half+1])
+		other := newHybrid(tc.sketchedO, sampleIntValues[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeIntSetFromSlice(sampleIntValues)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleIntValues, s.Exact())
+		}
+		assertIntHybridCount(t, tc.name, s, len(sampleIntValues))
+		assertIntHybridCount(t, tc.name+" leaves other", other, len(sampleIntValues)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewIntHybridSet(half, intHybridPrecision)
+	s.Add(sampleIntValues[0])
+	if err := s.Merge(newHybrid(false, sampleIntValues)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertIntHybridCount(t, "merged past the threshold", s, len(sampleIntValues))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleIntValues) / 2
+	for _, threshold := range []int{len(sampleIntValues), 0} {
+		s := NewIntHybridSet(threshold, intHybridPrecision)
+		for _, v := range sampleIntValues[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleIntValues), 0} {
+			other := NewIntHybridSet(otherThreshold, intHybridPrecision-2)
+			other.Add(sampleIntValues[len(sampleIntValues)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, intHybridPrecision, intHybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeIntSetFromSlice(sampleIntValues[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
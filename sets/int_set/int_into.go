@@ -0,0 +1,195 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeIntSet()
+		return &s
+	},
+}
+
+// NewScratchIntSet returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchIntSet() IntSet {
+	return scratchPool.Get().(*threadUnsafeIntSet)
+}
+
+// Release empties s and makes it available to NewScratchIntSet. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s IntSet) {
+	u, ok := s.(*threadUnsafeIntSet)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b IntSet) {
+	d, x, y, locks := lockIntInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllInt(d, y)
+	case dst == b:
+		addAllInt(d, x)
+	default:
+		clear(d)
+		addAllInt(d, x)
+		addAllInt(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b IntSet) {
+	d, x, y, locks := lockIntInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyInt(d, y)
+	case dst == b:
+		keepOnlyInt(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b IntSet) {
+	d, x, y, locks := lockIntInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchIntSet().(*threadUnsafeIntSet)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllInt(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllInt(dst, src threadUnsafeIntSet) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyInt removes the elements of dst that are not in other.
+func keepOnlyInt(dst, other threadUnsafeIntSet) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoIntLocks records the locks taken by lockIntInto, or the guards for
+// thread-unsafe sets.
+type intoIntLocks struct {
+	sets     [3]*threadSafeIntSet
+	distinct int
+	dst      *threadSafeIntSet
+	guards   [3]unsafeIntGuard
+}
+
+// lockIntInto locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockIntInto(dst, a, b IntSet) (d, x, y threadUnsafeIntSet, locks intoIntLocks) {
+	w, ok := dst.(*threadSafeIntSet)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeIntSet)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeIntSet).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeIntSet).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeIntSet), *b.(*threadUnsafeIntSet), locks
+	}
+
+	locks.sets = [3]*threadSafeIntSet{w, a.(*threadSafeIntSet), b.(*threadSafeIntSet)}
+	locks.distinct = len(lockIntOrder(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeIntSet).s, b.(*threadSafeIntSet).s, locks
+}
+
+func (l intoIntLocks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleIntValues)
+	aValues, bValues := sampleIntValues[:n-1], sampleIntValues[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b IntSet)
+		expected []int
+	}{
+		{"UnionInto", UnionInto, sampleIntValues},
+		{"IntersectInto", IntersectInto, sampleIntValues[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleIntValues[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range intSetFactories() {
+			expected := fillIntSet(newSet(), testCase.expected...)
+
+			dst := fillIntSet(newSet(), sampleIntValues[n-1])
+			testCase.into(dst, fillIntSet(newSet(), aValues...), fillIntSet(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillIntSet(newSet(), aValues...)
+			testCase.into(a, a, fillIntSet(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillIntSet(newSet(), bValues...)
+			testCase.into(b, fillIntSet(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range intSetFactories() {
+		s := fillIntSet(newSet(), sampleIntValues...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchIntSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleIntValues[0])
+	Release(s)
+	Release(NewIntSet(sampleIntValues[0]))
+
+	if again := NewScratchIntSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s IntSet) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s IntSet) error { return json.Unmarshal(b, s) }
+	assertIntRoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewIntSet(sampleIntValues...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewIntSet()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleIntValues[i%len(sampleIntValues)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewIntSet(sampleIntValues...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleIntValues, s)
+	}
+}
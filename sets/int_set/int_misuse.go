@@ -0,0 +1,20 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetint
+
+// unsafeIntGuard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeIntGuard struct{}
+
+func trackIntSet(set *threadUnsafeIntSet) {}
+
+func (set *threadUnsafeIntSet) guardRead() unsafeIntGuard { return unsafeIntGuard{} }
+
+func (set *threadUnsafeIntSet) guardWrite() unsafeIntGuard { return unsafeIntGuard{} }
+
+func (set *threadUnsafeIntSet) guardIterate() unsafeIntGuard { return unsafeIntGuard{} }
+
+func (unsafeIntGuard) done() {}
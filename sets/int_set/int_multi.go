@@ -0,0 +1,110 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...IntSet) IntSet {
+	if len(sets) == 0 {
+		return NewIntSet()
+	}
+	maps, unlock := readLockIntSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeIntSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapIntLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...IntSet) IntSet {
+	if len(sets) == 0 {
+		return NewIntSet()
+	}
+	maps, unlock := readLockIntSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeIntSet()
+	for elem := range maps[smallest] {
+		if containedInAllInt(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapIntLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base IntSet, sets ...IntSet) IntSet {
+	maps, unlock := readLockIntSets(append([]IntSet{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeIntSet()
+	for elem := range maps[0] {
+		if !containedInAnyInt(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapIntLike(base, difference)
+}
+
+func containedInAllInt(maps []threadUnsafeIntSet, skip int, elem int) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyInt(maps []threadUnsafeIntSet, elem int) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapIntLike returns s as a Set of the same implementation as like.
+func wrapIntLike(like IntSet, s threadUnsafeIntSet) IntSet {
+	if _, ok := like.(*threadSafeIntSet); ok {
+		return &threadSafeIntSet{s: s}
+	}
+	trackIntSet(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A IntPair represents a 2-tuple of values.
+type IntPair struct {
+	First  int
+	Second int
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *IntPair) Equal(other IntPair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair IntPair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// IntPairSet is an unordered set of IntPairs, as
+// returned by CartesianProduct.
+type IntPairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p IntPair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...IntPair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(IntPair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other IntPairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p IntPair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []IntPair
+}
+
+type threadUnsafeIntPairSet map[IntPair]struct{}
+
+func newThreadUnsafeIntPairSet() threadUnsafeIntPairSet {
+	return make(threadUnsafeIntPairSet)
+}
+
+func (set *threadUnsafeIntPairSet) Add(p IntPair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeIntPairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeIntPairSet) Contains(p ...IntPair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeIntPairSet) Each(cb func(IntPair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeIntPairSet) Equal(other IntPairSet) bool {
+	_ = other.(*threadUnsafeIntPairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeIntPairSet) Remove(p IntPair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeIntPairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeIntPairSet) ToSlice() []IntPair {
+	keys := make([]IntPair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeIntPairSet struct {
+	s threadUnsafeIntPairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeIntPairSet) Add(p IntPair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeIntPairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeIntPairSet) Contains(p ...IntPair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeIntPairSet) Each(cb func(IntPair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockIntPairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockIntPair does for sets.
+func rlockIntPairSets(x, y *threadSafeIntPairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockIntPairSets releases the locks taken by rlockIntPairSets.
+func runlockIntPairSets(x, y *threadSafeIntPairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeIntPairSet) Equal(other IntPairSet) bool {
+	o := other.(*threadSafeIntPairSet)
+
+	rlockIntPairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockIntPairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeIntPairSet) Remove(p IntPair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeIntPairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeIntPairSet) ToSlice() []IntPair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
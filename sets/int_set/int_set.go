@@ -1,3 +1,5 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
 package mapsetint
 
 // IntSet is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type IntSet interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other IntSet) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type IntSet interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() int
 
-	// Returns all subsets of a given set (Power IntSet).
-	// Not yet supported
-	// PowerSet() IntSet
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []IntSet
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other IntSet) IntSet
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other IntSet) IntPairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []int
@@ -145,7 +154,7 @@ type IntSet interface {
 // NewIntSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewIntSet(s ...int) IntSet {
-	set := newThreadSafeIntSet()
+	set := threadSafeIntSet{s: newThreadUnsafeIntSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewIntSetFromSlice(s []int) IntSet {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeIntSet() IntSet {
 	set := newThreadUnsafeIntSet()
+	trackIntSet(&set)
+	return &set
+}
+
+// NewIntSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewIntSetWithCapacity(n int) IntSet {
+	return &threadSafeIntSet{s: newThreadUnsafeIntSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeIntSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeIntSetWithCapacity(n int) IntSet {
+	set := newThreadUnsafeIntSetWithCapacity(n)
+	trackIntSet(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeIntSet() IntSet {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeIntSetFromSlice(s []int) IntSet {
-	a := NewThreadUnsafeIntSet()
+	a := NewThreadUnsafeIntSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,105 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleIntValues are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleIntValues = []int{
+	-128,
+	-1,
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+}
+
+// intSetFactories returns constructors for both implementations.
+func intSetFactories() map[string]func() IntSet {
+	return map[string]func() IntSet{
+		"safe":   func() IntSet { return NewIntSet() },
+		"unsafe": NewThreadUnsafeIntSet,
+	}
+}
+
+// fillIntSet adds values to s and returns it.
+func fillIntSet(s IntSet, values ...int) IntSet {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertIntRoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertIntRoundTrip(t *testing.T, marshal func(IntSet) ([]byte, error), unmarshal func([]byte, IntSet) error) {
+	t.Helper()
+	contents := [][]int{sampleIntValues, sampleIntValues[:1]}
+	for name, newSet := range intSetFactories() {
+		for _, values := range contents {
+			s := fillIntSet(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range intSetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[int, IntSet]{
+				New:   newSet,
+				Elems: sampleIntValues,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range intSetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleIntValues[0])
+		a.Add(sampleIntValues[1])
+		for _, v := range sampleIntValues {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleIntValues); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(IntPair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
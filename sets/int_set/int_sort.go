@@ -0,0 +1,18 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"sort"
+)
+
+// lessInt orders elements for output that must be stable,
+// such as sorted encodings.
+func lessInt(a, b int) bool {
+	return a < b
+}
+
+// sortIntElements sorts elems in place using lessInt.
+func sortIntElements(elems []int) {
+	sort.Slice(elems, func(i, j int) bool { return lessInt(elems[i], elems[j]) })
+}
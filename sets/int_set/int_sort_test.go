@@ -0,0 +1,26 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]int, 0, len(sampleIntValues))
+	for i := len(sampleIntValues) - 1; i >= 0; i-- {
+		elems = append(elems, sampleIntValues[i])
+	}
+	sortIntElements(elems)
+	for i, elem := range elems {
+		if lessInt(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessInt(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewIntSet(elems...).Equal(NewIntSet(sampleIntValues...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleIntValues, elems)
+	}
+}
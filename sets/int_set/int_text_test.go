@@ -0,0 +1,14 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s IntSet) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s IntSet) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertIntRoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
 package mapsetint
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeIntSet struct {
-	s threadUnsafeIntSet
+	s     threadUnsafeIntSet
+	stats atomic.Pointer[IntSetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeIntSet() threadSafeIntSet {
 	return threadSafeIntSet{s: newThreadUnsafeIntSet()}
 }
 
+// readLockIntSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockIntSets(sets []IntSet) ([]threadUnsafeIntSet, func()) {
+	maps := make([]threadUnsafeIntSet, len(sets))
+	if _, ok := sets[0].(*threadSafeIntSet); !ok {
+		guards := make([]unsafeIntGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeIntSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeIntSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeIntSet))
+	}
+	distinct := lockIntOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeIntSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockIntOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockIntOrder(sets []*threadSafeIntSet) []*threadSafeIntSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockIntPair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockIntPair(x, y *threadSafeIntSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockIntPair releases the locks taken by rlockIntPair.
+func runlockIntPair(x, y *threadSafeIntSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeIntSet) Add(i int) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeIntSet) Contains(i ...int) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeIntSet) IsSubset(other IntSet) bool {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
 func (set *threadSafeIntSet) IsProperSubset(other IntSet) bool {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockIntPair(set, o)
+	defer runlockIntPair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeIntSet) IsProperSuperset(other IntSet) bool {
 func (set *threadSafeIntSet) Union(other IntSet) IntSet {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
+	readIntPair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeIntSet)
 	ret := &threadSafeIntSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
 func (set *threadSafeIntSet) Intersect(other IntSet) IntSet {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
+	readIntPair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeIntSet)
 	ret := &threadSafeIntSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
 func (set *threadSafeIntSet) Difference(other IntSet) IntSet {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
+	readIntPair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeIntSet)
 	ret := &threadSafeIntSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
 func (set *threadSafeIntSet) SymmetricDifference(other IntSet) IntSet {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
+	readIntPair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeIntSet)
 	ret := &threadSafeIntSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
 func (set *threadSafeIntSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeIntSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeIntSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeIntSet) Remove(i int) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyInt(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeIntSet) Cardinality() int {
 }
 
 func (set *threadSafeIntSet) Each(cb func(int) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeIntSet) Iterator() *IntIterator {
 func (set *threadSafeIntSet) Equal(other IntSet) bool {
 	o := other.(*threadSafeIntSet)
 
-	set.RLock()
-	o.RLock()
+	rlockIntPair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockIntPair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeIntSet) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeIntSet) PowerSet() IntSet {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeIntSet)
-    set.RUnlock()
+func (set *threadSafeIntSet) PowerSet() []IntSet {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeIntSet{s: newThreadUnsafeIntSet()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeIntSet)
-        ret.Add(&threadSafeIntSet{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]IntSet, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeIntSet)
+		ret = append(ret, &threadSafeIntSet{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeIntSet) Pop() int {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeIntSet) CartesianProduct(other IntSet) IntSet {
-    o := other.(*threadSafeIntSet)
+func (set *threadSafeIntSet) CartesianProduct(other IntSet) IntPairSet {
+	o := other.(*threadSafeIntSet)
 
-    set.RLock()
-    o.RLock()
+	rlockIntPair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeIntSet)
-    ret := &threadSafeIntSet{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeIntPairSet)
+	ret := &threadSafeIntPairSet{s: *unsafeCartProduct}
+	runlockIntPair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeIntSet) ToSlice() []int {
-	keys := make([]int, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]int, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeIntSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeIntSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
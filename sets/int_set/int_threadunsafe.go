@@ -1,7 +1,10 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
 package mapsetint
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeIntSet map[int]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  int
-	Second int
-}
-
 func newThreadUnsafeIntSet() threadUnsafeIntSet {
 	return make(threadUnsafeIntSet)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeIntSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeIntSetWithCapacity(n int) threadUnsafeIntSet {
+	return make(threadUnsafeIntSet, n)
+}
 
-	return false
+// keyInt returns the map key of i.
+func keyInt(i int) int {
+	return i
 }
 
 func (set *threadUnsafeIntSet) Add(i int) bool {
+	defer set.guardWrite().done()
+	i = keyInt(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeIntSet) Add(i int) bool {
 }
 
 func (set *threadUnsafeIntSet) Contains(i ...int) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyInt(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeIntSet) Contains(i ...int) bool {
 
 func (set *threadUnsafeIntSet) IsSubset(other IntSet) bool {
 	_ = other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeIntSet) IsSubset(other IntSet) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeIntSet) IsProperSubset(other IntSet) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeIntSet) IsSuperset(other IntSet) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeIntSet) IsSuperset(other IntSet) bool {
 }
 
 func (set *threadUnsafeIntSet) IsProperSuperset(other IntSet) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeIntSet) Union(other IntSet) IntSet {
 	o := other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeIntSet()
+	unionedSet := newThreadUnsafeIntSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeIntSet) Union(other IntSet) IntSet {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackIntSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeIntSet) Intersect(other IntSet) IntSet {
 	o := other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeIntSet()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeIntSet) Intersect(other IntSet) IntSet {
 			}
 		}
 	}
+	trackIntSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeIntSet) Difference(other IntSet) IntSet {
 	_ = other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeIntSet()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeIntSet) Difference(other IntSet) IntSet {
 			difference.Add(elem)
 		}
 	}
+	trackIntSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeIntSet) SymmetricDifference(other IntSet) IntSet {
-	_ = other.(*threadUnsafeIntSet)
+	o := other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeIntSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackIntSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeIntSet) Clear() {
-	*set = newThreadUnsafeIntSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeIntSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeIntSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeIntSet) Remove(i int) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyInt(i))
 }
 
 func (set *threadUnsafeIntSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeIntSet) Each(cb func(int) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeIntSet) Each(cb func(int) bool) {
 func (set *threadUnsafeIntSet) Iter() <-chan int {
 	ch := make(chan int)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeIntSet) Iterator() *IntIterator {
 	iterator, ch, stopCh := newIntIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeIntSet) Iterator() *IntIterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeIntSet) Iterator() *IntIterator {
 
 func (set *threadUnsafeIntSet) Equal(other IntSet) bool {
 	_ = other.(*threadUnsafeIntSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeIntSet) Equal(other IntSet) bool {
 }
 
 func (set *threadUnsafeIntSet) Clone() IntSet {
-	clonedSet := newThreadUnsafeIntSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeIntSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackIntSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeIntSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeIntSet) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeIntSet) Pop() int {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeIntSet) Pop() int {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeIntSet) PowerSet() IntSet {
-	powSet := NewThreadUnsafeIntSet()
+func (set *threadUnsafeIntSet) PowerSet() []IntSet {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeIntSet()
-	powSet.Add(&nullset)
+	powSet := []IntSet{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeIntSet()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeIntSet()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeIntSet)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeIntSet) CartesianProduct(other IntSet) IntSet {
+func (set *threadUnsafeIntSet) CartesianProduct(other IntSet) IntPairSet {
 	o := other.(*threadUnsafeIntSet)
-	cartProduct := NewThreadUnsafeIntSet()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeIntPairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := IntPair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeIntSet) ToSlice() []int {
+	defer set.guardRead().done()
 	keys := make([]int, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeIntSet) ToSlice() []int {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeIntSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, IntJSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeIntSet) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeIntSet) UnmarshalJSON(data []byte) error {
 	var i []int
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
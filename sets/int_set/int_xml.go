@@ -0,0 +1,154 @@
+// Code generated by generate_set for int. DO NOT EDIT.
+
+package mapsetint
+
+import (
+	"encoding/xml"
+)
+
+// DefaultIntXMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultIntXMLItemName = "item"
+
+// IntXML adapts a IntSet to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type IntXML struct {
+	Set IntSet
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultIntXMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x IntXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeIntXML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *IntXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeIntXML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x IntXML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultIntXMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeIntXML(e *xml.Encoder, start xml.StartElement, s IntSet, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortIntElements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatIntText(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeIntXML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeIntXML(dec *xml.Decoder, itemName string) ([]int, error) {
+	var elems []int
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseIntText(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultIntXMLItemName. Use IntXML for other
+// names.
+func (set *threadUnsafeIntSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeIntXML(e, start, set, DefaultIntXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeIntSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeIntXML(d, DefaultIntXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeIntSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultIntXMLItemName. Use IntXML for other
+// names.
+func (set *threadSafeIntSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeIntXML(e, start, set, DefaultIntXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeIntSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeIntXML(d, DefaultIntXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeIntSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
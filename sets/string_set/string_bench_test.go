@@ -0,0 +1,86 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s StringSet) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleStringValues[i%len(sampleStringValues)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewStringSet())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeStringSet())
+}
+
+func benchContains(b *testing.B, s StringSet) {
+	for _, v := range sampleStringValues[:len(sampleStringValues)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleStringValues[i%len(sampleStringValues)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewStringSet())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeStringSet())
+}
+
+func benchUnion(b *testing.B, x, y StringSet) {
+	half := len(sampleStringValues) / 2
+	for _, v := range sampleStringValues[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleStringValues[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewStringSet(), NewStringSet())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeStringSet(), NewThreadUnsafeStringSet())
+}
+
+func benchMarshalJSON(b *testing.B, s StringSet) {
+	for _, v := range sampleStringValues {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewStringSet())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeStringSet())
+}
@@ -0,0 +1,127 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"testing"
+)
+
+// stringHybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const stringHybridPrecision = 14
+
+// assertStringHybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertStringHybridCount(t *testing.T, name string, s *StringHybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleStringValues) / 2
+	s := NewStringHybridSet(half, stringHybridPrecision)
+	for _, v := range sampleStringValues[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertStringHybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeStringSetFromSlice(sampleStringValues[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleStringValues[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleStringValues[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertStringHybridCount(t, "converted", s, half+1)
+	s.Add(sampleStringValues[0])
+	assertStringHybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleStringValues) / 2
+	newHybrid := func(sketched bool, elems []string) *StringHybridSet {
+		threshold := len(sampleStringValues)
+		if sketched {
+			threshold = 0
+		}
+		s := NewStringHybridSet(threshold, stringHybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleStringValues[:half+1])
+		other := newHybrid(tc.sketchedO, sampleStringValues[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeStringSetFromSlice(sampleStringValues)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleStringValues, s.Exact())
+		}
+		assertStringHybridCount(t, tc.name, s, len(sampleStringValues))
+		assertStringHybridCount(t, tc.name+" leaves other", other, len(sampleStringValues)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewStringHybridSet(half, stringHybridPrecision)
+	s.Add(sampleStringValues[0])
+	if err := s.Merge(newHybrid(false, sampleStringValues)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertStringHybridCount(t, "merged past the threshold", s, len(sampleStringValues))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleStringValues) / 2
+	for _, threshold := range []int{len(sampleStringValues), 0} {
+		s := NewStringHybridSet(threshold, stringHybridPrecision)
+		for _, v := range sampleStringValues[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleStringValues), 0} {
+			other := NewStringHybridSet(otherThreshold, stringHybridPrecision-2)
+			other.Add(sampleStringValues[len(sampleStringValues)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, stringHybridPrecision, stringHybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeStringSetFromSlice(sampleStringValues[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
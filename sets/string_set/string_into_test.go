@@ -0,0 +1,74 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleStringValues)
+	aValues, bValues := sampleStringValues[:n-1], sampleStringValues[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b StringSet)
+		expected []string
+	}{
+		{"UnionInto", UnionInto, sampleStringValues},
+		{"IntersectInto", IntersectInto, sampleStringValues[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleStringValues[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range stringSetFactories() {
+			expected := fillStringSet(newSet(), testCase.expected...)
+
+			dst := fillStringSet(newSet(), sampleStringValues[n-1])
+			testCase.into(dst, fillStringSet(newSet(), aValues...), fillStringSet(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillStringSet(newSet(), aValues...)
+			testCase.into(a, a, fillStringSet(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillStringSet(newSet(), bValues...)
+			testCase.into(b, fillStringSet(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range stringSetFactories() {
+		s := fillStringSet(newSet(), sampleStringValues...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchStringSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleStringValues[0])
+	Release(s)
+	Release(NewStringSet(sampleStringValues[0]))
+
+	if again := NewScratchStringSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
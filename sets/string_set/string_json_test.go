@@ -0,0 +1,49 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s StringSet) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s StringSet) error { return json.Unmarshal(b, s) }
+	assertStringRoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewStringSet(sampleStringValues...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStringSet()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleStringValues[i%len(sampleStringValues)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewStringSet(sampleStringValues...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleStringValues, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetstring
+
+// unsafeStringGuard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeStringGuard struct{}
+
+func trackStringSet(set *threadUnsafeStringSet) {}
+
+func (set *threadUnsafeStringSet) guardRead() unsafeStringGuard { return unsafeStringGuard{} }
+
+func (set *threadUnsafeStringSet) guardWrite() unsafeStringGuard { return unsafeStringGuard{} }
+
+func (set *threadUnsafeStringSet) guardIterate() unsafeStringGuard { return unsafeStringGuard{} }
+
+func (unsafeStringGuard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...StringSet) StringSet {
+	if len(sets) == 0 {
+		return NewStringSet()
+	}
+	maps, unlock := readLockStringSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeStringSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapStringLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...StringSet) StringSet {
+	if len(sets) == 0 {
+		return NewStringSet()
+	}
+	maps, unlock := readLockStringSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeStringSet()
+	for elem := range maps[smallest] {
+		if containedInAllString(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapStringLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base StringSet, sets ...StringSet) StringSet {
+	maps, unlock := readLockStringSets(append([]StringSet{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeStringSet()
+	for elem := range maps[0] {
+		if !containedInAnyString(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapStringLike(base, difference)
+}
+
+func containedInAllString(maps []threadUnsafeStringSet, skip int, elem string) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyString(maps []threadUnsafeStringSet, elem string) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapStringLike returns s as a Set of the same implementation as like.
+func wrapStringLike(like StringSet, s threadUnsafeStringSet) StringSet {
+	if _, ok := like.(*threadSafeStringSet); ok {
+		return &threadSafeStringSet{s: s}
+	}
+	trackStringSet(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A StringPair represents a 2-tuple of values.
+type StringPair struct {
+	First  string
+	Second string
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *StringPair) Equal(other StringPair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair StringPair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// StringPairSet is an unordered set of StringPairs, as
+// returned by CartesianProduct.
+type StringPairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p StringPair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...StringPair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(StringPair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other StringPairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p StringPair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []StringPair
+}
+
+type threadUnsafeStringPairSet map[StringPair]struct{}
+
+func newThreadUnsafeStringPairSet() threadUnsafeStringPairSet {
+	return make(threadUnsafeStringPairSet)
+}
+
+func (set *threadUnsafeStringPairSet) Add(p StringPair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeStringPairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeStringPairSet) Contains(p ...StringPair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeStringPairSet) Each(cb func(StringPair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeStringPairSet) Equal(other StringPairSet) bool {
+	_ = other.(*threadUnsafeStringPairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeStringPairSet) Remove(p StringPair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeStringPairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeStringPairSet) ToSlice() []StringPair {
+	keys := make([]StringPair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeStringPairSet struct {
+	s threadUnsafeStringPairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeStringPairSet) Add(p StringPair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeStringPairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeStringPairSet) Contains(p ...StringPair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeStringPairSet) Each(cb func(StringPair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockStringPairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockStringPair does for sets.
+func rlockStringPairSets(x, y *threadSafeStringPairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockStringPairSets releases the locks taken by rlockStringPairSets.
+func runlockStringPairSets(x, y *threadSafeStringPairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeStringPairSet) Equal(other StringPairSet) bool {
+	o := other.(*threadSafeStringPairSet)
+
+	rlockStringPairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockStringPairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeStringPairSet) Remove(p StringPair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeStringPairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeStringPairSet) ToSlice() []StringPair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
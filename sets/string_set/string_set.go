@@ -1,3 +1,5 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
 package mapsetstring
 
 // StringSet is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type StringSet interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other StringSet) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type StringSet interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() string
 
-	// Returns all subsets of a given set (Power StringSet).
-	// Not yet supported
-	// PowerSet() StringSet
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []StringSet
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other StringSet) StringSet
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other StringSet) StringPairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []string
@@ -145,7 +154,7 @@ type StringSet interface {
 // NewStringSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewStringSet(s ...string) StringSet {
-	set := newThreadSafeStringSet()
+	set := threadSafeStringSet{s: newThreadUnsafeStringSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewStringSetFromSlice(s []string) StringSet {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeStringSet() StringSet {
 	set := newThreadUnsafeStringSet()
+	trackStringSet(&set)
+	return &set
+}
+
+// NewStringSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewStringSetWithCapacity(n int) StringSet {
+	return &threadSafeStringSet{s: newThreadUnsafeStringSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeStringSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeStringSetWithCapacity(n int) StringSet {
+	set := newThreadUnsafeStringSetWithCapacity(n)
+	trackStringSet(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeStringSet() StringSet {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeStringSetFromSlice(s []string) StringSet {
-	a := NewThreadUnsafeStringSet()
+	a := NewThreadUnsafeStringSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,105 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleStringValues are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleStringValues = []string{
+	"",
+	"a",
+	"b",
+	"hello world",
+	"comma,separated",
+	"quoted \"value\"",
+	"<tag>&amp;",
+	"ünïcödé",
+}
+
+// stringSetFactories returns constructors for both implementations.
+func stringSetFactories() map[string]func() StringSet {
+	return map[string]func() StringSet{
+		"safe":   func() StringSet { return NewStringSet() },
+		"unsafe": NewThreadUnsafeStringSet,
+	}
+}
+
+// fillStringSet adds values to s and returns it.
+func fillStringSet(s StringSet, values ...string) StringSet {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertStringRoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertStringRoundTrip(t *testing.T, marshal func(StringSet) ([]byte, error), unmarshal func([]byte, StringSet) error) {
+	t.Helper()
+	contents := [][]string{sampleStringValues, sampleStringValues[:1]}
+	for name, newSet := range stringSetFactories() {
+		for _, values := range contents {
+			s := fillStringSet(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range stringSetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[string, StringSet]{
+				New:   newSet,
+				Elems: sampleStringValues,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range stringSetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleStringValues[0])
+		a.Add(sampleStringValues[1])
+		for _, v := range sampleStringValues {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleStringValues); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(StringPair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
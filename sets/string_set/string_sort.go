@@ -0,0 +1,18 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"sort"
+)
+
+// lessString orders elements for output that must be stable,
+// such as sorted encodings.
+func lessString(a, b string) bool {
+	return a < b
+}
+
+// sortStringElements sorts elems in place using lessString.
+func sortStringElements(elems []string) {
+	sort.Slice(elems, func(i, j int) bool { return lessString(elems[i], elems[j]) })
+}
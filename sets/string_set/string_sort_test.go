@@ -0,0 +1,26 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]string, 0, len(sampleStringValues))
+	for i := len(sampleStringValues) - 1; i >= 0; i-- {
+		elems = append(elems, sampleStringValues[i])
+	}
+	sortStringElements(elems)
+	for i, elem := range elems {
+		if lessString(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessString(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewStringSet(elems...).Equal(NewStringSet(sampleStringValues...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleStringValues, elems)
+	}
+}
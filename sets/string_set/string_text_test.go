@@ -0,0 +1,14 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s StringSet) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s StringSet) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertStringRoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
 package mapsetstring
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeStringSet struct {
-	s threadUnsafeStringSet
+	s     threadUnsafeStringSet
+	stats atomic.Pointer[StringSetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeStringSet() threadSafeStringSet {
 	return threadSafeStringSet{s: newThreadUnsafeStringSet()}
 }
 
+// readLockStringSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockStringSets(sets []StringSet) ([]threadUnsafeStringSet, func()) {
+	maps := make([]threadUnsafeStringSet, len(sets))
+	if _, ok := sets[0].(*threadSafeStringSet); !ok {
+		guards := make([]unsafeStringGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeStringSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeStringSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeStringSet))
+	}
+	distinct := lockStringOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeStringSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockStringOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockStringOrder(sets []*threadSafeStringSet) []*threadSafeStringSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockStringPair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockStringPair(x, y *threadSafeStringSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockStringPair releases the locks taken by rlockStringPair.
+func runlockStringPair(x, y *threadSafeStringSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeStringSet) Add(i string) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeStringSet) Contains(i ...string) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeStringSet) IsSubset(other StringSet) bool {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
 func (set *threadSafeStringSet) IsProperSubset(other StringSet) bool {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockStringPair(set, o)
+	defer runlockStringPair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeStringSet) IsProperSuperset(other StringSet) bool {
 func (set *threadSafeStringSet) Union(other StringSet) StringSet {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
+	readStringPair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeStringSet)
 	ret := &threadSafeStringSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
 func (set *threadSafeStringSet) Intersect(other StringSet) StringSet {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
+	readStringPair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeStringSet)
 	ret := &threadSafeStringSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
 func (set *threadSafeStringSet) Difference(other StringSet) StringSet {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
+	readStringPair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeStringSet)
 	ret := &threadSafeStringSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
 func (set *threadSafeStringSet) SymmetricDifference(other StringSet) StringSet {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
+	readStringPair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeStringSet)
 	ret := &threadSafeStringSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
 func (set *threadSafeStringSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeStringSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeStringSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeStringSet) Remove(i string) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyString(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeStringSet) Cardinality() int {
 }
 
 func (set *threadSafeStringSet) Each(cb func(string) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeStringSet) Iterator() *StringIterator {
 func (set *threadSafeStringSet) Equal(other StringSet) bool {
 	o := other.(*threadSafeStringSet)
 
-	set.RLock()
-	o.RLock()
+	rlockStringPair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockStringPair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeStringSet) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeStringSet) PowerSet() StringSet {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeStringSet)
-    set.RUnlock()
+func (set *threadSafeStringSet) PowerSet() []StringSet {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeStringSet{s: newThreadUnsafeStringSet()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeStringSet)
-        ret.Add(&threadSafeStringSet{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]StringSet, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeStringSet)
+		ret = append(ret, &threadSafeStringSet{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeStringSet) Pop() string {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeStringSet) CartesianProduct(other StringSet) StringSet {
-    o := other.(*threadSafeStringSet)
+func (set *threadSafeStringSet) CartesianProduct(other StringSet) StringPairSet {
+	o := other.(*threadSafeStringSet)
 
-    set.RLock()
-    o.RLock()
+	rlockStringPair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeStringSet)
-    ret := &threadSafeStringSet{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeStringPairSet)
+	ret := &threadSafeStringPairSet{s: *unsafeCartProduct}
+	runlockStringPair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeStringSet) ToSlice() []string {
-	keys := make([]string, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]string, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeStringSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeStringSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
 package mapsetstring
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeStringSet map[string]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  string
-	Second string
-}
-
 func newThreadUnsafeStringSet() threadUnsafeStringSet {
 	return make(threadUnsafeStringSet)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeStringSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeStringSetWithCapacity(n int) threadUnsafeStringSet {
+	return make(threadUnsafeStringSet, n)
+}
 
-	return false
+// keyString returns the map key of i.
+func keyString(i string) string {
+	return i
 }
 
 func (set *threadUnsafeStringSet) Add(i string) bool {
+	defer set.guardWrite().done()
+	i = keyString(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeStringSet) Add(i string) bool {
 }
 
 func (set *threadUnsafeStringSet) Contains(i ...string) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyString(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeStringSet) Contains(i ...string) bool {
 
 func (set *threadUnsafeStringSet) IsSubset(other StringSet) bool {
 	_ = other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeStringSet) IsSubset(other StringSet) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeStringSet) IsProperSubset(other StringSet) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeStringSet) IsSuperset(other StringSet) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeStringSet) IsSuperset(other StringSet) bool {
 }
 
 func (set *threadUnsafeStringSet) IsProperSuperset(other StringSet) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeStringSet) Union(other StringSet) StringSet {
 	o := other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeStringSet()
+	unionedSet := newThreadUnsafeStringSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeStringSet) Union(other StringSet) StringSet {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackStringSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeStringSet) Intersect(other StringSet) StringSet {
 	o := other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeStringSet()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeStringSet) Intersect(other StringSet) StringSet {
 			}
 		}
 	}
+	trackStringSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeStringSet) Difference(other StringSet) StringSet {
 	_ = other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeStringSet()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeStringSet) Difference(other StringSet) StringSet {
 			difference.Add(elem)
 		}
 	}
+	trackStringSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeStringSet) SymmetricDifference(other StringSet) StringSet {
-	_ = other.(*threadUnsafeStringSet)
+	o := other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeStringSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackStringSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeStringSet) Clear() {
-	*set = newThreadUnsafeStringSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeStringSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeStringSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeStringSet) Remove(i string) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyString(i))
 }
 
 func (set *threadUnsafeStringSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeStringSet) Each(cb func(string) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeStringSet) Each(cb func(string) bool) {
 func (set *threadUnsafeStringSet) Iter() <-chan string {
 	ch := make(chan string)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeStringSet) Iterator() *StringIterator {
 	iterator, ch, stopCh := newStringIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeStringSet) Iterator() *StringIterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeStringSet) Iterator() *StringIterator {
 
 func (set *threadUnsafeStringSet) Equal(other StringSet) bool {
 	_ = other.(*threadUnsafeStringSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeStringSet) Equal(other StringSet) bool {
 }
 
 func (set *threadUnsafeStringSet) Clone() StringSet {
-	clonedSet := newThreadUnsafeStringSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeStringSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackStringSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeStringSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeStringSet) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeStringSet) Pop() string {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeStringSet) Pop() string {
 	return ""
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeStringSet) PowerSet() StringSet {
-	powSet := NewThreadUnsafeStringSet()
+func (set *threadUnsafeStringSet) PowerSet() []StringSet {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeStringSet()
-	powSet.Add(&nullset)
+	powSet := []StringSet{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeStringSet()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeStringSet()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeStringSet)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeStringSet) CartesianProduct(other StringSet) StringSet {
+func (set *threadUnsafeStringSet) CartesianProduct(other StringSet) StringPairSet {
 	o := other.(*threadUnsafeStringSet)
-	cartProduct := NewThreadUnsafeStringSet()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeStringPairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := StringPair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeStringSet) ToSlice() []string {
+	defer set.guardRead().done()
 	keys := make([]string, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeStringSet) ToSlice() []string {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeStringSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, StringJSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeStringSet) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeStringSet) UnmarshalJSON(data []byte) error {
 	var i []string
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
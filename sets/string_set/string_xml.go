@@ -0,0 +1,154 @@
+// Code generated by generate_set for string. DO NOT EDIT.
+
+package mapsetstring
+
+import (
+	"encoding/xml"
+)
+
+// DefaultStringXMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultStringXMLItemName = "item"
+
+// StringXML adapts a StringSet to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type StringXML struct {
+	Set StringSet
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultStringXMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x StringXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeStringXML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *StringXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeStringXML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x StringXML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultStringXMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeStringXML(e *xml.Encoder, start xml.StartElement, s StringSet, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortStringElements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatStringText(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeStringXML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeStringXML(dec *xml.Decoder, itemName string) ([]string, error) {
+	var elems []string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseStringText(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultStringXMLItemName. Use StringXML for other
+// names.
+func (set *threadUnsafeStringSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeStringXML(e, start, set, DefaultStringXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeStringSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeStringXML(d, DefaultStringXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeStringSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultStringXMLItemName. Use StringXML for other
+// names.
+func (set *threadSafeStringSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeStringXML(e, start, set, DefaultStringXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeStringSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeStringXML(d, DefaultStringXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeStringSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s TimeTimeSet) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleTimeTimeValues[i%len(sampleTimeTimeValues)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewTimeTimeSet())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeTimeTimeSet())
+}
+
+func benchContains(b *testing.B, s TimeTimeSet) {
+	for _, v := range sampleTimeTimeValues[:len(sampleTimeTimeValues)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleTimeTimeValues[i%len(sampleTimeTimeValues)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewTimeTimeSet())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeTimeTimeSet())
+}
+
+func benchUnion(b *testing.B, x, y TimeTimeSet) {
+	half := len(sampleTimeTimeValues) / 2
+	for _, v := range sampleTimeTimeValues[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleTimeTimeValues[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewTimeTimeSet(), NewTimeTimeSet())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeTimeTimeSet(), NewThreadUnsafeTimeTimeSet())
+}
+
+func benchMarshalJSON(b *testing.B, s TimeTimeSet) {
+	for _, v := range sampleTimeTimeValues {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewTimeTimeSet())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeTimeTimeSet())
+}
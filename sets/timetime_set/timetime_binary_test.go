@@ -0,0 +1,42 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"encoding"
+	"testing"
+	"time"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s TimeTimeSet) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s TimeTimeSet) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertTimeTimeRoundTrip(t, marshal, unmarshal)
+}
+
+func TestBinaryTimes(t *testing.T) {
+	// The encoding keeps UTC and Local times as they were, so that they
+	// decode as the same elements.
+	now := time.Now()
+	utc := time.Date(2020, 2, 29, 7, 30, 0, 0, time.UTC)
+	for name, newSet := range timetimeSetFactories() {
+		s := newSet()
+		s.Add(now)
+		s.Add(utc)
+		b, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := newSet()
+		if err := decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(s) || !decoded.Contains(now, utc) {
+			t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+		}
+		decoded.Remove(now)
+		if decoded.Contains(now) || decoded.Cardinality() != 1 {
+			t.Errorf("%s: expected to remove %v from %v", name, now, decoded)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeTimeTimeSet()
+		return &s
+	},
+}
+
+// NewScratchTimeTimeSet returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchTimeTimeSet() TimeTimeSet {
+	return scratchPool.Get().(*threadUnsafeTimeTimeSet)
+}
+
+// Release empties s and makes it available to NewScratchTimeTimeSet. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s TimeTimeSet) {
+	u, ok := s.(*threadUnsafeTimeTimeSet)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b TimeTimeSet) {
+	d, x, y, locks := lockTimeTimeInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllTimeTime(d, y)
+	case dst == b:
+		addAllTimeTime(d, x)
+	default:
+		clear(d)
+		addAllTimeTime(d, x)
+		addAllTimeTime(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b TimeTimeSet) {
+	d, x, y, locks := lockTimeTimeInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyTimeTime(d, y)
+	case dst == b:
+		keepOnlyTimeTime(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b TimeTimeSet) {
+	d, x, y, locks := lockTimeTimeInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchTimeTimeSet().(*threadUnsafeTimeTimeSet)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllTimeTime(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllTimeTime(dst, src threadUnsafeTimeTimeSet) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyTimeTime removes the elements of dst that are not in other.
+func keepOnlyTimeTime(dst, other threadUnsafeTimeTimeSet) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoTimeTimeLocks records the locks taken by lockTimeTimeInto, or the guards for
+// thread-unsafe sets.
+type intoTimeTimeLocks struct {
+	sets     [3]*threadSafeTimeTimeSet
+	distinct int
+	dst      *threadSafeTimeTimeSet
+	guards   [3]unsafeTimeTimeGuard
+}
+
+// lockTimeTimeInto locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockTimeTimeInto(dst, a, b TimeTimeSet) (d, x, y threadUnsafeTimeTimeSet, locks intoTimeTimeLocks) {
+	w, ok := dst.(*threadSafeTimeTimeSet)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeTimeTimeSet)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeTimeTimeSet).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeTimeTimeSet).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeTimeTimeSet), *b.(*threadUnsafeTimeTimeSet), locks
+	}
+
+	locks.sets = [3]*threadSafeTimeTimeSet{w, a.(*threadSafeTimeTimeSet), b.(*threadSafeTimeTimeSet)}
+	locks.distinct = len(lockTimeTimeOrder(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeTimeTimeSet).s, b.(*threadSafeTimeTimeSet).s, locks
+}
+
+func (l intoTimeTimeLocks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
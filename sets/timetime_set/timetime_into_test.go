@@ -0,0 +1,75 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleTimeTimeValues)
+	aValues, bValues := sampleTimeTimeValues[:n-1], sampleTimeTimeValues[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b TimeTimeSet)
+		expected []time.Time
+	}{
+		{"UnionInto", UnionInto, sampleTimeTimeValues},
+		{"IntersectInto", IntersectInto, sampleTimeTimeValues[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleTimeTimeValues[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range timetimeSetFactories() {
+			expected := fillTimeTimeSet(newSet(), testCase.expected...)
+
+			dst := fillTimeTimeSet(newSet(), sampleTimeTimeValues[n-1])
+			testCase.into(dst, fillTimeTimeSet(newSet(), aValues...), fillTimeTimeSet(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillTimeTimeSet(newSet(), aValues...)
+			testCase.into(a, a, fillTimeTimeSet(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillTimeTimeSet(newSet(), bValues...)
+			testCase.into(b, fillTimeTimeSet(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range timetimeSetFactories() {
+		s := fillTimeTimeSet(newSet(), sampleTimeTimeValues...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchTimeTimeSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleTimeTimeValues[0])
+	Release(s)
+	Release(NewTimeTimeSet(sampleTimeTimeValues[0]))
+
+	if again := NewScratchTimeTimeSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s TimeTimeSet) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s TimeTimeSet) error { return json.Unmarshal(b, s) }
+	assertTimeTimeRoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewTimeTimeSet(sampleTimeTimeValues...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewTimeTimeSet()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleTimeTimeValues[i%len(sampleTimeTimeValues)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewTimeTimeSet(sampleTimeTimeValues...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleTimeTimeValues, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsettimetime
+
+// unsafeTimeTimeGuard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeTimeTimeGuard struct{}
+
+func trackTimeTimeSet(set *threadUnsafeTimeTimeSet) {}
+
+func (set *threadUnsafeTimeTimeSet) guardRead() unsafeTimeTimeGuard { return unsafeTimeTimeGuard{} }
+
+func (set *threadUnsafeTimeTimeSet) guardWrite() unsafeTimeTimeGuard { return unsafeTimeTimeGuard{} }
+
+func (set *threadUnsafeTimeTimeSet) guardIterate() unsafeTimeTimeGuard { return unsafeTimeTimeGuard{} }
+
+func (unsafeTimeTimeGuard) done() {}
@@ -0,0 +1,114 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"time"
+)
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...TimeTimeSet) TimeTimeSet {
+	if len(sets) == 0 {
+		return NewTimeTimeSet()
+	}
+	maps, unlock := readLockTimeTimeSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeTimeTimeSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapTimeTimeLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...TimeTimeSet) TimeTimeSet {
+	if len(sets) == 0 {
+		return NewTimeTimeSet()
+	}
+	maps, unlock := readLockTimeTimeSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeTimeTimeSet()
+	for elem := range maps[smallest] {
+		if containedInAllTimeTime(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapTimeTimeLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base TimeTimeSet, sets ...TimeTimeSet) TimeTimeSet {
+	maps, unlock := readLockTimeTimeSets(append([]TimeTimeSet{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeTimeTimeSet()
+	for elem := range maps[0] {
+		if !containedInAnyTimeTime(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapTimeTimeLike(base, difference)
+}
+
+func containedInAllTimeTime(maps []threadUnsafeTimeTimeSet, skip int, elem time.Time) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyTimeTime(maps []threadUnsafeTimeTimeSet, elem time.Time) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapTimeTimeLike returns s as a Set of the same implementation as like.
+func wrapTimeTimeLike(like TimeTimeSet, s threadUnsafeTimeTimeSet) TimeTimeSet {
+	if _, ok := like.(*threadSafeTimeTimeSet); ok {
+		return &threadSafeTimeTimeSet{s: s}
+	}
+	trackTimeTimeSet(&s)
+	return &s
+}
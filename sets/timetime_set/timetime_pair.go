@@ -0,0 +1,225 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// A TimeTimePair represents a 2-tuple of values.
+type TimeTimePair struct {
+	First  time.Time
+	Second time.Time
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *TimeTimePair) Equal(other TimeTimePair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair TimeTimePair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// TimeTimePairSet is an unordered set of TimeTimePairs, as
+// returned by CartesianProduct.
+type TimeTimePairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p TimeTimePair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...TimeTimePair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(TimeTimePair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other TimeTimePairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p TimeTimePair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []TimeTimePair
+}
+
+type threadUnsafeTimeTimePairSet map[TimeTimePair]struct{}
+
+func newThreadUnsafeTimeTimePairSet() threadUnsafeTimeTimePairSet {
+	return make(threadUnsafeTimeTimePairSet)
+}
+
+func (set *threadUnsafeTimeTimePairSet) Add(p TimeTimePair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeTimeTimePairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeTimeTimePairSet) Contains(p ...TimeTimePair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeTimeTimePairSet) Each(cb func(TimeTimePair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeTimeTimePairSet) Equal(other TimeTimePairSet) bool {
+	_ = other.(*threadUnsafeTimeTimePairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeTimeTimePairSet) Remove(p TimeTimePair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeTimeTimePairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeTimeTimePairSet) ToSlice() []TimeTimePair {
+	keys := make([]TimeTimePair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeTimeTimePairSet struct {
+	s threadUnsafeTimeTimePairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeTimeTimePairSet) Add(p TimeTimePair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeTimeTimePairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeTimeTimePairSet) Contains(p ...TimeTimePair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeTimeTimePairSet) Each(cb func(TimeTimePair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockTimeTimePairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockTimeTimePair does for sets.
+func rlockTimeTimePairSets(x, y *threadSafeTimeTimePairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockTimeTimePairSets releases the locks taken by rlockTimeTimePairSets.
+func runlockTimeTimePairSets(x, y *threadSafeTimeTimePairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeTimeTimePairSet) Equal(other TimeTimePairSet) bool {
+	o := other.(*threadSafeTimeTimePairSet)
+
+	rlockTimeTimePairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockTimeTimePairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeTimeTimePairSet) Remove(p TimeTimePair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeTimeTimePairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeTimeTimePairSet) ToSlice() []TimeTimePair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
 package mapsettimetime
 
 import (
@@ -9,7 +11,13 @@ import (
 // operations that can be applied to that set.
 type TimeTimeSet interface {
 	// Adds an element to the set. Returns whether
-	// the item was added.
+	// the item was added. A time is stored as
+	// i.Round(0), without its monotonic clock
+	// reading. Its location is kept, so the same
+	// instant in two locations is two elements, as
+	// with ==. Encodings keep a time's offset but
+	// not its *time.Location, so a time outside UTC
+	// and Local decodes as a different element.
 	Add(i time.Time) bool
 
 	// Returns the number of elements in the set.
@@ -49,6 +57,10 @@ type TimeTimeSet interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other TimeTimeSet) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -134,13 +146,16 @@ type TimeTimeSet interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() time.Time
 
-	// Returns all subsets of a given set (Power TimeTimeSet).
-	// Not yet supported
-	// PowerSet() TimeTimeSet
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []TimeTimeSet
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other TimeTimeSet) TimeTimeSet
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other TimeTimeSet) TimeTimePairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []time.Time
@@ -149,7 +164,7 @@ type TimeTimeSet interface {
 // NewTimeTimeSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewTimeTimeSet(s ...time.Time) TimeTimeSet {
-	set := newThreadSafeTimeTimeSet()
+	set := threadSafeTimeTimeSet{s: newThreadUnsafeTimeTimeSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -173,6 +188,23 @@ func NewTimeTimeSetFromSlice(s []time.Time) TimeTimeSet {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeTimeTimeSet() TimeTimeSet {
 	set := newThreadUnsafeTimeTimeSet()
+	trackTimeTimeSet(&set)
+	return &set
+}
+
+// NewTimeTimeSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewTimeTimeSetWithCapacity(n int) TimeTimeSet {
+	return &threadSafeTimeTimeSet{s: newThreadUnsafeTimeTimeSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeTimeTimeSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeTimeTimeSetWithCapacity(n int) TimeTimeSet {
+	set := newThreadUnsafeTimeTimeSetWithCapacity(n)
+	trackTimeTimeSet(&set)
 	return &set
 }
 
@@ -180,7 +212,7 @@ func NewThreadUnsafeTimeTimeSet() TimeTimeSet {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeTimeTimeSetFromSlice(s []time.Time) TimeTimeSet {
-	a := NewThreadUnsafeTimeTimeSet()
+	a := NewThreadUnsafeTimeTimeSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
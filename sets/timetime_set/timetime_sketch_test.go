@@ -0,0 +1,59 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFiltersFindSetElements(t *testing.T) {
+	s := NewTimeTimeSet(sampleTimeTimeValues...)
+	queries := s.ToSlice()
+
+	// Times with a monotonic reading or in another location must be
+	// found as the set stores them.
+	now := time.Now()
+	zoned := time.Date(2020, 2, 29, 12, 30, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	s.Add(now)
+	s.Add(zoned)
+	queries = append(queries, now, zoned)
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range queries {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+
+	later := now.Add(time.Hour).In(time.FixedZone("UTC-3", -3*60*60))
+	bloom.Add(later)
+	if !bloom.MightContain(later) || !cuckoo.Add(later) || !cuckoo.MightContain(later) {
+		t.Errorf("expected %v in both filters after adding it", later)
+	}
+	if !cuckoo.Delete(now) {
+		t.Errorf("expected to delete %v from the cuckoo filter", now)
+	}
+}
+
+func TestToCuckooFilter(t *testing.T) {
+	f, err := ToCuckooFilter(NewTimeTimeSet(sampleTimeTimeValues...), 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != len(sampleTimeTimeValues) {
+		t.Errorf("expected %d elements, got %d", len(sampleTimeTimeValues), f.Len())
+	}
+	for _, v := range sampleTimeTimeValues {
+		if !f.MightContain(v) {
+			t.Errorf("expected %v in the filter", v)
+		}
+	}
+}
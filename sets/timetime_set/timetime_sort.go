@@ -0,0 +1,19 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"sort"
+	"time"
+)
+
+// lessTimeTime orders elements for output that must be stable,
+// such as sorted encodings.
+func lessTimeTime(a, b time.Time) bool {
+	return a.Before(b)
+}
+
+// sortTimeTimeElements sorts elems in place using lessTimeTime.
+func sortTimeTimeElements(elems []time.Time) {
+	sort.Slice(elems, func(i, j int) bool { return lessTimeTime(elems[i], elems[j]) })
+}
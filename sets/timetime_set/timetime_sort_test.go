@@ -0,0 +1,27 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]time.Time, 0, len(sampleTimeTimeValues))
+	for i := len(sampleTimeTimeValues) - 1; i >= 0; i-- {
+		elems = append(elems, sampleTimeTimeValues[i])
+	}
+	sortTimeTimeElements(elems)
+	for i, elem := range elems {
+		if lessTimeTime(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessTimeTime(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewTimeTimeSet(elems...).Equal(NewTimeTimeSet(sampleTimeTimeValues...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleTimeTimeValues, elems)
+	}
+}
@@ -0,0 +1,269 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeTimeTextFormat describes how a set is written to and read from a
+// single line of text, such as a command line flag or an environment
+// variable.
+//
+// Elements are joined by Separator. Occurrences of the separator, of a
+// double quote or of a backslash inside an element are escaped with a
+// backslash, and an empty element is written as "" so that a set of
+// just the empty element is not read back as an empty set.
+type TimeTimeTextFormat struct {
+	// Separator delimits elements. Defaults to ",".
+	Separator string
+
+	// Quote writes string elements using strconv.Quote.
+	// It has no effect on time.Time elements.
+	Quote bool
+}
+
+// DefaultTimeTimeTextFormat is the format used by MarshalText,
+// UnmarshalText and NewTimeTimeFlagValue.
+var DefaultTimeTimeTextFormat = TimeTimeTextFormat{Separator: ","}
+
+var errTextEscape = errors.New("mapsettimetime: text ends with an unfinished escape")
+var errTextQuote = errors.New("mapsettimetime: text has an unterminated quote")
+
+// Marshal renders the elements of s as text, in sorted order.
+func (format TimeTimeTextFormat) Marshal(s TimeTimeSet) ([]byte, error) {
+	elems := s.ToSlice()
+	sortTimeTimeElements(elems)
+
+	items := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		item, err := formatTimeTimeText(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, format.escape(item))
+	}
+	return []byte(strings.Join(items, format.separator())), nil
+}
+
+// Unmarshal adds the elements read from text to s. Nothing is added if
+// any element fails to parse. Empty text holds no elements.
+func (format TimeTimeTextFormat) Unmarshal(text []byte, s TimeTimeSet) error {
+	elems, err := format.parse(string(text))
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func (format TimeTimeTextFormat) separator() string {
+	if format.Separator == "" {
+		return ","
+	}
+	return format.Separator
+}
+
+func (format TimeTimeTextFormat) parse(text string) ([]time.Time, error) {
+	fields, err := format.split(text)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]time.Time, 0, len(fields))
+	for _, field := range fields {
+		item, err := format.unescape(field)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := parseTimeTimeText(item)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// split cuts text at every separator that is neither escaped nor, when
+// quoting, inside quotes. Escapes and quotes are kept in the fields.
+func (format TimeTimeTextFormat) split(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	sep := format.separator()
+	var fields []string
+	var field strings.Builder
+	inQuote := false
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\':
+			if i+1 == len(text) {
+				return nil, errTextEscape
+			}
+			field.WriteString(text[i : i+2])
+			i += 2
+			continue
+		case format.Quote && c == '"':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(text[i:], sep):
+			fields = append(fields, field.String())
+			field.Reset()
+			i += len(sep)
+			continue
+		}
+		field.WriteByte(text[i])
+		i++
+	}
+	if inQuote {
+		return nil, errTextQuote
+	}
+	return append(fields, field.String()), nil
+}
+
+func (format TimeTimeTextFormat) escape(item string) string {
+	if item == "" {
+		return `""`
+	}
+	sep := format.separator()
+	if !strings.ContainsAny(item, `\"`) && !strings.Contains(item, sep) {
+		return item
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(item); {
+		if strings.HasPrefix(item[i:], sep) {
+			for j := 0; j < len(sep); j++ {
+				b.WriteByte('\\')
+				b.WriteByte(sep[j])
+			}
+			i += len(sep)
+			continue
+		}
+		if c := item[i]; c == '\\' || c == '"' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(item[i])
+		i++
+	}
+	return b.String()
+}
+
+func (format TimeTimeTextFormat) unescape(field string) (string, error) {
+	if format.Quote {
+		if quoted := strings.TrimSpace(field); strings.HasPrefix(quoted, `"`) {
+			return strconv.Unquote(quoted)
+		}
+	}
+	if field == `""` {
+		return "", nil
+	}
+	if !strings.Contains(field, `\`) {
+		return field, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' {
+			i++
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String(), nil
+}
+
+// formatTimeTimeText renders a single element as text.
+func formatTimeTimeText(elem time.Time) (string, error) {
+	return elem.Format(time.RFC3339Nano), nil
+}
+
+// parseTimeTimeText parses a single element rendered by
+// formatTimeTimeText. Surrounding white space is ignored.
+func parseTimeTimeText(item string) (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(item))
+}
+
+// TimeTimeFlagValue adapts a TimeTimeSet to the flag.Value interface.
+// Every occurrence of the flag adds its elements to the set, so both
+// -flag=a,b and -flag=a -flag=b collect the same elements.
+type TimeTimeFlagValue struct {
+	set    TimeTimeSet
+	Format TimeTimeTextFormat
+}
+
+// NewTimeTimeFlagValue returns a flag.Value that adds elements to s using
+// DefaultTimeTimeTextFormat.
+func NewTimeTimeFlagValue(s TimeTimeSet) *TimeTimeFlagValue {
+	return &TimeTimeFlagValue{set: s, Format: DefaultTimeTimeTextFormat}
+}
+
+// String renders the set as text, as required by flag.Value.
+func (v *TimeTimeFlagValue) String() string {
+	if v == nil || v.set == nil {
+		return ""
+	}
+	b, err := v.Format.Marshal(v.set)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set adds the elements read from text to the set, as required by
+// flag.Value.
+func (v *TimeTimeFlagValue) Set(text string) error {
+	return v.Format.Unmarshal([]byte(text), v.set)
+}
+
+// MarshalText renders the set using DefaultTimeTimeTextFormat.
+func (set *threadUnsafeTimeTimeSet) MarshalText() ([]byte, error) {
+	return DefaultTimeTimeTextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultTimeTimeTextFormat. The set is left untouched
+// on error.
+func (set *threadUnsafeTimeTimeSet) UnmarshalText(text []byte) error {
+	elems, err := DefaultTimeTimeTextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeTimeTimeSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalText renders the set using DefaultTimeTimeTextFormat.
+func (set *threadSafeTimeTimeSet) MarshalText() ([]byte, error) {
+	return DefaultTimeTimeTextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultTimeTimeTextFormat. The set is left untouched
+// on error.
+func (set *threadSafeTimeTimeSet) UnmarshalText(text []byte) error {
+	elems, err := DefaultTimeTimeTextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeTimeTimeSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
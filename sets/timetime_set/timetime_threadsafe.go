@@ -1,12 +1,18 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
 package mapsettimetime
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
+
 	"time"
 )
 
 type threadSafeTimeTimeSet struct {
-	s threadUnsafeTimeTimeSet
+	s     threadUnsafeTimeTimeSet
+	stats atomic.Pointer[TimeTimeSetStats]
 	sync.RWMutex
 }
 
@@ -14,39 +20,129 @@ func newThreadSafeTimeTimeSet() threadSafeTimeTimeSet {
 	return threadSafeTimeTimeSet{s: newThreadUnsafeTimeTimeSet()}
 }
 
+// readLockTimeTimeSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockTimeTimeSets(sets []TimeTimeSet) ([]threadUnsafeTimeTimeSet, func()) {
+	maps := make([]threadUnsafeTimeTimeSet, len(sets))
+	if _, ok := sets[0].(*threadSafeTimeTimeSet); !ok {
+		guards := make([]unsafeTimeTimeGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeTimeTimeSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeTimeTimeSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeTimeTimeSet))
+	}
+	distinct := lockTimeTimeOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeTimeTimeSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockTimeTimeOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockTimeTimeOrder(sets []*threadSafeTimeTimeSet) []*threadSafeTimeTimeSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockTimeTimePair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockTimeTimePair(x, y *threadSafeTimeTimeSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockTimeTimePair releases the locks taken by rlockTimeTimePair.
+func runlockTimeTimePair(x, y *threadSafeTimeTimeSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeTimeTimeSet) Add(i time.Time) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) Contains(i ...time.Time) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) IsSubset(other TimeTimeSet) bool {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) IsProperSubset(other TimeTimeSet) bool {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockTimeTimePair(set, o)
+	defer runlockTimeTimePair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -62,64 +158,69 @@ func (set *threadSafeTimeTimeSet) IsProperSuperset(other TimeTimeSet) bool {
 func (set *threadSafeTimeTimeSet) Union(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
+	readTimeTimePair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeTimeTimeSet)
 	ret := &threadSafeTimeTimeSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) Intersect(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
+	readTimeTimePair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeTimeTimeSet)
 	ret := &threadSafeTimeTimeSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) Difference(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
+	readTimeTimePair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeTimeTimeSet)
 	ret := &threadSafeTimeTimeSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) SymmetricDifference(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
+	readTimeTimePair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeTimeTimeSet)
 	ret := &threadSafeTimeTimeSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
 func (set *threadSafeTimeTimeSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeTimeTimeSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeTimeTimeSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeTimeTimeSet) Remove(i time.Time) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyTimeTime(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -130,7 +231,7 @@ func (set *threadSafeTimeTimeSet) Cardinality() int {
 }
 
 func (set *threadSafeTimeTimeSet) Each(cb func(time.Time) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -177,12 +278,10 @@ func (set *threadSafeTimeTimeSet) Iterator() *TimeTimeIterator {
 func (set *threadSafeTimeTimeSet) Equal(other TimeTimeSet) bool {
 	o := other.(*threadSafeTimeTimeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockTimeTimePair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockTimeTimePair(set, o)
 	return ret
 }
 
@@ -202,47 +301,42 @@ func (set *threadSafeTimeTimeSet) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeTimeTimeSet) PowerSet() TimeTimeSet {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeTimeTimeSet)
-    set.RUnlock()
+func (set *threadSafeTimeTimeSet) PowerSet() []TimeTimeSet {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeTimeTimeSet{s: newThreadUnsafeTimeTimeSet()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeTimeTimeSet)
-        ret.Add(&threadSafeTimeTimeSet{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]TimeTimeSet, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeTimeTimeSet)
+		ret = append(ret, &threadSafeTimeTimeSet{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeTimeTimeSet) Pop() time.Time {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeTimeTimeSet) CartesianProduct(other TimeTimeSet) TimeTimeSet {
-    o := other.(*threadSafeTimeTimeSet)
+func (set *threadSafeTimeTimeSet) CartesianProduct(other TimeTimeSet) TimeTimePairSet {
+	o := other.(*threadSafeTimeTimeSet)
 
-    set.RLock()
-    o.RLock()
+	rlockTimeTimePair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeTimeTimeSet)
-    ret := &threadSafeTimeTimeSet{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeTimeTimePairSet)
+	ret := &threadSafeTimeTimePairSet{s: *unsafeCartProduct}
+	runlockTimeTimePair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeTimeTimeSet) ToSlice() []time.Time {
-	keys := make([]time.Time, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]time.Time, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -259,9 +353,9 @@ func (set *threadSafeTimeTimeSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeTimeTimeSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
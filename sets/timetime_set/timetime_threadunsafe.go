@@ -1,7 +1,10 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
 package mapsettimetime
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -11,27 +14,25 @@ import (
 
 type threadUnsafeTimeTimeSet map[time.Time]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  time.Time
-	Second time.Time
-}
-
 func newThreadUnsafeTimeTimeSet() threadUnsafeTimeTimeSet {
 	return make(threadUnsafeTimeTimeSet)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeTimeTimeSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeTimeTimeSetWithCapacity(n int) threadUnsafeTimeTimeSet {
+	return make(threadUnsafeTimeTimeSet, n)
+}
 
-	return false
+// keyTimeTime returns the map key of i. A time is keyed without its
+// monotonic clock reading, which decoded times lack.
+func keyTimeTime(i time.Time) time.Time {
+	return i.Round(0)
 }
 
 func (set *threadUnsafeTimeTimeSet) Add(i time.Time) bool {
+	defer set.guardWrite().done()
+	i = keyTimeTime(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -42,8 +43,9 @@ func (set *threadUnsafeTimeTimeSet) Add(i time.Time) bool {
 }
 
 func (set *threadUnsafeTimeTimeSet) Contains(i ...time.Time) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyTimeTime(val)]; !ok {
 			return false
 		}
 	}
@@ -52,6 +54,7 @@ func (set *threadUnsafeTimeTimeSet) Contains(i ...time.Time) bool {
 
 func (set *threadUnsafeTimeTimeSet) IsSubset(other TimeTimeSet) bool {
 	_ = other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -63,8 +66,10 @@ func (set *threadUnsafeTimeTimeSet) IsSubset(other TimeTimeSet) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeTimeTimeSet) IsProperSubset(other TimeTimeSet) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeTimeTimeSet) IsSuperset(other TimeTimeSet) bool {
@@ -72,13 +77,15 @@ func (set *threadUnsafeTimeTimeSet) IsSuperset(other TimeTimeSet) bool {
 }
 
 func (set *threadUnsafeTimeTimeSet) IsProperSuperset(other TimeTimeSet) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeTimeTimeSet) Union(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeTimeTimeSet()
+	unionedSet := newThreadUnsafeTimeTimeSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -86,11 +93,14 @@ func (set *threadUnsafeTimeTimeSet) Union(other TimeTimeSet) TimeTimeSet {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackTimeTimeSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeTimeTimeSet) Intersect(other TimeTimeSet) TimeTimeSet {
 	o := other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeTimeTimeSet()
 	// loop over smaller set
@@ -107,11 +117,13 @@ func (set *threadUnsafeTimeTimeSet) Intersect(other TimeTimeSet) TimeTimeSet {
 			}
 		}
 	}
+	trackTimeTimeSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeTimeTimeSet) Difference(other TimeTimeSet) TimeTimeSet {
 	_ = other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeTimeTimeSet()
 	for elem := range *set {
@@ -119,30 +131,63 @@ func (set *threadUnsafeTimeTimeSet) Difference(other TimeTimeSet) TimeTimeSet {
 			difference.Add(elem)
 		}
 	}
+	trackTimeTimeSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeTimeTimeSet) SymmetricDifference(other TimeTimeSet) TimeTimeSet {
-	_ = other.(*threadUnsafeTimeTimeSet)
+	o := other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeTimeTimeSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackTimeTimeSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeTimeTimeSet) Clear() {
-	*set = newThreadUnsafeTimeTimeSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeTimeTimeSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeTimeTimeSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeTimeTimeSet) Remove(i time.Time) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyTimeTime(i))
 }
 
 func (set *threadUnsafeTimeTimeSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeTimeTimeSet) Each(cb func(time.Time) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -153,9 +198,11 @@ func (set *threadUnsafeTimeTimeSet) Each(cb func(time.Time) bool) {
 func (set *threadUnsafeTimeTimeSet) Iter() <-chan time.Time {
 	ch := make(chan time.Time)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -166,6 +213,7 @@ func (set *threadUnsafeTimeTimeSet) Iterator() *TimeTimeIterator {
 	iterator, ch, stopCh := newTimeTimeIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -174,6 +222,7 @@ func (set *threadUnsafeTimeTimeSet) Iterator() *TimeTimeIterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -182,6 +231,7 @@ func (set *threadUnsafeTimeTimeSet) Iterator() *TimeTimeIterator {
 
 func (set *threadUnsafeTimeTimeSet) Equal(other TimeTimeSet) bool {
 	_ = other.(*threadUnsafeTimeTimeSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -195,14 +245,17 @@ func (set *threadUnsafeTimeTimeSet) Equal(other TimeTimeSet) bool {
 }
 
 func (set *threadUnsafeTimeTimeSet) Clone() TimeTimeSet {
-	clonedSet := newThreadUnsafeTimeTimeSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeTimeTimeSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackTimeTimeSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeTimeTimeSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -211,12 +264,8 @@ func (set *threadUnsafeTimeTimeSet) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeTimeTimeSet) Pop() time.Time {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -224,55 +273,40 @@ func (set *threadUnsafeTimeTimeSet) Pop() time.Time {
 	return time.Time{}
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeTimeTimeSet) PowerSet() TimeTimeSet {
-	powSet := NewThreadUnsafeTimeTimeSet()
+func (set *threadUnsafeTimeTimeSet) PowerSet() []TimeTimeSet {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeTimeTimeSet()
-	powSet.Add(&nullset)
+	powSet := []TimeTimeSet{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeTimeTimeSet()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeTimeTimeSet()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeTimeTimeSet)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeTimeTimeSet) CartesianProduct(other TimeTimeSet) TimeTimeSet {
+func (set *threadUnsafeTimeTimeSet) CartesianProduct(other TimeTimeSet) TimeTimePairSet {
 	o := other.(*threadUnsafeTimeTimeSet)
-	cartProduct := NewThreadUnsafeTimeTimeSet()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeTimeTimePairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := TimeTimePair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeTimeTimeSet) ToSlice() []time.Time {
+	defer set.guardRead().done()
 	keys := make([]time.Time, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -283,26 +317,19 @@ func (set *threadUnsafeTimeTimeSet) ToSlice() []time.Time {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeTimeTimeSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, TimeTimeJSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeTimeTimeSet) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeTimeTimeSet) UnmarshalJSON(data []byte) error {
 	var i []time.Time
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
@@ -0,0 +1,155 @@
+// Code generated by generate_set for time.Time. DO NOT EDIT.
+
+package mapsettimetime
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// DefaultTimeTimeXMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultTimeTimeXMLItemName = "item"
+
+// TimeTimeXML adapts a TimeTimeSet to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type TimeTimeXML struct {
+	Set TimeTimeSet
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultTimeTimeXMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x TimeTimeXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeTimeTimeXML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *TimeTimeXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeTimeTimeXML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x TimeTimeXML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultTimeTimeXMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeTimeTimeXML(e *xml.Encoder, start xml.StartElement, s TimeTimeSet, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortTimeTimeElements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatTimeTimeText(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeTimeTimeXML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeTimeTimeXML(dec *xml.Decoder, itemName string) ([]time.Time, error) {
+	var elems []time.Time
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseTimeTimeText(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultTimeTimeXMLItemName. Use TimeTimeXML for other
+// names.
+func (set *threadUnsafeTimeTimeSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeTimeTimeXML(e, start, set, DefaultTimeTimeXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeTimeTimeSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeTimeTimeXML(d, DefaultTimeTimeXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeTimeTimeSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultTimeTimeXMLItemName. Use TimeTimeXML for other
+// names.
+func (set *threadSafeTimeTimeSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeTimeTimeXML(e, start, set, DefaultTimeTimeXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeTimeTimeSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeTimeTimeXML(d, DefaultTimeTimeXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeTimeTimeSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
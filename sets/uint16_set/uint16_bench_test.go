@@ -0,0 +1,86 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Uint16Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleUint16Values[i%len(sampleUint16Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewUint16Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeUint16Set())
+}
+
+func benchContains(b *testing.B, s Uint16Set) {
+	for _, v := range sampleUint16Values[:len(sampleUint16Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleUint16Values[i%len(sampleUint16Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewUint16Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeUint16Set())
+}
+
+func benchUnion(b *testing.B, x, y Uint16Set) {
+	half := len(sampleUint16Values) / 2
+	for _, v := range sampleUint16Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleUint16Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewUint16Set(), NewUint16Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeUint16Set(), NewThreadUnsafeUint16Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Uint16Set) {
+	for _, v := range sampleUint16Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewUint16Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeUint16Set())
+}
@@ -0,0 +1,127 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"testing"
+)
+
+// uint16HybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const uint16HybridPrecision = 14
+
+// assertUint16HybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertUint16HybridCount(t *testing.T, name string, s *Uint16HybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleUint16Values) / 2
+	s := NewUint16HybridSet(half, uint16HybridPrecision)
+	for _, v := range sampleUint16Values[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertUint16HybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeUint16SetFromSlice(sampleUint16Values[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleUint16Values[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleUint16Values[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertUint16HybridCount(t, "converted", s, half+1)
+	s.Add(sampleUint16Values[0])
+	assertUint16HybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleUint16Values) / 2
+	newHybrid := func(sketched bool, elems []uint16) *Uint16HybridSet {
+		threshold := len(sampleUint16Values)
+		if sketched {
+			threshold = 0
+		}
+		s := NewUint16HybridSet(threshold, uint16HybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleUint16Values[:half+1])
+		other := newHybrid(tc.sketchedO, sampleUint16Values[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeUint16SetFromSlice(sampleUint16Values)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleUint16Values, s.Exact())
+		}
+		assertUint16HybridCount(t, tc.name, s, len(sampleUint16Values))
+		assertUint16HybridCount(t, tc.name+" leaves other", other, len(sampleUint16Values)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewUint16HybridSet(half, uint16HybridPrecision)
+	s.Add(sampleUint16Values[0])
+	if err := s.Merge(newHybrid(false, sampleUint16Values)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertUint16HybridCount(t, "merged past the threshold", s, len(sampleUint16Values))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleUint16Values) / 2
+	for _, threshold := range []int{len(sampleUint16Values), 0} {
+		s := NewUint16HybridSet(threshold, uint16HybridPrecision)
+		for _, v := range sampleUint16Values[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleUint16Values), 0} {
+			other := NewUint16HybridSet(otherThreshold, uint16HybridPrecision-2)
+			other.Add(sampleUint16Values[len(sampleUint16Values)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, uint16HybridPrecision, uint16HybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeUint16SetFromSlice(sampleUint16Values[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeUint16Set()
+		return &s
+	},
+}
+
+// NewScratchUint16Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchUint16Set() Uint16Set {
+	return scratchPool.Get().(*threadUnsafeUint16Set)
+}
+
+// Release empties s and makes it available to NewScratchUint16Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Uint16Set) {
+	u, ok := s.(*threadUnsafeUint16Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Uint16Set) {
+	d, x, y, locks := lockUint16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllUint16(d, y)
+	case dst == b:
+		addAllUint16(d, x)
+	default:
+		clear(d)
+		addAllUint16(d, x)
+		addAllUint16(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Uint16Set) {
+	d, x, y, locks := lockUint16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyUint16(d, y)
+	case dst == b:
+		keepOnlyUint16(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Uint16Set) {
+	d, x, y, locks := lockUint16Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchUint16Set().(*threadUnsafeUint16Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllUint16(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllUint16(dst, src threadUnsafeUint16Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyUint16 removes the elements of dst that are not in other.
+func keepOnlyUint16(dst, other threadUnsafeUint16Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoUint16Locks records the locks taken by lockUint16Into, or the guards for
+// thread-unsafe sets.
+type intoUint16Locks struct {
+	sets     [3]*threadSafeUint16Set
+	distinct int
+	dst      *threadSafeUint16Set
+	guards   [3]unsafeUint16Guard
+}
+
+// lockUint16Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockUint16Into(dst, a, b Uint16Set) (d, x, y threadUnsafeUint16Set, locks intoUint16Locks) {
+	w, ok := dst.(*threadSafeUint16Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeUint16Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeUint16Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeUint16Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeUint16Set), *b.(*threadUnsafeUint16Set), locks
+	}
+
+	locks.sets = [3]*threadSafeUint16Set{w, a.(*threadSafeUint16Set), b.(*threadSafeUint16Set)}
+	locks.distinct = len(lockUint16Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeUint16Set).s, b.(*threadSafeUint16Set).s, locks
+}
+
+func (l intoUint16Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
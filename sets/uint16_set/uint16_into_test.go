@@ -0,0 +1,74 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleUint16Values)
+	aValues, bValues := sampleUint16Values[:n-1], sampleUint16Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Uint16Set)
+		expected []uint16
+	}{
+		{"UnionInto", UnionInto, sampleUint16Values},
+		{"IntersectInto", IntersectInto, sampleUint16Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleUint16Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range uint16SetFactories() {
+			expected := fillUint16Set(newSet(), testCase.expected...)
+
+			dst := fillUint16Set(newSet(), sampleUint16Values[n-1])
+			testCase.into(dst, fillUint16Set(newSet(), aValues...), fillUint16Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillUint16Set(newSet(), aValues...)
+			testCase.into(a, a, fillUint16Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillUint16Set(newSet(), bValues...)
+			testCase.into(b, fillUint16Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range uint16SetFactories() {
+		s := fillUint16Set(newSet(), sampleUint16Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchUint16Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleUint16Values[0])
+	Release(s)
+	Release(NewUint16Set(sampleUint16Values[0]))
+
+	if again := NewScratchUint16Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Uint16Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Uint16Set) error { return json.Unmarshal(b, s) }
+	assertUint16RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewUint16Set(sampleUint16Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUint16Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleUint16Values[i%len(sampleUint16Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewUint16Set(sampleUint16Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleUint16Values, s)
+	}
+}
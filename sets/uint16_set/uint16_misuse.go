@@ -0,0 +1,20 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetuint16
+
+// unsafeUint16Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeUint16Guard struct{}
+
+func trackUint16Set(set *threadUnsafeUint16Set) {}
+
+func (set *threadUnsafeUint16Set) guardRead() unsafeUint16Guard { return unsafeUint16Guard{} }
+
+func (set *threadUnsafeUint16Set) guardWrite() unsafeUint16Guard { return unsafeUint16Guard{} }
+
+func (set *threadUnsafeUint16Set) guardIterate() unsafeUint16Guard { return unsafeUint16Guard{} }
+
+func (unsafeUint16Guard) done() {}
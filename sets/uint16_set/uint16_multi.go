@@ -0,0 +1,110 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Uint16Set) Uint16Set {
+	if len(sets) == 0 {
+		return NewUint16Set()
+	}
+	maps, unlock := readLockUint16Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeUint16Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapUint16Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Uint16Set) Uint16Set {
+	if len(sets) == 0 {
+		return NewUint16Set()
+	}
+	maps, unlock := readLockUint16Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeUint16Set()
+	for elem := range maps[smallest] {
+		if containedInAllUint16(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapUint16Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Uint16Set, sets ...Uint16Set) Uint16Set {
+	maps, unlock := readLockUint16Sets(append([]Uint16Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeUint16Set()
+	for elem := range maps[0] {
+		if !containedInAnyUint16(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapUint16Like(base, difference)
+}
+
+func containedInAllUint16(maps []threadUnsafeUint16Set, skip int, elem uint16) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyUint16(maps []threadUnsafeUint16Set, elem uint16) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapUint16Like returns s as a Set of the same implementation as like.
+func wrapUint16Like(like Uint16Set, s threadUnsafeUint16Set) Uint16Set {
+	if _, ok := like.(*threadSafeUint16Set); ok {
+		return &threadSafeUint16Set{s: s}
+	}
+	trackUint16Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Uint16Pair represents a 2-tuple of values.
+type Uint16Pair struct {
+	First  uint16
+	Second uint16
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Uint16Pair) Equal(other Uint16Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Uint16Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Uint16PairSet is an unordered set of Uint16Pairs, as
+// returned by CartesianProduct.
+type Uint16PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Uint16Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Uint16Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Uint16Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Uint16PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Uint16Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Uint16Pair
+}
+
+type threadUnsafeUint16PairSet map[Uint16Pair]struct{}
+
+func newThreadUnsafeUint16PairSet() threadUnsafeUint16PairSet {
+	return make(threadUnsafeUint16PairSet)
+}
+
+func (set *threadUnsafeUint16PairSet) Add(p Uint16Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeUint16PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeUint16PairSet) Contains(p ...Uint16Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint16PairSet) Each(cb func(Uint16Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeUint16PairSet) Equal(other Uint16PairSet) bool {
+	_ = other.(*threadUnsafeUint16PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint16PairSet) Remove(p Uint16Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeUint16PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeUint16PairSet) ToSlice() []Uint16Pair {
+	keys := make([]Uint16Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeUint16PairSet struct {
+	s threadUnsafeUint16PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeUint16PairSet) Add(p Uint16Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeUint16PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeUint16PairSet) Contains(p ...Uint16Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint16PairSet) Each(cb func(Uint16Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockUint16PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockUint16Pair does for sets.
+func rlockUint16PairSets(x, y *threadSafeUint16PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint16PairSets releases the locks taken by rlockUint16PairSets.
+func runlockUint16PairSets(x, y *threadSafeUint16PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeUint16PairSet) Equal(other Uint16PairSet) bool {
+	o := other.(*threadSafeUint16PairSet)
+
+	rlockUint16PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockUint16PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeUint16PairSet) Remove(p Uint16Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeUint16PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint16PairSet) ToSlice() []Uint16Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
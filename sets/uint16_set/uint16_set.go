@@ -1,3 +1,5 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
 package mapsetuint16
 
 // Uint16Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Uint16Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Uint16Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Uint16Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() uint16
 
-	// Returns all subsets of a given set (Power Uint16Set).
-	// Not yet supported
-	// PowerSet() Uint16Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Uint16Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Uint16Set) Uint16Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Uint16Set) Uint16PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []uint16
@@ -145,7 +154,7 @@ type Uint16Set interface {
 // NewUint16Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewUint16Set(s ...uint16) Uint16Set {
-	set := newThreadSafeUint16Set()
+	set := threadSafeUint16Set{s: newThreadUnsafeUint16SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewUint16SetFromSlice(s []uint16) Uint16Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeUint16Set() Uint16Set {
 	set := newThreadUnsafeUint16Set()
+	trackUint16Set(&set)
+	return &set
+}
+
+// NewUint16SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewUint16SetWithCapacity(n int) Uint16Set {
+	return &threadSafeUint16Set{s: newThreadUnsafeUint16SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeUint16SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeUint16SetWithCapacity(n int) Uint16Set {
+	set := newThreadUnsafeUint16SetWithCapacity(n)
+	trackUint16Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeUint16Set() Uint16Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeUint16SetFromSlice(s []uint16) Uint16Set {
-	a := NewThreadUnsafeUint16Set()
+	a := NewThreadUnsafeUint16SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"sort"
+)
+
+// lessUint16 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessUint16(a, b uint16) bool {
+	return a < b
+}
+
+// sortUint16Elements sorts elems in place using lessUint16.
+func sortUint16Elements(elems []uint16) {
+	sort.Slice(elems, func(i, j int) bool { return lessUint16(elems[i], elems[j]) })
+}
@@ -0,0 +1,26 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]uint16, 0, len(sampleUint16Values))
+	for i := len(sampleUint16Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleUint16Values[i])
+	}
+	sortUint16Elements(elems)
+	for i, elem := range elems {
+		if lessUint16(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessUint16(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewUint16Set(elems...).Equal(NewUint16Set(sampleUint16Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleUint16Values, elems)
+	}
+}
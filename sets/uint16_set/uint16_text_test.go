@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Uint16Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Uint16Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertUint16RoundTrip(t, marshal, unmarshal)
+}
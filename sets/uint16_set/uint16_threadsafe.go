@@ -1,11 +1,16 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
 package mapsetuint16
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeUint16Set struct {
-	s threadUnsafeUint16Set
+	s     threadUnsafeUint16Set
+	stats atomic.Pointer[Uint16SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeUint16Set() threadSafeUint16Set {
 	return threadSafeUint16Set{s: newThreadUnsafeUint16Set()}
 }
 
+// readLockUint16Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockUint16Sets(sets []Uint16Set) ([]threadUnsafeUint16Set, func()) {
+	maps := make([]threadUnsafeUint16Set, len(sets))
+	if _, ok := sets[0].(*threadSafeUint16Set); !ok {
+		guards := make([]unsafeUint16Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeUint16Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeUint16Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeUint16Set))
+	}
+	distinct := lockUint16Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeUint16Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockUint16Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockUint16Order(sets []*threadSafeUint16Set) []*threadSafeUint16Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockUint16Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockUint16Pair(x, y *threadSafeUint16Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint16Pair releases the locks taken by rlockUint16Pair.
+func runlockUint16Pair(x, y *threadSafeUint16Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeUint16Set) Add(i uint16) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeUint16Set) Contains(i ...uint16) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeUint16Set) IsSubset(other Uint16Set) bool {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint16Set) IsProperSubset(other Uint16Set) bool {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockUint16Pair(set, o)
+	defer runlockUint16Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeUint16Set) IsProperSuperset(other Uint16Set) bool {
 func (set *threadSafeUint16Set) Union(other Uint16Set) Uint16Set {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
+	readUint16Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeUint16Set)
 	ret := &threadSafeUint16Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint16Set) Intersect(other Uint16Set) Uint16Set {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
+	readUint16Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeUint16Set)
 	ret := &threadSafeUint16Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint16Set) Difference(other Uint16Set) Uint16Set {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
+	readUint16Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeUint16Set)
 	ret := &threadSafeUint16Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint16Set) SymmetricDifference(other Uint16Set) Uint16Set {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
+	readUint16Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeUint16Set)
 	ret := &threadSafeUint16Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint16Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeUint16Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeUint16Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeUint16Set) Remove(i uint16) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyUint16(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeUint16Set) Cardinality() int {
 }
 
 func (set *threadSafeUint16Set) Each(cb func(uint16) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeUint16Set) Iterator() *Uint16Iterator {
 func (set *threadSafeUint16Set) Equal(other Uint16Set) bool {
 	o := other.(*threadSafeUint16Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint16Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint16Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeUint16Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint16Set) PowerSet() Uint16Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeUint16Set)
-    set.RUnlock()
+func (set *threadSafeUint16Set) PowerSet() []Uint16Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeUint16Set{s: newThreadUnsafeUint16Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeUint16Set)
-        ret.Add(&threadSafeUint16Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Uint16Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeUint16Set)
+		ret = append(ret, &threadSafeUint16Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeUint16Set) Pop() uint16 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint16Set) CartesianProduct(other Uint16Set) Uint16Set {
-    o := other.(*threadSafeUint16Set)
+func (set *threadSafeUint16Set) CartesianProduct(other Uint16Set) Uint16PairSet {
+	o := other.(*threadSafeUint16Set)
 
-    set.RLock()
-    o.RLock()
+	rlockUint16Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint16Set)
-    ret := &threadSafeUint16Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint16PairSet)
+	ret := &threadSafeUint16PairSet{s: *unsafeCartProduct}
+	runlockUint16Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeUint16Set) ToSlice() []uint16 {
-	keys := make([]uint16, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]uint16, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeUint16Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeUint16Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
 package mapsetuint16
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeUint16Set map[uint16]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  uint16
-	Second uint16
-}
-
 func newThreadUnsafeUint16Set() threadUnsafeUint16Set {
 	return make(threadUnsafeUint16Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeUint16SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeUint16SetWithCapacity(n int) threadUnsafeUint16Set {
+	return make(threadUnsafeUint16Set, n)
+}
 
-	return false
+// keyUint16 returns the map key of i.
+func keyUint16(i uint16) uint16 {
+	return i
 }
 
 func (set *threadUnsafeUint16Set) Add(i uint16) bool {
+	defer set.guardWrite().done()
+	i = keyUint16(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeUint16Set) Add(i uint16) bool {
 }
 
 func (set *threadUnsafeUint16Set) Contains(i ...uint16) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyUint16(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeUint16Set) Contains(i ...uint16) bool {
 
 func (set *threadUnsafeUint16Set) IsSubset(other Uint16Set) bool {
 	_ = other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeUint16Set) IsSubset(other Uint16Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeUint16Set) IsProperSubset(other Uint16Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeUint16Set) IsSuperset(other Uint16Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeUint16Set) IsSuperset(other Uint16Set) bool {
 }
 
 func (set *threadUnsafeUint16Set) IsProperSuperset(other Uint16Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeUint16Set) Union(other Uint16Set) Uint16Set {
 	o := other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeUint16Set()
+	unionedSet := newThreadUnsafeUint16SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeUint16Set) Union(other Uint16Set) Uint16Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUint16Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeUint16Set) Intersect(other Uint16Set) Uint16Set {
 	o := other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeUint16Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeUint16Set) Intersect(other Uint16Set) Uint16Set {
 			}
 		}
 	}
+	trackUint16Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeUint16Set) Difference(other Uint16Set) Uint16Set {
 	_ = other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeUint16Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeUint16Set) Difference(other Uint16Set) Uint16Set {
 			difference.Add(elem)
 		}
 	}
+	trackUint16Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeUint16Set) SymmetricDifference(other Uint16Set) Uint16Set {
-	_ = other.(*threadUnsafeUint16Set)
+	o := other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeUint16Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUint16Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeUint16Set) Clear() {
-	*set = newThreadUnsafeUint16Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeUint16Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeUint16SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeUint16Set) Remove(i uint16) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyUint16(i))
 }
 
 func (set *threadUnsafeUint16Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeUint16Set) Each(cb func(uint16) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeUint16Set) Each(cb func(uint16) bool) {
 func (set *threadUnsafeUint16Set) Iter() <-chan uint16 {
 	ch := make(chan uint16)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeUint16Set) Iterator() *Uint16Iterator {
 	iterator, ch, stopCh := newUint16Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeUint16Set) Iterator() *Uint16Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeUint16Set) Iterator() *Uint16Iterator {
 
 func (set *threadUnsafeUint16Set) Equal(other Uint16Set) bool {
 	_ = other.(*threadUnsafeUint16Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeUint16Set) Equal(other Uint16Set) bool {
 }
 
 func (set *threadUnsafeUint16Set) Clone() Uint16Set {
-	clonedSet := newThreadUnsafeUint16Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeUint16SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUint16Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeUint16Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeUint16Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeUint16Set) Pop() uint16 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeUint16Set) Pop() uint16 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint16Set) PowerSet() Uint16Set {
-	powSet := NewThreadUnsafeUint16Set()
+func (set *threadUnsafeUint16Set) PowerSet() []Uint16Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeUint16Set()
-	powSet.Add(&nullset)
+	powSet := []Uint16Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeUint16Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeUint16Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeUint16Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint16Set) CartesianProduct(other Uint16Set) Uint16Set {
+func (set *threadUnsafeUint16Set) CartesianProduct(other Uint16Set) Uint16PairSet {
 	o := other.(*threadUnsafeUint16Set)
-	cartProduct := NewThreadUnsafeUint16Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeUint16PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Uint16Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeUint16Set) ToSlice() []uint16 {
+	defer set.guardRead().done()
 	keys := make([]uint16, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeUint16Set) ToSlice() []uint16 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeUint16Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Uint16JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeUint16Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeUint16Set) UnmarshalJSON(data []byte) error {
 	var i []uint16
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
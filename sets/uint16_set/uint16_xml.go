@@ -0,0 +1,154 @@
+// Code generated by generate_set for uint16. DO NOT EDIT.
+
+package mapsetuint16
+
+import (
+	"encoding/xml"
+)
+
+// DefaultUint16XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultUint16XMLItemName = "item"
+
+// Uint16XML adapts a Uint16Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Uint16XML struct {
+	Set Uint16Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultUint16XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Uint16XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint16XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Uint16XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint16XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Uint16XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultUint16XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeUint16XML(e *xml.Encoder, start xml.StartElement, s Uint16Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortUint16Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatUint16Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeUint16XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeUint16XML(dec *xml.Decoder, itemName string) ([]uint16, error) {
+	var elems []uint16
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseUint16Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint16XMLItemName. Use Uint16XML for other
+// names.
+func (set *threadUnsafeUint16Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint16XML(e, start, set, DefaultUint16XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeUint16Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint16XML(d, DefaultUint16XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeUint16Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint16XMLItemName. Use Uint16XML for other
+// names.
+func (set *threadSafeUint16Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint16XML(e, start, set, DefaultUint16XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeUint16Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint16XML(d, DefaultUint16XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeUint16Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
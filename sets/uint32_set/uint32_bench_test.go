@@ -0,0 +1,86 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Uint32Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleUint32Values[i%len(sampleUint32Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewUint32Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeUint32Set())
+}
+
+func benchContains(b *testing.B, s Uint32Set) {
+	for _, v := range sampleUint32Values[:len(sampleUint32Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleUint32Values[i%len(sampleUint32Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewUint32Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeUint32Set())
+}
+
+func benchUnion(b *testing.B, x, y Uint32Set) {
+	half := len(sampleUint32Values) / 2
+	for _, v := range sampleUint32Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleUint32Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewUint32Set(), NewUint32Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeUint32Set(), NewThreadUnsafeUint32Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Uint32Set) {
+	for _, v := range sampleUint32Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewUint32Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeUint32Set())
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s Uint32Set) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s Uint32Set) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertUint32RoundTrip(t, marshal, unmarshal)
+}
@@ -0,0 +1,127 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"testing"
+)
+
+// uint32HybridPrecision is large enough that the sketch
+// counts the few sample values almost exactly.
+const uint32HybridPrecision = 14
+
+// assertUint32HybridCount checks that s counts want elements,
+// allowing the sketch an error of one.
+func assertUint32HybridCount(t *testing.T, name string, s *Uint32HybridSet, want int) {
+	t.Helper()
+	got := int(s.Cardinality())
+	if s.IsExact() && got != want || got < want-1 || got > want+1 {
+		t.Errorf("%s: expected a count of %d, got %d (exact %v)", name, want, got, s.IsExact())
+	}
+}
+
+func TestHybridSetThreshold(t *testing.T) {
+	half := len(sampleUint32Values) / 2
+	s := NewUint32HybridSet(half, uint32HybridPrecision)
+	for _, v := range sampleUint32Values[:half] {
+		s.Add(v)
+		s.Add(v)
+	}
+	if !s.IsExact() {
+		t.Fatalf("expected the set to stay exact at its threshold of %d", half)
+	}
+	assertUint32HybridCount(t, "exact", s, half)
+	if exact := s.Exact(); !exact.Equal(NewThreadUnsafeUint32SetFromSlice(sampleUint32Values[:half])) {
+		t.Errorf("expected the exact elements %v, got %v", sampleUint32Values[:half], exact)
+	}
+	if got := s.Sketch().Estimate(); got != uint64(half) {
+		t.Errorf("expected a sketch of the exact elements to count %d, got %d", half, got)
+	}
+
+	s.Add(sampleUint32Values[half])
+	if s.IsExact() || s.Exact() != nil {
+		t.Fatal("expected the set to convert past its threshold")
+	}
+	assertUint32HybridCount(t, "converted", s, half+1)
+	s.Add(sampleUint32Values[0])
+	assertUint32HybridCount(t, "converted after a repeat", s, half+1)
+}
+
+func TestHybridSetMerge(t *testing.T) {
+	half := len(sampleUint32Values) / 2
+	newHybrid := func(sketched bool, elems []uint32) *Uint32HybridSet {
+		threshold := len(sampleUint32Values)
+		if sketched {
+			threshold = 0
+		}
+		s := NewUint32HybridSet(threshold, uint32HybridPrecision)
+		for _, v := range elems {
+			s.Add(v)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name                 string
+		sketchedS, sketchedO bool
+	}{
+		{"exact into exact", false, false},
+		{"sketch into exact", false, true},
+		{"exact into sketch", true, false},
+		{"sketch into sketch", true, true},
+	} {
+		s := newHybrid(tc.sketchedS, sampleUint32Values[:half+1])
+		other := newHybrid(tc.sketchedO, sampleUint32Values[half:])
+		if err := s.Merge(other); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+
+		if wantExact := !tc.sketchedS && !tc.sketchedO; s.IsExact() != wantExact {
+			t.Errorf("%s: expected exact %v after merging, got %v", tc.name, wantExact, s.IsExact())
+		}
+		if s.IsExact() && !s.Exact().Equal(NewThreadUnsafeUint32SetFromSlice(sampleUint32Values)) {
+			t.Errorf("%s: expected the union %v, got %v", tc.name, sampleUint32Values, s.Exact())
+		}
+		assertUint32HybridCount(t, tc.name, s, len(sampleUint32Values))
+		assertUint32HybridCount(t, tc.name+" leaves other", other, len(sampleUint32Values)-half)
+	}
+
+	// A union of exact sets past the threshold converts.
+	s := NewUint32HybridSet(half, uint32HybridPrecision)
+	s.Add(sampleUint32Values[0])
+	if err := s.Merge(newHybrid(false, sampleUint32Values)); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsExact() {
+		t.Error("expected a merge past the threshold to convert")
+	}
+	assertUint32HybridCount(t, "merged past the threshold", s, len(sampleUint32Values))
+}
+
+func TestHybridSetMergePrecisionMismatch(t *testing.T) {
+	half := len(sampleUint32Values) / 2
+	for _, threshold := range []int{len(sampleUint32Values), 0} {
+		s := NewUint32HybridSet(threshold, uint32HybridPrecision)
+		for _, v := range sampleUint32Values[:half] {
+			s.Add(v)
+		}
+		wasExact := s.IsExact()
+		before := s.Sketch().Estimate()
+
+		for _, otherThreshold := range []int{len(sampleUint32Values), 0} {
+			other := NewUint32HybridSet(otherThreshold, uint32HybridPrecision-2)
+			other.Add(sampleUint32Values[len(sampleUint32Values)-1])
+
+			if err := s.Merge(other); err == nil {
+				t.Errorf("threshold %d into %d: expected an error merging precisions %d and %d", otherThreshold, threshold, uint32HybridPrecision, uint32HybridPrecision-2)
+			}
+			if s.IsExact() != wasExact || s.Sketch().Estimate() != before {
+				t.Errorf("threshold %d into %d: expected a failed merge to leave the set unchanged", otherThreshold, threshold)
+			}
+			if wasExact && !s.Exact().Equal(NewThreadUnsafeUint32SetFromSlice(sampleUint32Values[:half])) {
+				t.Errorf("threshold %d into %d: expected the exact elements to be unchanged, got %v", otherThreshold, threshold, s.Exact())
+			}
+		}
+	}
+}
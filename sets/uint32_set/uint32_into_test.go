@@ -0,0 +1,74 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleUint32Values)
+	aValues, bValues := sampleUint32Values[:n-1], sampleUint32Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Uint32Set)
+		expected []uint32
+	}{
+		{"UnionInto", UnionInto, sampleUint32Values},
+		{"IntersectInto", IntersectInto, sampleUint32Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleUint32Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range uint32SetFactories() {
+			expected := fillUint32Set(newSet(), testCase.expected...)
+
+			dst := fillUint32Set(newSet(), sampleUint32Values[n-1])
+			testCase.into(dst, fillUint32Set(newSet(), aValues...), fillUint32Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillUint32Set(newSet(), aValues...)
+			testCase.into(a, a, fillUint32Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillUint32Set(newSet(), bValues...)
+			testCase.into(b, fillUint32Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range uint32SetFactories() {
+		s := fillUint32Set(newSet(), sampleUint32Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchUint32Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleUint32Values[0])
+	Release(s)
+	Release(NewUint32Set(sampleUint32Values[0]))
+
+	if again := NewScratchUint32Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
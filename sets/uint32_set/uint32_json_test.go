@@ -0,0 +1,49 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Uint32Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Uint32Set) error { return json.Unmarshal(b, s) }
+	assertUint32RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewUint32Set(sampleUint32Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUint32Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleUint32Values[i%len(sampleUint32Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewUint32Set(sampleUint32Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleUint32Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetuint32
+
+// unsafeUint32Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeUint32Guard struct{}
+
+func trackUint32Set(set *threadUnsafeUint32Set) {}
+
+func (set *threadUnsafeUint32Set) guardRead() unsafeUint32Guard { return unsafeUint32Guard{} }
+
+func (set *threadUnsafeUint32Set) guardWrite() unsafeUint32Guard { return unsafeUint32Guard{} }
+
+func (set *threadUnsafeUint32Set) guardIterate() unsafeUint32Guard { return unsafeUint32Guard{} }
+
+func (unsafeUint32Guard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Uint32Set) Uint32Set {
+	if len(sets) == 0 {
+		return NewUint32Set()
+	}
+	maps, unlock := readLockUint32Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeUint32Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapUint32Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Uint32Set) Uint32Set {
+	if len(sets) == 0 {
+		return NewUint32Set()
+	}
+	maps, unlock := readLockUint32Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeUint32Set()
+	for elem := range maps[smallest] {
+		if containedInAllUint32(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapUint32Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Uint32Set, sets ...Uint32Set) Uint32Set {
+	maps, unlock := readLockUint32Sets(append([]Uint32Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeUint32Set()
+	for elem := range maps[0] {
+		if !containedInAnyUint32(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapUint32Like(base, difference)
+}
+
+func containedInAllUint32(maps []threadUnsafeUint32Set, skip int, elem uint32) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyUint32(maps []threadUnsafeUint32Set, elem uint32) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapUint32Like returns s as a Set of the same implementation as like.
+func wrapUint32Like(like Uint32Set, s threadUnsafeUint32Set) Uint32Set {
+	if _, ok := like.(*threadSafeUint32Set); ok {
+		return &threadSafeUint32Set{s: s}
+	}
+	trackUint32Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Uint32Pair represents a 2-tuple of values.
+type Uint32Pair struct {
+	First  uint32
+	Second uint32
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Uint32Pair) Equal(other Uint32Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Uint32Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Uint32PairSet is an unordered set of Uint32Pairs, as
+// returned by CartesianProduct.
+type Uint32PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Uint32Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Uint32Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Uint32Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Uint32PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Uint32Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Uint32Pair
+}
+
+type threadUnsafeUint32PairSet map[Uint32Pair]struct{}
+
+func newThreadUnsafeUint32PairSet() threadUnsafeUint32PairSet {
+	return make(threadUnsafeUint32PairSet)
+}
+
+func (set *threadUnsafeUint32PairSet) Add(p Uint32Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeUint32PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeUint32PairSet) Contains(p ...Uint32Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint32PairSet) Each(cb func(Uint32Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeUint32PairSet) Equal(other Uint32PairSet) bool {
+	_ = other.(*threadUnsafeUint32PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint32PairSet) Remove(p Uint32Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeUint32PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeUint32PairSet) ToSlice() []Uint32Pair {
+	keys := make([]Uint32Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeUint32PairSet struct {
+	s threadUnsafeUint32PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeUint32PairSet) Add(p Uint32Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeUint32PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeUint32PairSet) Contains(p ...Uint32Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint32PairSet) Each(cb func(Uint32Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockUint32PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockUint32Pair does for sets.
+func rlockUint32PairSets(x, y *threadSafeUint32PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint32PairSets releases the locks taken by rlockUint32PairSets.
+func runlockUint32PairSets(x, y *threadSafeUint32PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeUint32PairSet) Equal(other Uint32PairSet) bool {
+	o := other.(*threadSafeUint32PairSet)
+
+	rlockUint32PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockUint32PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeUint32PairSet) Remove(p Uint32Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeUint32PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint32PairSet) ToSlice() []Uint32Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
@@ -1,3 +1,5 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
 package mapsetuint32
 
 // Uint32Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Uint32Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Uint32Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Uint32Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() uint32
 
-	// Returns all subsets of a given set (Power Uint32Set).
-	// Not yet supported
-	// PowerSet() Uint32Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Uint32Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Uint32Set) Uint32Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Uint32Set) Uint32PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []uint32
@@ -145,7 +154,7 @@ type Uint32Set interface {
 // NewUint32Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewUint32Set(s ...uint32) Uint32Set {
-	set := newThreadSafeUint32Set()
+	set := threadSafeUint32Set{s: newThreadUnsafeUint32SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewUint32SetFromSlice(s []uint32) Uint32Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeUint32Set() Uint32Set {
 	set := newThreadUnsafeUint32Set()
+	trackUint32Set(&set)
+	return &set
+}
+
+// NewUint32SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewUint32SetWithCapacity(n int) Uint32Set {
+	return &threadSafeUint32Set{s: newThreadUnsafeUint32SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeUint32SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeUint32SetWithCapacity(n int) Uint32Set {
+	set := newThreadUnsafeUint32SetWithCapacity(n)
+	trackUint32Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeUint32Set() Uint32Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeUint32SetFromSlice(s []uint32) Uint32Set {
-	a := NewThreadUnsafeUint32Set()
+	a := NewThreadUnsafeUint32SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
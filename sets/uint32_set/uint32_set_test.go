@@ -0,0 +1,105 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleUint32Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleUint32Values = []uint32{
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+	128,
+	255,
+}
+
+// uint32SetFactories returns constructors for both implementations.
+func uint32SetFactories() map[string]func() Uint32Set {
+	return map[string]func() Uint32Set{
+		"safe":   func() Uint32Set { return NewUint32Set() },
+		"unsafe": NewThreadUnsafeUint32Set,
+	}
+}
+
+// fillUint32Set adds values to s and returns it.
+func fillUint32Set(s Uint32Set, values ...uint32) Uint32Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertUint32RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertUint32RoundTrip(t *testing.T, marshal func(Uint32Set) ([]byte, error), unmarshal func([]byte, Uint32Set) error) {
+	t.Helper()
+	contents := [][]uint32{sampleUint32Values, sampleUint32Values[:1]}
+	for name, newSet := range uint32SetFactories() {
+		for _, values := range contents {
+			s := fillUint32Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range uint32SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[uint32, Uint32Set]{
+				New:   newSet,
+				Elems: sampleUint32Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range uint32SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleUint32Values[0])
+		a.Add(sampleUint32Values[1])
+		for _, v := range sampleUint32Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleUint32Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Uint32Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import "math"
+
+// Jaccard returns the Jaccard index of a and b: the size of their
+// intersection divided by the size of their union. Two empty sets are
+// identical and have an index of 1.
+//
+// The similarity functions count shared elements without building any
+// intermediate set. The sets must share one implementation, as for
+// Intersect; thread-safe sets are read-locked while counting.
+func Jaccard(a, b Uint32Set) float64 {
+	na, nb, common := overlapUint32Sizes(a, b)
+	if na+nb == 0 {
+		return 1
+	}
+	return float64(common) / float64(na+nb-common)
+}
+
+// SorensenDice returns the Sørensen–Dice coefficient of a and b: twice
+// the size of their intersection divided by the sum of their sizes. Two
+// empty sets have a coefficient of 1.
+func SorensenDice(a, b Uint32Set) float64 {
+	na, nb, common := overlapUint32Sizes(a, b)
+	if na+nb == 0 {
+		return 1
+	}
+	return 2 * float64(common) / float64(na+nb)
+}
+
+// OverlapCoefficient returns the size of the intersection of a and b
+// divided by the size of the smaller set. Two empty sets have a
+// coefficient of 1; an empty and a non-empty set have 0.
+func OverlapCoefficient(a, b Uint32Set) float64 {
+	na, nb, common := overlapUint32Sizes(a, b)
+	if na+nb == 0 {
+		return 1
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	if na > nb {
+		na = nb
+	}
+	return float64(common) / float64(na)
+}
+
+// Cosine returns the cosine similarity of a and b viewed as binary
+// vectors: the size of their intersection divided by the geometric mean
+// of their sizes. Two empty sets have a similarity of 1; an empty and a
+// non-empty set have 0.
+func Cosine(a, b Uint32Set) float64 {
+	na, nb, common := overlapUint32Sizes(a, b)
+	if na+nb == 0 {
+		return 1
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return float64(common) / math.Sqrt(float64(na)*float64(nb))
+}
+
+// SymmetricDifferenceSize returns the number of elements in exactly one
+// of a and b, without building their symmetric difference.
+func SymmetricDifferenceSize(a, b Uint32Set) int {
+	na, nb, common := overlapUint32Sizes(a, b)
+	return na + nb - 2*common
+}
+
+// overlapUint32Sizes returns the sizes of a and b and the number of elements
+// they share.
+func overlapUint32Sizes(a, b Uint32Set) (na, nb, common int) {
+	if x, ok := a.(*threadSafeUint32Set); ok {
+		y := b.(*threadSafeUint32Set)
+		rlockUint32Pair(x, y)
+		na, nb, common = countUint32Overlap(x.s, y.s)
+		runlockUint32Pair(x, y)
+		return na, nb, common
+	}
+	x, y := a.(*threadUnsafeUint32Set), b.(*threadUnsafeUint32Set)
+	defer x.guardRead().done()
+	defer y.guardRead().done()
+	return countUint32Overlap(*x, *y)
+}
+
+func countUint32Overlap(a, b threadUnsafeUint32Set) (na, nb, common int) {
+	small, large := a, b
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	for elem := range small {
+		if _, ok := large[elem]; ok {
+			common++
+		}
+	}
+	return len(a), len(b), common
+}
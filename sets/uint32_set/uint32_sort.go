@@ -0,0 +1,18 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"sort"
+)
+
+// lessUint32 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessUint32(a, b uint32) bool {
+	return a < b
+}
+
+// sortUint32Elements sorts elems in place using lessUint32.
+func sortUint32Elements(elems []uint32) {
+	sort.Slice(elems, func(i, j int) bool { return lessUint32(elems[i], elems[j]) })
+}
@@ -0,0 +1,26 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]uint32, 0, len(sampleUint32Values))
+	for i := len(sampleUint32Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleUint32Values[i])
+	}
+	sortUint32Elements(elems)
+	for i, elem := range elems {
+		if lessUint32(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessUint32(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewUint32Set(elems...).Equal(NewUint32Set(sampleUint32Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleUint32Values, elems)
+	}
+}
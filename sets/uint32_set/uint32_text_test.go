@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Uint32Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Uint32Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertUint32RoundTrip(t, marshal, unmarshal)
+}
@@ -1,11 +1,16 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
 package mapsetuint32
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeUint32Set struct {
-	s threadUnsafeUint32Set
+	s     threadUnsafeUint32Set
+	stats atomic.Pointer[Uint32SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeUint32Set() threadSafeUint32Set {
 	return threadSafeUint32Set{s: newThreadUnsafeUint32Set()}
 }
 
+// readLockUint32Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockUint32Sets(sets []Uint32Set) ([]threadUnsafeUint32Set, func()) {
+	maps := make([]threadUnsafeUint32Set, len(sets))
+	if _, ok := sets[0].(*threadSafeUint32Set); !ok {
+		guards := make([]unsafeUint32Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeUint32Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeUint32Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeUint32Set))
+	}
+	distinct := lockUint32Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeUint32Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockUint32Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockUint32Order(sets []*threadSafeUint32Set) []*threadSafeUint32Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockUint32Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockUint32Pair(x, y *threadSafeUint32Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint32Pair releases the locks taken by rlockUint32Pair.
+func runlockUint32Pair(x, y *threadSafeUint32Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeUint32Set) Add(i uint32) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeUint32Set) Contains(i ...uint32) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeUint32Set) IsSubset(other Uint32Set) bool {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint32Set) IsProperSubset(other Uint32Set) bool {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockUint32Pair(set, o)
+	defer runlockUint32Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeUint32Set) IsProperSuperset(other Uint32Set) bool {
 func (set *threadSafeUint32Set) Union(other Uint32Set) Uint32Set {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
+	readUint32Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeUint32Set)
 	ret := &threadSafeUint32Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint32Set) Intersect(other Uint32Set) Uint32Set {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
+	readUint32Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeUint32Set)
 	ret := &threadSafeUint32Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint32Set) Difference(other Uint32Set) Uint32Set {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
+	readUint32Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeUint32Set)
 	ret := &threadSafeUint32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint32Set) SymmetricDifference(other Uint32Set) Uint32Set {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
+	readUint32Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeUint32Set)
 	ret := &threadSafeUint32Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint32Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeUint32Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeUint32Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeUint32Set) Remove(i uint32) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyUint32(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeUint32Set) Cardinality() int {
 }
 
 func (set *threadSafeUint32Set) Each(cb func(uint32) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeUint32Set) Iterator() *Uint32Iterator {
 func (set *threadSafeUint32Set) Equal(other Uint32Set) bool {
 	o := other.(*threadSafeUint32Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint32Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint32Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeUint32Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint32Set) PowerSet() Uint32Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeUint32Set)
-    set.RUnlock()
+func (set *threadSafeUint32Set) PowerSet() []Uint32Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeUint32Set{s: newThreadUnsafeUint32Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeUint32Set)
-        ret.Add(&threadSafeUint32Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Uint32Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeUint32Set)
+		ret = append(ret, &threadSafeUint32Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeUint32Set) Pop() uint32 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint32Set) CartesianProduct(other Uint32Set) Uint32Set {
-    o := other.(*threadSafeUint32Set)
+func (set *threadSafeUint32Set) CartesianProduct(other Uint32Set) Uint32PairSet {
+	o := other.(*threadSafeUint32Set)
 
-    set.RLock()
-    o.RLock()
+	rlockUint32Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint32Set)
-    ret := &threadSafeUint32Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint32PairSet)
+	ret := &threadSafeUint32PairSet{s: *unsafeCartProduct}
+	runlockUint32Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeUint32Set) ToSlice() []uint32 {
-	keys := make([]uint32, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]uint32, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeUint32Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeUint32Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
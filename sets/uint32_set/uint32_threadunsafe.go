@@ -1,7 +1,10 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
 package mapsetuint32
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeUint32Set map[uint32]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  uint32
-	Second uint32
-}
-
 func newThreadUnsafeUint32Set() threadUnsafeUint32Set {
 	return make(threadUnsafeUint32Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeUint32SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeUint32SetWithCapacity(n int) threadUnsafeUint32Set {
+	return make(threadUnsafeUint32Set, n)
+}
 
-	return false
+// keyUint32 returns the map key of i.
+func keyUint32(i uint32) uint32 {
+	return i
 }
 
 func (set *threadUnsafeUint32Set) Add(i uint32) bool {
+	defer set.guardWrite().done()
+	i = keyUint32(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeUint32Set) Add(i uint32) bool {
 }
 
 func (set *threadUnsafeUint32Set) Contains(i ...uint32) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyUint32(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeUint32Set) Contains(i ...uint32) bool {
 
 func (set *threadUnsafeUint32Set) IsSubset(other Uint32Set) bool {
 	_ = other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeUint32Set) IsSubset(other Uint32Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeUint32Set) IsProperSubset(other Uint32Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeUint32Set) IsSuperset(other Uint32Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeUint32Set) IsSuperset(other Uint32Set) bool {
 }
 
 func (set *threadUnsafeUint32Set) IsProperSuperset(other Uint32Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeUint32Set) Union(other Uint32Set) Uint32Set {
 	o := other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeUint32Set()
+	unionedSet := newThreadUnsafeUint32SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeUint32Set) Union(other Uint32Set) Uint32Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUint32Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeUint32Set) Intersect(other Uint32Set) Uint32Set {
 	o := other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeUint32Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeUint32Set) Intersect(other Uint32Set) Uint32Set {
 			}
 		}
 	}
+	trackUint32Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeUint32Set) Difference(other Uint32Set) Uint32Set {
 	_ = other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeUint32Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeUint32Set) Difference(other Uint32Set) Uint32Set {
 			difference.Add(elem)
 		}
 	}
+	trackUint32Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeUint32Set) SymmetricDifference(other Uint32Set) Uint32Set {
-	_ = other.(*threadUnsafeUint32Set)
+	o := other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeUint32Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUint32Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeUint32Set) Clear() {
-	*set = newThreadUnsafeUint32Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeUint32Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeUint32SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeUint32Set) Remove(i uint32) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyUint32(i))
 }
 
 func (set *threadUnsafeUint32Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeUint32Set) Each(cb func(uint32) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeUint32Set) Each(cb func(uint32) bool) {
 func (set *threadUnsafeUint32Set) Iter() <-chan uint32 {
 	ch := make(chan uint32)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeUint32Set) Iterator() *Uint32Iterator {
 	iterator, ch, stopCh := newUint32Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeUint32Set) Iterator() *Uint32Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeUint32Set) Iterator() *Uint32Iterator {
 
 func (set *threadUnsafeUint32Set) Equal(other Uint32Set) bool {
 	_ = other.(*threadUnsafeUint32Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeUint32Set) Equal(other Uint32Set) bool {
 }
 
 func (set *threadUnsafeUint32Set) Clone() Uint32Set {
-	clonedSet := newThreadUnsafeUint32Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeUint32SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUint32Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeUint32Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeUint32Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeUint32Set) Pop() uint32 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeUint32Set) Pop() uint32 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint32Set) PowerSet() Uint32Set {
-	powSet := NewThreadUnsafeUint32Set()
+func (set *threadUnsafeUint32Set) PowerSet() []Uint32Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeUint32Set()
-	powSet.Add(&nullset)
+	powSet := []Uint32Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeUint32Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeUint32Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeUint32Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint32Set) CartesianProduct(other Uint32Set) Uint32Set {
+func (set *threadUnsafeUint32Set) CartesianProduct(other Uint32Set) Uint32PairSet {
 	o := other.(*threadUnsafeUint32Set)
-	cartProduct := NewThreadUnsafeUint32Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeUint32PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Uint32Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeUint32Set) ToSlice() []uint32 {
+	defer set.guardRead().done()
 	keys := make([]uint32, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeUint32Set) ToSlice() []uint32 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeUint32Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Uint32JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeUint32Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeUint32Set) UnmarshalJSON(data []byte) error {
 	var i []uint32
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
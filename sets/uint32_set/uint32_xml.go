@@ -0,0 +1,154 @@
+// Code generated by generate_set for uint32. DO NOT EDIT.
+
+package mapsetuint32
+
+import (
+	"encoding/xml"
+)
+
+// DefaultUint32XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultUint32XMLItemName = "item"
+
+// Uint32XML adapts a Uint32Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Uint32XML struct {
+	Set Uint32Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultUint32XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Uint32XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint32XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Uint32XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint32XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Uint32XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultUint32XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeUint32XML(e *xml.Encoder, start xml.StartElement, s Uint32Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortUint32Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatUint32Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeUint32XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeUint32XML(dec *xml.Decoder, itemName string) ([]uint32, error) {
+	var elems []uint32
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseUint32Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint32XMLItemName. Use Uint32XML for other
+// names.
+func (set *threadUnsafeUint32Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint32XML(e, start, set, DefaultUint32XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeUint32Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint32XML(d, DefaultUint32XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeUint32Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint32XMLItemName. Use Uint32XML for other
+// names.
+func (set *threadSafeUint32Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint32XML(e, start, set, DefaultUint32XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeUint32Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint32XML(d, DefaultUint32XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeUint32Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Uint64Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleUint64Values[i%len(sampleUint64Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewUint64Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeUint64Set())
+}
+
+func benchContains(b *testing.B, s Uint64Set) {
+	for _, v := range sampleUint64Values[:len(sampleUint64Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleUint64Values[i%len(sampleUint64Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewUint64Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeUint64Set())
+}
+
+func benchUnion(b *testing.B, x, y Uint64Set) {
+	half := len(sampleUint64Values) / 2
+	for _, v := range sampleUint64Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleUint64Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewUint64Set(), NewUint64Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeUint64Set(), NewThreadUnsafeUint64Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Uint64Set) {
+	for _, v := range sampleUint64Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewUint64Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeUint64Set())
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s Uint64Set) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s Uint64Set) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertUint64RoundTrip(t, marshal, unmarshal)
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeUint64Set()
+		return &s
+	},
+}
+
+// NewScratchUint64Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchUint64Set() Uint64Set {
+	return scratchPool.Get().(*threadUnsafeUint64Set)
+}
+
+// Release empties s and makes it available to NewScratchUint64Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Uint64Set) {
+	u, ok := s.(*threadUnsafeUint64Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Uint64Set) {
+	d, x, y, locks := lockUint64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllUint64(d, y)
+	case dst == b:
+		addAllUint64(d, x)
+	default:
+		clear(d)
+		addAllUint64(d, x)
+		addAllUint64(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Uint64Set) {
+	d, x, y, locks := lockUint64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyUint64(d, y)
+	case dst == b:
+		keepOnlyUint64(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Uint64Set) {
+	d, x, y, locks := lockUint64Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchUint64Set().(*threadUnsafeUint64Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllUint64(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllUint64(dst, src threadUnsafeUint64Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyUint64 removes the elements of dst that are not in other.
+func keepOnlyUint64(dst, other threadUnsafeUint64Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoUint64Locks records the locks taken by lockUint64Into, or the guards for
+// thread-unsafe sets.
+type intoUint64Locks struct {
+	sets     [3]*threadSafeUint64Set
+	distinct int
+	dst      *threadSafeUint64Set
+	guards   [3]unsafeUint64Guard
+}
+
+// lockUint64Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockUint64Into(dst, a, b Uint64Set) (d, x, y threadUnsafeUint64Set, locks intoUint64Locks) {
+	w, ok := dst.(*threadSafeUint64Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeUint64Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeUint64Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeUint64Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeUint64Set), *b.(*threadUnsafeUint64Set), locks
+	}
+
+	locks.sets = [3]*threadSafeUint64Set{w, a.(*threadSafeUint64Set), b.(*threadSafeUint64Set)}
+	locks.distinct = len(lockUint64Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeUint64Set).s, b.(*threadSafeUint64Set).s, locks
+}
+
+func (l intoUint64Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleUint64Values)
+	aValues, bValues := sampleUint64Values[:n-1], sampleUint64Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Uint64Set)
+		expected []uint64
+	}{
+		{"UnionInto", UnionInto, sampleUint64Values},
+		{"IntersectInto", IntersectInto, sampleUint64Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleUint64Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range uint64SetFactories() {
+			expected := fillUint64Set(newSet(), testCase.expected...)
+
+			dst := fillUint64Set(newSet(), sampleUint64Values[n-1])
+			testCase.into(dst, fillUint64Set(newSet(), aValues...), fillUint64Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillUint64Set(newSet(), aValues...)
+			testCase.into(a, a, fillUint64Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillUint64Set(newSet(), bValues...)
+			testCase.into(b, fillUint64Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range uint64SetFactories() {
+		s := fillUint64Set(newSet(), sampleUint64Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchUint64Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleUint64Values[0])
+	Release(s)
+	Release(NewUint64Set(sampleUint64Values[0]))
+
+	if again := NewScratchUint64Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s Uint64Set) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s Uint64Set) error { return json.Unmarshal(b, s) }
+	assertUint64RoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewUint64Set(sampleUint64Values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUint64Set()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleUint64Values[i%len(sampleUint64Values)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewUint64Set(sampleUint64Values...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleUint64Values, s)
+	}
+}
@@ -0,0 +1,20 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetuint64
+
+// unsafeUint64Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeUint64Guard struct{}
+
+func trackUint64Set(set *threadUnsafeUint64Set) {}
+
+func (set *threadUnsafeUint64Set) guardRead() unsafeUint64Guard { return unsafeUint64Guard{} }
+
+func (set *threadUnsafeUint64Set) guardWrite() unsafeUint64Guard { return unsafeUint64Guard{} }
+
+func (set *threadUnsafeUint64Set) guardIterate() unsafeUint64Guard { return unsafeUint64Guard{} }
+
+func (unsafeUint64Guard) done() {}
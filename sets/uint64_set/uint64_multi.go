@@ -0,0 +1,110 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Uint64Set) Uint64Set {
+	if len(sets) == 0 {
+		return NewUint64Set()
+	}
+	maps, unlock := readLockUint64Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeUint64Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapUint64Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Uint64Set) Uint64Set {
+	if len(sets) == 0 {
+		return NewUint64Set()
+	}
+	maps, unlock := readLockUint64Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeUint64Set()
+	for elem := range maps[smallest] {
+		if containedInAllUint64(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapUint64Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Uint64Set, sets ...Uint64Set) Uint64Set {
+	maps, unlock := readLockUint64Sets(append([]Uint64Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeUint64Set()
+	for elem := range maps[0] {
+		if !containedInAnyUint64(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapUint64Like(base, difference)
+}
+
+func containedInAllUint64(maps []threadUnsafeUint64Set, skip int, elem uint64) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyUint64(maps []threadUnsafeUint64Set, elem uint64) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapUint64Like returns s as a Set of the same implementation as like.
+func wrapUint64Like(like Uint64Set, s threadUnsafeUint64Set) Uint64Set {
+	if _, ok := like.(*threadSafeUint64Set); ok {
+		return &threadSafeUint64Set{s: s}
+	}
+	trackUint64Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Uint64Pair represents a 2-tuple of values.
+type Uint64Pair struct {
+	First  uint64
+	Second uint64
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Uint64Pair) Equal(other Uint64Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Uint64Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Uint64PairSet is an unordered set of Uint64Pairs, as
+// returned by CartesianProduct.
+type Uint64PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Uint64Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Uint64Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Uint64Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Uint64PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Uint64Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Uint64Pair
+}
+
+type threadUnsafeUint64PairSet map[Uint64Pair]struct{}
+
+func newThreadUnsafeUint64PairSet() threadUnsafeUint64PairSet {
+	return make(threadUnsafeUint64PairSet)
+}
+
+func (set *threadUnsafeUint64PairSet) Add(p Uint64Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeUint64PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeUint64PairSet) Contains(p ...Uint64Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint64PairSet) Each(cb func(Uint64Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeUint64PairSet) Equal(other Uint64PairSet) bool {
+	_ = other.(*threadUnsafeUint64PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint64PairSet) Remove(p Uint64Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeUint64PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeUint64PairSet) ToSlice() []Uint64Pair {
+	keys := make([]Uint64Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeUint64PairSet struct {
+	s threadUnsafeUint64PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeUint64PairSet) Add(p Uint64Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeUint64PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeUint64PairSet) Contains(p ...Uint64Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint64PairSet) Each(cb func(Uint64Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockUint64PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockUint64Pair does for sets.
+func rlockUint64PairSets(x, y *threadSafeUint64PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint64PairSets releases the locks taken by rlockUint64PairSets.
+func runlockUint64PairSets(x, y *threadSafeUint64PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeUint64PairSet) Equal(other Uint64PairSet) bool {
+	o := other.(*threadSafeUint64PairSet)
+
+	rlockUint64PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockUint64PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeUint64PairSet) Remove(p Uint64Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeUint64PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint64PairSet) ToSlice() []Uint64Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
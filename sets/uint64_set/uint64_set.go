@@ -1,3 +1,5 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
 package mapsetuint64
 
 // Uint64Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Uint64Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Uint64Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Uint64Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() uint64
 
-	// Returns all subsets of a given set (Power Uint64Set).
-	// Not yet supported
-	// PowerSet() Uint64Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Uint64Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Uint64Set) Uint64Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Uint64Set) Uint64PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []uint64
@@ -145,7 +154,7 @@ type Uint64Set interface {
 // NewUint64Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewUint64Set(s ...uint64) Uint64Set {
-	set := newThreadSafeUint64Set()
+	set := threadSafeUint64Set{s: newThreadUnsafeUint64SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewUint64SetFromSlice(s []uint64) Uint64Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeUint64Set() Uint64Set {
 	set := newThreadUnsafeUint64Set()
+	trackUint64Set(&set)
+	return &set
+}
+
+// NewUint64SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewUint64SetWithCapacity(n int) Uint64Set {
+	return &threadSafeUint64Set{s: newThreadUnsafeUint64SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeUint64SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeUint64SetWithCapacity(n int) Uint64Set {
+	set := newThreadUnsafeUint64SetWithCapacity(n)
+	trackUint64Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeUint64Set() Uint64Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeUint64SetFromSlice(s []uint64) Uint64Set {
-	a := NewThreadUnsafeUint64Set()
+	a := NewThreadUnsafeUint64SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
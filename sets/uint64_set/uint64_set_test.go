@@ -0,0 +1,105 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleUint64Values are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleUint64Values = []uint64{
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+	128,
+	255,
+}
+
+// uint64SetFactories returns constructors for both implementations.
+func uint64SetFactories() map[string]func() Uint64Set {
+	return map[string]func() Uint64Set{
+		"safe":   func() Uint64Set { return NewUint64Set() },
+		"unsafe": NewThreadUnsafeUint64Set,
+	}
+}
+
+// fillUint64Set adds values to s and returns it.
+func fillUint64Set(s Uint64Set, values ...uint64) Uint64Set {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertUint64RoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertUint64RoundTrip(t *testing.T, marshal func(Uint64Set) ([]byte, error), unmarshal func([]byte, Uint64Set) error) {
+	t.Helper()
+	contents := [][]uint64{sampleUint64Values, sampleUint64Values[:1]}
+	for name, newSet := range uint64SetFactories() {
+		for _, values := range contents {
+			s := fillUint64Set(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range uint64SetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[uint64, Uint64Set]{
+				New:   newSet,
+				Elems: sampleUint64Values,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range uint64SetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleUint64Values[0])
+		a.Add(sampleUint64Values[1])
+		for _, v := range sampleUint64Values {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleUint64Values); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(Uint64Pair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"sort"
+)
+
+// lessUint64 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessUint64(a, b uint64) bool {
+	return a < b
+}
+
+// sortUint64Elements sorts elems in place using lessUint64.
+func sortUint64Elements(elems []uint64) {
+	sort.Slice(elems, func(i, j int) bool { return lessUint64(elems[i], elems[j]) })
+}
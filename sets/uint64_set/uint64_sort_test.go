@@ -0,0 +1,26 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]uint64, 0, len(sampleUint64Values))
+	for i := len(sampleUint64Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleUint64Values[i])
+	}
+	sortUint64Elements(elems)
+	for i, elem := range elems {
+		if lessUint64(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessUint64(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewUint64Set(elems...).Equal(NewUint64Set(sampleUint64Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleUint64Values, elems)
+	}
+}
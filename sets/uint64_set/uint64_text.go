@@ -0,0 +1,269 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Uint64TextFormat describes how a set is written to and read from a
+// single line of text, such as a command line flag or an environment
+// variable.
+//
+// Elements are joined by Separator. Occurrences of the separator, of a
+// double quote or of a backslash inside an element are escaped with a
+// backslash, and an empty element is written as "" so that a set of
+// just the empty element is not read back as an empty set.
+type Uint64TextFormat struct {
+	// Separator delimits elements. Defaults to ",".
+	Separator string
+
+	// Quote writes string elements using strconv.Quote.
+	// It has no effect on uint64 elements.
+	Quote bool
+}
+
+// DefaultUint64TextFormat is the format used by MarshalText,
+// UnmarshalText and NewUint64FlagValue.
+var DefaultUint64TextFormat = Uint64TextFormat{Separator: ","}
+
+var errTextEscape = errors.New("mapsetuint64: text ends with an unfinished escape")
+var errTextQuote = errors.New("mapsetuint64: text has an unterminated quote")
+
+// Marshal renders the elements of s as text, in sorted order.
+func (format Uint64TextFormat) Marshal(s Uint64Set) ([]byte, error) {
+	elems := s.ToSlice()
+	sortUint64Elements(elems)
+
+	items := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		item, err := formatUint64Text(elem)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, format.escape(item))
+	}
+	return []byte(strings.Join(items, format.separator())), nil
+}
+
+// Unmarshal adds the elements read from text to s. Nothing is added if
+// any element fails to parse. Empty text holds no elements.
+func (format Uint64TextFormat) Unmarshal(text []byte, s Uint64Set) error {
+	elems, err := format.parse(string(text))
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func (format Uint64TextFormat) separator() string {
+	if format.Separator == "" {
+		return ","
+	}
+	return format.Separator
+}
+
+func (format Uint64TextFormat) parse(text string) ([]uint64, error) {
+	fields, err := format.split(text)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]uint64, 0, len(fields))
+	for _, field := range fields {
+		item, err := format.unescape(field)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := parseUint64Text(item)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// split cuts text at every separator that is neither escaped nor, when
+// quoting, inside quotes. Escapes and quotes are kept in the fields.
+func (format Uint64TextFormat) split(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	sep := format.separator()
+	var fields []string
+	var field strings.Builder
+	inQuote := false
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\':
+			if i+1 == len(text) {
+				return nil, errTextEscape
+			}
+			field.WriteString(text[i : i+2])
+			i += 2
+			continue
+		case format.Quote && c == '"':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(text[i:], sep):
+			fields = append(fields, field.String())
+			field.Reset()
+			i += len(sep)
+			continue
+		}
+		field.WriteByte(text[i])
+		i++
+	}
+	if inQuote {
+		return nil, errTextQuote
+	}
+	return append(fields, field.String()), nil
+}
+
+func (format Uint64TextFormat) escape(item string) string {
+	if item == "" {
+		return `""`
+	}
+	sep := format.separator()
+	if !strings.ContainsAny(item, `\"`) && !strings.Contains(item, sep) {
+		return item
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(item); {
+		if strings.HasPrefix(item[i:], sep) {
+			for j := 0; j < len(sep); j++ {
+				b.WriteByte('\\')
+				b.WriteByte(sep[j])
+			}
+			i += len(sep)
+			continue
+		}
+		if c := item[i]; c == '\\' || c == '"' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(item[i])
+		i++
+	}
+	return b.String()
+}
+
+func (format Uint64TextFormat) unescape(field string) (string, error) {
+	if format.Quote {
+		if quoted := strings.TrimSpace(field); strings.HasPrefix(quoted, `"`) {
+			return strconv.Unquote(quoted)
+		}
+	}
+	if field == `""` {
+		return "", nil
+	}
+	if !strings.Contains(field, `\`) {
+		return field, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' {
+			i++
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String(), nil
+}
+
+// formatUint64Text renders a single element as text.
+func formatUint64Text(elem uint64) (string, error) {
+	return strconv.FormatUint(uint64(elem), 10), nil
+}
+
+// parseUint64Text parses a single element rendered by
+// formatUint64Text. Surrounding white space is ignored.
+func parseUint64Text(item string) (uint64, error) {
+	u, err := strconv.ParseUint(strings.TrimSpace(item), 10, 64)
+	return uint64(u), err
+}
+
+// Uint64FlagValue adapts a Uint64Set to the flag.Value interface.
+// Every occurrence of the flag adds its elements to the set, so both
+// -flag=a,b and -flag=a -flag=b collect the same elements.
+type Uint64FlagValue struct {
+	set    Uint64Set
+	Format Uint64TextFormat
+}
+
+// NewUint64FlagValue returns a flag.Value that adds elements to s using
+// DefaultUint64TextFormat.
+func NewUint64FlagValue(s Uint64Set) *Uint64FlagValue {
+	return &Uint64FlagValue{set: s, Format: DefaultUint64TextFormat}
+}
+
+// String renders the set as text, as required by flag.Value.
+func (v *Uint64FlagValue) String() string {
+	if v == nil || v.set == nil {
+		return ""
+	}
+	b, err := v.Format.Marshal(v.set)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set adds the elements read from text to the set, as required by
+// flag.Value.
+func (v *Uint64FlagValue) Set(text string) error {
+	return v.Format.Unmarshal([]byte(text), v.set)
+}
+
+// MarshalText renders the set using DefaultUint64TextFormat.
+func (set *threadUnsafeUint64Set) MarshalText() ([]byte, error) {
+	return DefaultUint64TextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultUint64TextFormat. The set is left untouched
+// on error.
+func (set *threadUnsafeUint64Set) UnmarshalText(text []byte) error {
+	elems, err := DefaultUint64TextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeUint64Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalText renders the set using DefaultUint64TextFormat.
+func (set *threadSafeUint64Set) MarshalText() ([]byte, error) {
+	return DefaultUint64TextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultUint64TextFormat. The set is left untouched
+// on error.
+func (set *threadSafeUint64Set) UnmarshalText(text []byte) error {
+	elems, err := DefaultUint64TextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeUint64Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
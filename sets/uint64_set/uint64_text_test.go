@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
+package mapsetuint64
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s Uint64Set) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s Uint64Set) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertUint64RoundTrip(t, marshal, unmarshal)
+}
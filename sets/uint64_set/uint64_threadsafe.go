@@ -1,11 +1,16 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
 package mapsetuint64
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeUint64Set struct {
-	s threadUnsafeUint64Set
+	s     threadUnsafeUint64Set
+	stats atomic.Pointer[Uint64SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeUint64Set() threadSafeUint64Set {
 	return threadSafeUint64Set{s: newThreadUnsafeUint64Set()}
 }
 
+// readLockUint64Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockUint64Sets(sets []Uint64Set) ([]threadUnsafeUint64Set, func()) {
+	maps := make([]threadUnsafeUint64Set, len(sets))
+	if _, ok := sets[0].(*threadSafeUint64Set); !ok {
+		guards := make([]unsafeUint64Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeUint64Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeUint64Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeUint64Set))
+	}
+	distinct := lockUint64Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeUint64Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockUint64Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockUint64Order(sets []*threadSafeUint64Set) []*threadSafeUint64Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockUint64Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockUint64Pair(x, y *threadSafeUint64Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint64Pair releases the locks taken by rlockUint64Pair.
+func runlockUint64Pair(x, y *threadSafeUint64Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeUint64Set) Add(i uint64) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeUint64Set) Contains(i ...uint64) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeUint64Set) IsSubset(other Uint64Set) bool {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint64Set) IsProperSubset(other Uint64Set) bool {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockUint64Pair(set, o)
+	defer runlockUint64Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeUint64Set) IsProperSuperset(other Uint64Set) bool {
 func (set *threadSafeUint64Set) Union(other Uint64Set) Uint64Set {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
+	readUint64Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeUint64Set)
 	ret := &threadSafeUint64Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint64Set) Intersect(other Uint64Set) Uint64Set {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
+	readUint64Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeUint64Set)
 	ret := &threadSafeUint64Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint64Set) Difference(other Uint64Set) Uint64Set {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
+	readUint64Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeUint64Set)
 	ret := &threadSafeUint64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint64Set) SymmetricDifference(other Uint64Set) Uint64Set {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
+	readUint64Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeUint64Set)
 	ret := &threadSafeUint64Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint64Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeUint64Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeUint64Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeUint64Set) Remove(i uint64) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyUint64(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeUint64Set) Cardinality() int {
 }
 
 func (set *threadSafeUint64Set) Each(cb func(uint64) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeUint64Set) Iterator() *Uint64Iterator {
 func (set *threadSafeUint64Set) Equal(other Uint64Set) bool {
 	o := other.(*threadSafeUint64Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint64Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint64Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeUint64Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint64Set) PowerSet() Uint64Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeUint64Set)
-    set.RUnlock()
+func (set *threadSafeUint64Set) PowerSet() []Uint64Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeUint64Set{s: newThreadUnsafeUint64Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeUint64Set)
-        ret.Add(&threadSafeUint64Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Uint64Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeUint64Set)
+		ret = append(ret, &threadSafeUint64Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeUint64Set) Pop() uint64 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint64Set) CartesianProduct(other Uint64Set) Uint64Set {
-    o := other.(*threadSafeUint64Set)
+func (set *threadSafeUint64Set) CartesianProduct(other Uint64Set) Uint64PairSet {
+	o := other.(*threadSafeUint64Set)
 
-    set.RLock()
-    o.RLock()
+	rlockUint64Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint64Set)
-    ret := &threadSafeUint64Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint64PairSet)
+	ret := &threadSafeUint64PairSet{s: *unsafeCartProduct}
+	runlockUint64Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeUint64Set) ToSlice() []uint64 {
-	keys := make([]uint64, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]uint64, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeUint64Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeUint64Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
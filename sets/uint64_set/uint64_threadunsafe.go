@@ -1,7 +1,10 @@
+// Code generated by generate_set for uint64. DO NOT EDIT.
+
 package mapsetuint64
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeUint64Set map[uint64]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  uint64
-	Second uint64
-}
-
 func newThreadUnsafeUint64Set() threadUnsafeUint64Set {
 	return make(threadUnsafeUint64Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeUint64SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeUint64SetWithCapacity(n int) threadUnsafeUint64Set {
+	return make(threadUnsafeUint64Set, n)
+}
 
-	return false
+// keyUint64 returns the map key of i.
+func keyUint64(i uint64) uint64 {
+	return i
 }
 
 func (set *threadUnsafeUint64Set) Add(i uint64) bool {
+	defer set.guardWrite().done()
+	i = keyUint64(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeUint64Set) Add(i uint64) bool {
 }
 
 func (set *threadUnsafeUint64Set) Contains(i ...uint64) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyUint64(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeUint64Set) Contains(i ...uint64) bool {
 
 func (set *threadUnsafeUint64Set) IsSubset(other Uint64Set) bool {
 	_ = other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeUint64Set) IsSubset(other Uint64Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeUint64Set) IsProperSubset(other Uint64Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeUint64Set) IsSuperset(other Uint64Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeUint64Set) IsSuperset(other Uint64Set) bool {
 }
 
 func (set *threadUnsafeUint64Set) IsProperSuperset(other Uint64Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeUint64Set) Union(other Uint64Set) Uint64Set {
 	o := other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeUint64Set()
+	unionedSet := newThreadUnsafeUint64SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeUint64Set) Union(other Uint64Set) Uint64Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUint64Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeUint64Set) Intersect(other Uint64Set) Uint64Set {
 	o := other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeUint64Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeUint64Set) Intersect(other Uint64Set) Uint64Set {
 			}
 		}
 	}
+	trackUint64Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeUint64Set) Difference(other Uint64Set) Uint64Set {
 	_ = other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeUint64Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeUint64Set) Difference(other Uint64Set) Uint64Set {
 			difference.Add(elem)
 		}
 	}
+	trackUint64Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeUint64Set) SymmetricDifference(other Uint64Set) Uint64Set {
-	_ = other.(*threadUnsafeUint64Set)
+	o := other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeUint64Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUint64Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeUint64Set) Clear() {
-	*set = newThreadUnsafeUint64Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeUint64Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeUint64SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeUint64Set) Remove(i uint64) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyUint64(i))
 }
 
 func (set *threadUnsafeUint64Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeUint64Set) Each(cb func(uint64) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeUint64Set) Each(cb func(uint64) bool) {
 func (set *threadUnsafeUint64Set) Iter() <-chan uint64 {
 	ch := make(chan uint64)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeUint64Set) Iterator() *Uint64Iterator {
 	iterator, ch, stopCh := newUint64Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeUint64Set) Iterator() *Uint64Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeUint64Set) Iterator() *Uint64Iterator {
 
 func (set *threadUnsafeUint64Set) Equal(other Uint64Set) bool {
 	_ = other.(*threadUnsafeUint64Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeUint64Set) Equal(other Uint64Set) bool {
 }
 
 func (set *threadUnsafeUint64Set) Clone() Uint64Set {
-	clonedSet := newThreadUnsafeUint64Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeUint64SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUint64Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeUint64Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeUint64Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeUint64Set) Pop() uint64 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeUint64Set) Pop() uint64 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint64Set) PowerSet() Uint64Set {
-	powSet := NewThreadUnsafeUint64Set()
+func (set *threadUnsafeUint64Set) PowerSet() []Uint64Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeUint64Set()
-	powSet.Add(&nullset)
+	powSet := []Uint64Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeUint64Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeUint64Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeUint64Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint64Set) CartesianProduct(other Uint64Set) Uint64Set {
+func (set *threadUnsafeUint64Set) CartesianProduct(other Uint64Set) Uint64PairSet {
 	o := other.(*threadUnsafeUint64Set)
-	cartProduct := NewThreadUnsafeUint64Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeUint64PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Uint64Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeUint64Set) ToSlice() []uint64 {
+	defer set.guardRead().done()
 	keys := make([]uint64, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeUint64Set) ToSlice() []uint64 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeUint64Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Uint64JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeUint64Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeUint64Set) UnmarshalJSON(data []byte) error {
 	var i []uint64
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
@@ -0,0 +1,86 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s Uint8Set) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleUint8Values[i%len(sampleUint8Values)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewUint8Set())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeUint8Set())
+}
+
+func benchContains(b *testing.B, s Uint8Set) {
+	for _, v := range sampleUint8Values[:len(sampleUint8Values)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleUint8Values[i%len(sampleUint8Values)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewUint8Set())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeUint8Set())
+}
+
+func benchUnion(b *testing.B, x, y Uint8Set) {
+	half := len(sampleUint8Values) / 2
+	for _, v := range sampleUint8Values[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleUint8Values[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewUint8Set(), NewUint8Set())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeUint8Set(), NewThreadUnsafeUint8Set())
+}
+
+func benchMarshalJSON(b *testing.B, s Uint8Set) {
+	for _, v := range sampleUint8Values {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewUint8Set())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeUint8Set())
+}
@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s Uint8Set) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s Uint8Set) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertUint8RoundTrip(t, marshal, unmarshal)
+}
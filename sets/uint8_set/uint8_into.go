@@ -0,0 +1,195 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeUint8Set()
+		return &s
+	},
+}
+
+// NewScratchUint8Set returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchUint8Set() Uint8Set {
+	return scratchPool.Get().(*threadUnsafeUint8Set)
+}
+
+// Release empties s and makes it available to NewScratchUint8Set. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s Uint8Set) {
+	u, ok := s.(*threadUnsafeUint8Set)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b Uint8Set) {
+	d, x, y, locks := lockUint8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllUint8(d, y)
+	case dst == b:
+		addAllUint8(d, x)
+	default:
+		clear(d)
+		addAllUint8(d, x)
+		addAllUint8(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b Uint8Set) {
+	d, x, y, locks := lockUint8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyUint8(d, y)
+	case dst == b:
+		keepOnlyUint8(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b Uint8Set) {
+	d, x, y, locks := lockUint8Into(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchUint8Set().(*threadUnsafeUint8Set)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllUint8(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllUint8(dst, src threadUnsafeUint8Set) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyUint8 removes the elements of dst that are not in other.
+func keepOnlyUint8(dst, other threadUnsafeUint8Set) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoUint8Locks records the locks taken by lockUint8Into, or the guards for
+// thread-unsafe sets.
+type intoUint8Locks struct {
+	sets     [3]*threadSafeUint8Set
+	distinct int
+	dst      *threadSafeUint8Set
+	guards   [3]unsafeUint8Guard
+}
+
+// lockUint8Into locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockUint8Into(dst, a, b Uint8Set) (d, x, y threadUnsafeUint8Set, locks intoUint8Locks) {
+	w, ok := dst.(*threadSafeUint8Set)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeUint8Set)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeUint8Set).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeUint8Set).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeUint8Set), *b.(*threadUnsafeUint8Set), locks
+	}
+
+	locks.sets = [3]*threadSafeUint8Set{w, a.(*threadSafeUint8Set), b.(*threadSafeUint8Set)}
+	locks.distinct = len(lockUint8Order(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeUint8Set).s, b.(*threadSafeUint8Set).s, locks
+}
+
+func (l intoUint8Locks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
@@ -0,0 +1,74 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleUint8Values)
+	aValues, bValues := sampleUint8Values[:n-1], sampleUint8Values[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b Uint8Set)
+		expected []uint8
+	}{
+		{"UnionInto", UnionInto, sampleUint8Values},
+		{"IntersectInto", IntersectInto, sampleUint8Values[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleUint8Values[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range uint8SetFactories() {
+			expected := fillUint8Set(newSet(), testCase.expected...)
+
+			dst := fillUint8Set(newSet(), sampleUint8Values[n-1])
+			testCase.into(dst, fillUint8Set(newSet(), aValues...), fillUint8Set(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillUint8Set(newSet(), aValues...)
+			testCase.into(a, a, fillUint8Set(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillUint8Set(newSet(), bValues...)
+			testCase.into(b, fillUint8Set(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range uint8SetFactories() {
+		s := fillUint8Set(newSet(), sampleUint8Values...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchUint8Set()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleUint8Values[0])
+	Release(s)
+	Release(NewUint8Set(sampleUint8Values[0]))
+
+	if again := NewScratchUint8Set(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
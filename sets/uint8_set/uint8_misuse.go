@@ -0,0 +1,20 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetuint8
+
+// unsafeUint8Guard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeUint8Guard struct{}
+
+func trackUint8Set(set *threadUnsafeUint8Set) {}
+
+func (set *threadUnsafeUint8Set) guardRead() unsafeUint8Guard { return unsafeUint8Guard{} }
+
+func (set *threadUnsafeUint8Set) guardWrite() unsafeUint8Guard { return unsafeUint8Guard{} }
+
+func (set *threadUnsafeUint8Set) guardIterate() unsafeUint8Guard { return unsafeUint8Guard{} }
+
+func (unsafeUint8Guard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...Uint8Set) Uint8Set {
+	if len(sets) == 0 {
+		return NewUint8Set()
+	}
+	maps, unlock := readLockUint8Sets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeUint8Set, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapUint8Like(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...Uint8Set) Uint8Set {
+	if len(sets) == 0 {
+		return NewUint8Set()
+	}
+	maps, unlock := readLockUint8Sets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeUint8Set()
+	for elem := range maps[smallest] {
+		if containedInAllUint8(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapUint8Like(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base Uint8Set, sets ...Uint8Set) Uint8Set {
+	maps, unlock := readLockUint8Sets(append([]Uint8Set{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeUint8Set()
+	for elem := range maps[0] {
+		if !containedInAnyUint8(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapUint8Like(base, difference)
+}
+
+func containedInAllUint8(maps []threadUnsafeUint8Set, skip int, elem uint8) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyUint8(maps []threadUnsafeUint8Set, elem uint8) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapUint8Like returns s as a Set of the same implementation as like.
+func wrapUint8Like(like Uint8Set, s threadUnsafeUint8Set) Uint8Set {
+	if _, ok := like.(*threadSafeUint8Set); ok {
+		return &threadSafeUint8Set{s: s}
+	}
+	trackUint8Set(&s)
+	return &s
+}
@@ -0,0 +1,224 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A Uint8Pair represents a 2-tuple of values.
+type Uint8Pair struct {
+	First  uint8
+	Second uint8
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *Uint8Pair) Equal(other Uint8Pair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair Uint8Pair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// Uint8PairSet is an unordered set of Uint8Pairs, as
+// returned by CartesianProduct.
+type Uint8PairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p Uint8Pair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...Uint8Pair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(Uint8Pair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other Uint8PairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p Uint8Pair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []Uint8Pair
+}
+
+type threadUnsafeUint8PairSet map[Uint8Pair]struct{}
+
+func newThreadUnsafeUint8PairSet() threadUnsafeUint8PairSet {
+	return make(threadUnsafeUint8PairSet)
+}
+
+func (set *threadUnsafeUint8PairSet) Add(p Uint8Pair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeUint8PairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeUint8PairSet) Contains(p ...Uint8Pair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint8PairSet) Each(cb func(Uint8Pair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeUint8PairSet) Equal(other Uint8PairSet) bool {
+	_ = other.(*threadUnsafeUint8PairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUint8PairSet) Remove(p Uint8Pair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeUint8PairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeUint8PairSet) ToSlice() []Uint8Pair {
+	keys := make([]Uint8Pair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeUint8PairSet struct {
+	s threadUnsafeUint8PairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeUint8PairSet) Add(p Uint8Pair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeUint8PairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeUint8PairSet) Contains(p ...Uint8Pair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint8PairSet) Each(cb func(Uint8Pair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockUint8PairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockUint8Pair does for sets.
+func rlockUint8PairSets(x, y *threadSafeUint8PairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint8PairSets releases the locks taken by rlockUint8PairSets.
+func runlockUint8PairSets(x, y *threadSafeUint8PairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeUint8PairSet) Equal(other Uint8PairSet) bool {
+	o := other.(*threadSafeUint8PairSet)
+
+	rlockUint8PairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockUint8PairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeUint8PairSet) Remove(p Uint8Pair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeUint8PairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUint8PairSet) ToSlice() []Uint8Pair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
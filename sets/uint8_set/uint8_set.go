@@ -1,3 +1,5 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
 package mapsetuint8
 
 // Uint8Set is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type Uint8Set interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other Uint8Set) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type Uint8Set interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() uint8
 
-	// Returns all subsets of a given set (Power Uint8Set).
-	// Not yet supported
-	// PowerSet() Uint8Set
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []Uint8Set
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other Uint8Set) Uint8Set
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other Uint8Set) Uint8PairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []uint8
@@ -145,7 +154,7 @@ type Uint8Set interface {
 // NewUint8Set creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewUint8Set(s ...uint8) Uint8Set {
-	set := newThreadSafeUint8Set()
+	set := threadSafeUint8Set{s: newThreadUnsafeUint8SetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewUint8SetFromSlice(s []uint8) Uint8Set {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeUint8Set() Uint8Set {
 	set := newThreadUnsafeUint8Set()
+	trackUint8Set(&set)
+	return &set
+}
+
+// NewUint8SetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewUint8SetWithCapacity(n int) Uint8Set {
+	return &threadSafeUint8Set{s: newThreadUnsafeUint8SetWithCapacity(n)}
+}
+
+// NewThreadUnsafeUint8SetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeUint8SetWithCapacity(n int) Uint8Set {
+	set := newThreadUnsafeUint8SetWithCapacity(n)
+	trackUint8Set(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeUint8Set() Uint8Set {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeUint8SetFromSlice(s []uint8) Uint8Set {
-	a := NewThreadUnsafeUint8Set()
+	a := NewThreadUnsafeUint8SetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"sort"
+)
+
+// lessUint8 orders elements for output that must be stable,
+// such as sorted encodings.
+func lessUint8(a, b uint8) bool {
+	return a < b
+}
+
+// sortUint8Elements sorts elems in place using lessUint8.
+func sortUint8Elements(elems []uint8) {
+	sort.Slice(elems, func(i, j int) bool { return lessUint8(elems[i], elems[j]) })
+}
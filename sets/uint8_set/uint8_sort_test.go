@@ -0,0 +1,26 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]uint8, 0, len(sampleUint8Values))
+	for i := len(sampleUint8Values) - 1; i >= 0; i-- {
+		elems = append(elems, sampleUint8Values[i])
+	}
+	sortUint8Elements(elems)
+	for i, elem := range elems {
+		if lessUint8(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessUint8(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewUint8Set(elems...).Equal(NewUint8Set(sampleUint8Values...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleUint8Values, elems)
+	}
+}
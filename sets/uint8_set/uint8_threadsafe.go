@@ -1,11 +1,16 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
 package mapsetuint8
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeUint8Set struct {
-	s threadUnsafeUint8Set
+	s     threadUnsafeUint8Set
+	stats atomic.Pointer[Uint8SetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeUint8Set() threadSafeUint8Set {
 	return threadSafeUint8Set{s: newThreadUnsafeUint8Set()}
 }
 
+// readLockUint8Sets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockUint8Sets(sets []Uint8Set) ([]threadUnsafeUint8Set, func()) {
+	maps := make([]threadUnsafeUint8Set, len(sets))
+	if _, ok := sets[0].(*threadSafeUint8Set); !ok {
+		guards := make([]unsafeUint8Guard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeUint8Set)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeUint8Set, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeUint8Set))
+	}
+	distinct := lockUint8Order(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeUint8Set).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockUint8Order sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockUint8Order(sets []*threadSafeUint8Set) []*threadSafeUint8Set {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockUint8Pair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockUint8Pair(x, y *threadSafeUint8Set) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUint8Pair releases the locks taken by rlockUint8Pair.
+func runlockUint8Pair(x, y *threadSafeUint8Set) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeUint8Set) Add(i uint8) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeUint8Set) Contains(i ...uint8) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeUint8Set) IsSubset(other Uint8Set) bool {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint8Set) IsProperSubset(other Uint8Set) bool {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockUint8Pair(set, o)
+	defer runlockUint8Pair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeUint8Set) IsProperSuperset(other Uint8Set) bool {
 func (set *threadSafeUint8Set) Union(other Uint8Set) Uint8Set {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
+	readUint8Pair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeUint8Set)
 	ret := &threadSafeUint8Set{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint8Set) Intersect(other Uint8Set) Uint8Set {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
+	readUint8Pair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeUint8Set)
 	ret := &threadSafeUint8Set{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint8Set) Difference(other Uint8Set) Uint8Set {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
+	readUint8Pair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeUint8Set)
 	ret := &threadSafeUint8Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint8Set) SymmetricDifference(other Uint8Set) Uint8Set {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
+	readUint8Pair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeUint8Set)
 	ret := &threadSafeUint8Set{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
 func (set *threadSafeUint8Set) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeUint8Set) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeUint8Set()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeUint8Set) Remove(i uint8) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyUint8(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeUint8Set) Cardinality() int {
 }
 
 func (set *threadSafeUint8Set) Each(cb func(uint8) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeUint8Set) Iterator() *Uint8Iterator {
 func (set *threadSafeUint8Set) Equal(other Uint8Set) bool {
 	o := other.(*threadSafeUint8Set)
 
-	set.RLock()
-	o.RLock()
+	rlockUint8Pair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUint8Pair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeUint8Set) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint8Set) PowerSet() Uint8Set {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeUint8Set)
-    set.RUnlock()
+func (set *threadSafeUint8Set) PowerSet() []Uint8Set {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeUint8Set{s: newThreadUnsafeUint8Set()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeUint8Set)
-        ret.Add(&threadSafeUint8Set{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]Uint8Set, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeUint8Set)
+		ret = append(ret, &threadSafeUint8Set{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeUint8Set) Pop() uint8 {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUint8Set) CartesianProduct(other Uint8Set) Uint8Set {
-    o := other.(*threadSafeUint8Set)
+func (set *threadSafeUint8Set) CartesianProduct(other Uint8Set) Uint8PairSet {
+	o := other.(*threadSafeUint8Set)
 
-    set.RLock()
-    o.RLock()
+	rlockUint8Pair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint8Set)
-    ret := &threadSafeUint8Set{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUint8PairSet)
+	ret := &threadSafeUint8PairSet{s: *unsafeCartProduct}
+	runlockUint8Pair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeUint8Set) ToSlice() []uint8 {
-	keys := make([]uint8, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]uint8, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeUint8Set) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeUint8Set) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
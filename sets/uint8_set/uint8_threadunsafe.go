@@ -1,7 +1,10 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
 package mapsetuint8
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeUint8Set map[uint8]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  uint8
-	Second uint8
-}
-
 func newThreadUnsafeUint8Set() threadUnsafeUint8Set {
 	return make(threadUnsafeUint8Set)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeUint8SetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeUint8SetWithCapacity(n int) threadUnsafeUint8Set {
+	return make(threadUnsafeUint8Set, n)
+}
 
-	return false
+// keyUint8 returns the map key of i.
+func keyUint8(i uint8) uint8 {
+	return i
 }
 
 func (set *threadUnsafeUint8Set) Add(i uint8) bool {
+	defer set.guardWrite().done()
+	i = keyUint8(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeUint8Set) Add(i uint8) bool {
 }
 
 func (set *threadUnsafeUint8Set) Contains(i ...uint8) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyUint8(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeUint8Set) Contains(i ...uint8) bool {
 
 func (set *threadUnsafeUint8Set) IsSubset(other Uint8Set) bool {
 	_ = other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeUint8Set) IsSubset(other Uint8Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeUint8Set) IsProperSubset(other Uint8Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeUint8Set) IsSuperset(other Uint8Set) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeUint8Set) IsSuperset(other Uint8Set) bool {
 }
 
 func (set *threadUnsafeUint8Set) IsProperSuperset(other Uint8Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeUint8Set) Union(other Uint8Set) Uint8Set {
 	o := other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeUint8Set()
+	unionedSet := newThreadUnsafeUint8SetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeUint8Set) Union(other Uint8Set) Uint8Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUint8Set(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeUint8Set) Intersect(other Uint8Set) Uint8Set {
 	o := other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeUint8Set()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeUint8Set) Intersect(other Uint8Set) Uint8Set {
 			}
 		}
 	}
+	trackUint8Set(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeUint8Set) Difference(other Uint8Set) Uint8Set {
 	_ = other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeUint8Set()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeUint8Set) Difference(other Uint8Set) Uint8Set {
 			difference.Add(elem)
 		}
 	}
+	trackUint8Set(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeUint8Set) SymmetricDifference(other Uint8Set) Uint8Set {
-	_ = other.(*threadUnsafeUint8Set)
+	o := other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeUint8Set()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUint8Set(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeUint8Set) Clear() {
-	*set = newThreadUnsafeUint8Set()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeUint8Set) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeUint8SetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeUint8Set) Remove(i uint8) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyUint8(i))
 }
 
 func (set *threadUnsafeUint8Set) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeUint8Set) Each(cb func(uint8) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeUint8Set) Each(cb func(uint8) bool) {
 func (set *threadUnsafeUint8Set) Iter() <-chan uint8 {
 	ch := make(chan uint8)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeUint8Set) Iterator() *Uint8Iterator {
 	iterator, ch, stopCh := newUint8Iterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeUint8Set) Iterator() *Uint8Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeUint8Set) Iterator() *Uint8Iterator {
 
 func (set *threadUnsafeUint8Set) Equal(other Uint8Set) bool {
 	_ = other.(*threadUnsafeUint8Set)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeUint8Set) Equal(other Uint8Set) bool {
 }
 
 func (set *threadUnsafeUint8Set) Clone() Uint8Set {
-	clonedSet := newThreadUnsafeUint8Set()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeUint8SetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUint8Set(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeUint8Set) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeUint8Set) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeUint8Set) Pop() uint8 {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeUint8Set) Pop() uint8 {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint8Set) PowerSet() Uint8Set {
-	powSet := NewThreadUnsafeUint8Set()
+func (set *threadUnsafeUint8Set) PowerSet() []Uint8Set {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeUint8Set()
-	powSet.Add(&nullset)
+	powSet := []Uint8Set{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeUint8Set()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeUint8Set()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeUint8Set)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeUint8Set) CartesianProduct(other Uint8Set) Uint8Set {
+func (set *threadUnsafeUint8Set) CartesianProduct(other Uint8Set) Uint8PairSet {
 	o := other.(*threadUnsafeUint8Set)
-	cartProduct := NewThreadUnsafeUint8Set()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeUint8PairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := Uint8Pair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeUint8Set) ToSlice() []uint8 {
+	defer set.guardRead().done()
 	keys := make([]uint8, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeUint8Set) ToSlice() []uint8 {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeUint8Set) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, Uint8JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeUint8Set) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeUint8Set) UnmarshalJSON(data []byte) error {
 	var i []uint8
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
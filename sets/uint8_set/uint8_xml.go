@@ -0,0 +1,154 @@
+// Code generated by generate_set for uint8. DO NOT EDIT.
+
+package mapsetuint8
+
+import (
+	"encoding/xml"
+)
+
+// DefaultUint8XMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultUint8XMLItemName = "item"
+
+// Uint8XML adapts a Uint8Set to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type Uint8XML struct {
+	Set Uint8Set
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultUint8XMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x Uint8XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint8XML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *Uint8XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint8XML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x Uint8XML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultUint8XMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeUint8XML(e *xml.Encoder, start xml.StartElement, s Uint8Set, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortUint8Elements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatUint8Text(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeUint8XML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeUint8XML(dec *xml.Decoder, itemName string) ([]uint8, error) {
+	var elems []uint8
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseUint8Text(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint8XMLItemName. Use Uint8XML for other
+// names.
+func (set *threadUnsafeUint8Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint8XML(e, start, set, DefaultUint8XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeUint8Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint8XML(d, DefaultUint8XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeUint8Set()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUint8XMLItemName. Use Uint8XML for other
+// names.
+func (set *threadSafeUint8Set) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUint8XML(e, start, set, DefaultUint8XMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeUint8Set) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUint8XML(d, DefaultUint8XMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeUint8Set()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func benchAdd(b *testing.B, s UintSet) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Add(sampleUintValues[i%len(sampleUintValues)])
+	}
+}
+
+func BenchmarkAddSafe(b *testing.B) {
+	benchAdd(b, NewUintSet())
+}
+
+func BenchmarkAddUnsafe(b *testing.B) {
+	benchAdd(b, NewThreadUnsafeUintSet())
+}
+
+func benchContains(b *testing.B, s UintSet) {
+	for _, v := range sampleUintValues[:len(sampleUintValues)/2] {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(sampleUintValues[i%len(sampleUintValues)])
+	}
+}
+
+func BenchmarkContainsSafe(b *testing.B) {
+	benchContains(b, NewUintSet())
+}
+
+func BenchmarkContainsUnsafe(b *testing.B) {
+	benchContains(b, NewThreadUnsafeUintSet())
+}
+
+func benchUnion(b *testing.B, x, y UintSet) {
+	half := len(sampleUintValues) / 2
+	for _, v := range sampleUintValues[:half] {
+		x.Add(v)
+	}
+	for _, v := range sampleUintValues[half:] {
+		y.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Union(y)
+	}
+}
+
+func BenchmarkUnionSafe(b *testing.B) {
+	benchUnion(b, NewUintSet(), NewUintSet())
+}
+
+func BenchmarkUnionUnsafe(b *testing.B) {
+	benchUnion(b, NewThreadUnsafeUintSet(), NewThreadUnsafeUintSet())
+}
+
+func benchMarshalJSON(b *testing.B, s UintSet) {
+	for _, v := range sampleUintValues {
+		s.Add(v)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSONSafe(b *testing.B) {
+	benchMarshalJSON(b, NewUintSet())
+}
+
+func BenchmarkMarshalJSONUnsafe(b *testing.B) {
+	benchMarshalJSON(b, NewThreadUnsafeUintSet())
+}
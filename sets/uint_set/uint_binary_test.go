@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	marshal := func(s UintSet) ([]byte, error) { return s.(encoding.BinaryMarshaler).MarshalBinary() }
+	unmarshal := func(b []byte, s UintSet) error { return s.(encoding.BinaryUnmarshaler).UnmarshalBinary(b) }
+	assertUintRoundTrip(t, marshal, unmarshal)
+}
@@ -0,0 +1,195 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import "sync"
+
+// scratchPoolMaxLen is the largest set that Release returns to the
+// pool; larger maps are left to the garbage collector so that one big
+// operation does not pin its memory.
+const scratchPoolMaxLen = 1 << 16
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		s := newThreadUnsafeUintSet()
+		return &s
+	},
+}
+
+// NewScratchUintSet returns an empty thread-unsafe set, reusing one given
+// to Release if possible. Scratch sets avoid allocating maps for short
+// lived intermediate results, such as the destination of UnionInto.
+func NewScratchUintSet() UintSet {
+	return scratchPool.Get().(*threadUnsafeUintSet)
+}
+
+// Release empties s and makes it available to NewScratchUintSet. s must be
+// a thread-unsafe set, and must not be used after it is released.
+// Releasing a thread-safe set does nothing.
+func Release(s UintSet) {
+	u, ok := s.(*threadUnsafeUintSet)
+	if !ok || u.Cardinality() > scratchPoolMaxLen {
+		return
+	}
+	u.Clear()
+	scratchPool.Put(u)
+}
+
+// UnionInto replaces the contents of dst with the union of a and b. dst
+// may be a or b, in which case only the missing elements are added.
+//
+// The sets must share one implementation, as for Union. Thread-safe
+// sets are locked in a consistent order, dst for writing and the others
+// for reading.
+func UnionInto(dst, a, b UintSet) {
+	d, x, y, locks := lockUintInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		addAllUint(d, y)
+	case dst == b:
+		addAllUint(d, x)
+	default:
+		clear(d)
+		addAllUint(d, x)
+		addAllUint(d, y)
+	}
+}
+
+// IntersectInto replaces the contents of dst with the intersection of a
+// and b. dst may be a or b, in which case elements are removed in
+// place. Implementations and locking follow UnionInto.
+func IntersectInto(dst, a, b UintSet) {
+	d, x, y, locks := lockUintInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case dst == a:
+		keepOnlyUint(d, y)
+	case dst == b:
+		keepOnlyUint(d, x)
+	default:
+		clear(d)
+		if len(y) < len(x) {
+			x, y = y, x
+		}
+		for elem := range x {
+			if _, ok := y[elem]; ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+// DifferenceInto replaces the contents of dst with the elements of a
+// that are not in b. dst may be a or b. Implementations and locking
+// follow UnionInto.
+func DifferenceInto(dst, a, b UintSet) {
+	d, x, y, locks := lockUintInto(dst, a, b)
+	defer locks.unlock()
+
+	switch {
+	case a == b:
+		clear(d)
+	case dst == a:
+		for elem := range y {
+			delete(d, elem)
+		}
+	case dst == b:
+		// The result depends on the old contents of dst, so build it
+		// aside first.
+		scratch := NewScratchUintSet().(*threadUnsafeUintSet)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				(*scratch)[elem] = struct{}{}
+			}
+		}
+		clear(d)
+		addAllUint(d, *scratch)
+		Release(scratch)
+	default:
+		clear(d)
+		for elem := range x {
+			if _, ok := y[elem]; !ok {
+				d[elem] = struct{}{}
+			}
+		}
+	}
+}
+
+func addAllUint(dst, src threadUnsafeUintSet) {
+	for elem := range src {
+		dst[elem] = struct{}{}
+	}
+}
+
+// keepOnlyUint removes the elements of dst that are not in other.
+func keepOnlyUint(dst, other threadUnsafeUintSet) {
+	for elem := range dst {
+		if _, ok := other[elem]; !ok {
+			delete(dst, elem)
+		}
+	}
+}
+
+// intoUintLocks records the locks taken by lockUintInto, or the guards for
+// thread-unsafe sets.
+type intoUintLocks struct {
+	sets     [3]*threadSafeUintSet
+	distinct int
+	dst      *threadSafeUintSet
+	guards   [3]unsafeUintGuard
+}
+
+// lockUintInto locks dst for writing and a and b for reading, taking each
+// distinct thread-safe set once and in address order, and returns the
+// underlying maps along with the locks to release. Thread-unsafe sets
+// are guarded the same way, for the mapsetdebug build.
+func lockUintInto(dst, a, b UintSet) (d, x, y threadUnsafeUintSet, locks intoUintLocks) {
+	w, ok := dst.(*threadSafeUintSet)
+	if !ok {
+		guarded := false
+		defer func() {
+			// A misuse panic must not leave dst marked as busy.
+			if !guarded {
+				locks.unlock()
+			}
+		}()
+		u := dst.(*threadUnsafeUintSet)
+		locks.guards[0] = u.guardWrite()
+		if a != dst {
+			locks.guards[1] = a.(*threadUnsafeUintSet).guardRead()
+		}
+		if b != dst {
+			locks.guards[2] = b.(*threadUnsafeUintSet).guardRead()
+		}
+		guarded = true
+		return *u, *a.(*threadUnsafeUintSet), *b.(*threadUnsafeUintSet), locks
+	}
+
+	locks.sets = [3]*threadSafeUintSet{w, a.(*threadSafeUintSet), b.(*threadSafeUintSet)}
+	locks.distinct = len(lockUintOrder(locks.sets[:]))
+	locks.dst = w
+	for _, s := range locks.sets[:locks.distinct] {
+		if s == w {
+			s.Lock()
+		} else {
+			s.RLock()
+		}
+	}
+	return w.s, a.(*threadSafeUintSet).s, b.(*threadSafeUintSet).s, locks
+}
+
+func (l intoUintLocks) unlock() {
+	for i := l.distinct - 1; i >= 0; i-- {
+		if l.sets[i] == l.dst {
+			l.sets[i].Unlock()
+		} else {
+			l.sets[i].RUnlock()
+		}
+	}
+	for i := len(l.guards) - 1; i >= 0; i-- {
+		l.guards[i].done()
+	}
+}
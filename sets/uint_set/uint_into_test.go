@@ -0,0 +1,74 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"testing"
+)
+
+func TestIntoOperations(t *testing.T) {
+	// a and b overlap in all but the first and last samples.
+	n := len(sampleUintValues)
+	aValues, bValues := sampleUintValues[:n-1], sampleUintValues[1:]
+
+	var testCases = []struct {
+		name     string
+		into     func(dst, a, b UintSet)
+		expected []uint
+	}{
+		{"UnionInto", UnionInto, sampleUintValues},
+		{"IntersectInto", IntersectInto, sampleUintValues[1 : n-1]},
+		{"DifferenceInto", DifferenceInto, sampleUintValues[:1]},
+	}
+
+	for _, testCase := range testCases {
+		for name, newSet := range uintSetFactories() {
+			expected := fillUintSet(newSet(), testCase.expected...)
+
+			dst := fillUintSet(newSet(), sampleUintValues[n-1])
+			testCase.into(dst, fillUintSet(newSet(), aValues...), fillUintSet(newSet(), bValues...))
+			if !dst.Equal(expected) {
+				t.Errorf("%s %s: expected %v, got %v", name, testCase.name, expected, dst)
+			}
+
+			a := fillUintSet(newSet(), aValues...)
+			testCase.into(a, a, fillUintSet(newSet(), bValues...))
+			if !a.Equal(expected) {
+				t.Errorf("%s %s into a: expected %v, got %v", name, testCase.name, expected, a)
+			}
+
+			b := fillUintSet(newSet(), bValues...)
+			testCase.into(b, fillUintSet(newSet(), aValues...), b)
+			if !b.Equal(expected) {
+				t.Errorf("%s %s into b: expected %v, got %v", name, testCase.name, expected, b)
+			}
+		}
+	}
+
+	for name, newSet := range uintSetFactories() {
+		s := fillUintSet(newSet(), sampleUintValues...)
+		UnionInto(s, s, s)
+		IntersectInto(s, s, s)
+		if s.Cardinality() != n {
+			t.Errorf("%s: expected union and intersection with itself to be unchanged, got %v", name, s)
+		}
+		DifferenceInto(s, s, s)
+		if s.Cardinality() != 0 {
+			t.Errorf("%s: expected the difference of a set with itself to be empty, got %v", name, s)
+		}
+	}
+}
+
+func TestScratchSet(t *testing.T) {
+	s := NewScratchUintSet()
+	if s.Cardinality() != 0 {
+		t.Fatal("expected an empty scratch set")
+	}
+	s.Add(sampleUintValues[0])
+	Release(s)
+	Release(NewUintSet(sampleUintValues[0]))
+
+	if again := NewScratchUintSet(); again.Cardinality() != 0 {
+		t.Errorf("expected a released set to come back empty, got %v", again)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	marshal := func(s UintSet) ([]byte, error) { return json.Marshal(s) }
+	unmarshal := func(b []byte, s UintSet) error { return json.Unmarshal(b, s) }
+	assertUintRoundTrip(t, marshal, unmarshal)
+}
+
+func TestUnmarshalJSONConcurrent(t *testing.T) {
+	b, err := json.Marshal(NewUintSet(sampleUintValues...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewUintSet()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := json.Unmarshal(b, s); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Contains(sampleUintValues[i%len(sampleUintValues)])
+				s.Cardinality()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !s.Equal(NewUintSet(sampleUintValues...)) {
+		t.Errorf("expected %v after concurrent unmarshaling, got %v", sampleUintValues, s)
+	}
+}
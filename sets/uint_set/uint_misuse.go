@@ -0,0 +1,20 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+//go:build !mapsetdebug
+
+package mapsetuint
+
+// unsafeUintGuard marks an operation in progress on a thread-unsafe set.
+// Without the mapsetdebug build tag, guards do nothing and compile away;
+// see the file generated with that tag.
+type unsafeUintGuard struct{}
+
+func trackUintSet(set *threadUnsafeUintSet) {}
+
+func (set *threadUnsafeUintSet) guardRead() unsafeUintGuard { return unsafeUintGuard{} }
+
+func (set *threadUnsafeUintSet) guardWrite() unsafeUintGuard { return unsafeUintGuard{} }
+
+func (set *threadUnsafeUintSet) guardIterate() unsafeUintGuard { return unsafeUintGuard{} }
+
+func (unsafeUintGuard) done() {}
@@ -0,0 +1,110 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+// UnionAll returns a new set with the elements of every given set. It
+// reads each input once and allocates only the result, unlike chained
+// calls to Union.
+//
+// The sets must share one implementation, as for Union, and the result
+// uses it too. Thread-safe sets are all read-locked for the duration,
+// in a consistent order. With no sets, UnionAll returns an empty
+// thread-safe set.
+func UnionAll(sets ...UintSet) UintSet {
+	if len(sets) == 0 {
+		return NewUintSet()
+	}
+	maps, unlock := readLockUintSets(sets)
+	defer unlock()
+
+	largest := 0
+	for _, m := range maps {
+		if len(m) > largest {
+			largest = len(m)
+		}
+	}
+
+	union := make(threadUnsafeUintSet, largest)
+	for _, m := range maps {
+		for elem := range m {
+			union[elem] = struct{}{}
+		}
+	}
+	return wrapUintLike(sets[0], union)
+}
+
+// IntersectAll returns a new set with the elements present in every
+// given set. It walks the smallest set and probes the others, so its
+// cost is bounded by the smallest input.
+//
+// Implementations and locking follow UnionAll. With no sets,
+// IntersectAll returns an empty thread-safe set.
+func IntersectAll(sets ...UintSet) UintSet {
+	if len(sets) == 0 {
+		return NewUintSet()
+	}
+	maps, unlock := readLockUintSets(sets)
+	defer unlock()
+
+	smallest := 0
+	for i, m := range maps {
+		if len(m) < len(maps[smallest]) {
+			smallest = i
+		}
+	}
+
+	intersection := newThreadUnsafeUintSet()
+	for elem := range maps[smallest] {
+		if containedInAllUint(maps, smallest, elem) {
+			intersection[elem] = struct{}{}
+		}
+	}
+	return wrapUintLike(sets[0], intersection)
+}
+
+// DifferenceAll returns a new set with the elements of base that are in
+// none of the other sets.
+//
+// Implementations and locking follow UnionAll.
+func DifferenceAll(base UintSet, sets ...UintSet) UintSet {
+	maps, unlock := readLockUintSets(append([]UintSet{base}, sets...))
+	defer unlock()
+
+	difference := newThreadUnsafeUintSet()
+	for elem := range maps[0] {
+		if !containedInAnyUint(maps[1:], elem) {
+			difference[elem] = struct{}{}
+		}
+	}
+	return wrapUintLike(base, difference)
+}
+
+func containedInAllUint(maps []threadUnsafeUintSet, skip int, elem uint) bool {
+	for i, m := range maps {
+		if i == skip {
+			continue
+		}
+		if _, ok := m[elem]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAnyUint(maps []threadUnsafeUintSet, elem uint) bool {
+	for _, m := range maps {
+		if _, ok := m[elem]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapUintLike returns s as a Set of the same implementation as like.
+func wrapUintLike(like UintSet, s threadUnsafeUintSet) UintSet {
+	if _, ok := like.(*threadSafeUintSet); ok {
+		return &threadSafeUintSet{s: s}
+	}
+	trackUintSet(&s)
+	return &s
+}
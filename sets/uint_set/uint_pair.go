@@ -0,0 +1,224 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// A UintPair represents a 2-tuple of values.
+type UintPair struct {
+	First  uint
+	Second uint
+}
+
+// Equal says whether two 2-tuples contain the same values in the same order.
+func (pair *UintPair) Equal(other UintPair) bool {
+	if pair.First == other.First &&
+		pair.Second == other.Second {
+		return true
+	}
+
+	return false
+}
+
+// String outputs a 2-tuple in the form "(A, B)".
+func (pair UintPair) String() string {
+	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
+}
+
+// UintPairSet is an unordered set of UintPairs, as
+// returned by CartesianProduct.
+type UintPairSet interface {
+	// Adds a pair to the set. Returns whether
+	// the pair was added.
+	Add(p UintPair) bool
+
+	// Returns the number of pairs in the set.
+	Cardinality() int
+
+	// Returns whether the given pairs
+	// are all in the set.
+	Contains(p ...UintPair) bool
+
+	// Iterates over pairs and executes the passed func against each pair.
+	// If passed func returns true, stop iteration at the time.
+	Each(func(UintPair) bool)
+
+	// Determines if two pair sets contain the same pairs.
+	//
+	// Note that the argument to Equal must be
+	// of the same type as the receiver of the
+	// method. Otherwise, Equal will panic.
+	Equal(other UintPairSet) bool
+
+	// Remove a single pair from the set.
+	Remove(p UintPair)
+
+	// Provides a convenient string representation
+	// of the current state of the set.
+	String() string
+
+	// Returns the members of the set as a slice.
+	ToSlice() []UintPair
+}
+
+type threadUnsafeUintPairSet map[UintPair]struct{}
+
+func newThreadUnsafeUintPairSet() threadUnsafeUintPairSet {
+	return make(threadUnsafeUintPairSet)
+}
+
+func (set *threadUnsafeUintPairSet) Add(p UintPair) bool {
+	_, found := (*set)[p]
+	if found {
+		return false //False if it existed already
+	}
+
+	(*set)[p] = struct{}{}
+	return true
+}
+
+func (set *threadUnsafeUintPairSet) Cardinality() int {
+	return len(*set)
+}
+
+func (set *threadUnsafeUintPairSet) Contains(p ...UintPair) bool {
+	for _, val := range p {
+		if _, ok := (*set)[val]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUintPairSet) Each(cb func(UintPair) bool) {
+	for elem := range *set {
+		if cb(elem) {
+			break
+		}
+	}
+}
+
+func (set *threadUnsafeUintPairSet) Equal(other UintPairSet) bool {
+	_ = other.(*threadUnsafeUintPairSet)
+
+	if set.Cardinality() != other.Cardinality() {
+		return false
+	}
+	for elem := range *set {
+		if !other.Contains(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *threadUnsafeUintPairSet) Remove(p UintPair) {
+	delete(*set, p)
+}
+
+func (set *threadUnsafeUintPairSet) String() string {
+	items := make([]string, 0, len(*set))
+
+	for elem := range *set {
+		items = append(items, elem.String())
+	}
+	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
+}
+
+func (set *threadUnsafeUintPairSet) ToSlice() []UintPair {
+	keys := make([]UintPair, 0, set.Cardinality())
+	for elem := range *set {
+		keys = append(keys, elem)
+	}
+
+	return keys
+}
+
+type threadSafeUintPairSet struct {
+	s threadUnsafeUintPairSet
+	sync.RWMutex
+}
+
+func (set *threadSafeUintPairSet) Add(p UintPair) bool {
+	set.Lock()
+	ret := set.s.Add(p)
+	set.Unlock()
+	return ret
+}
+
+func (set *threadSafeUintPairSet) Cardinality() int {
+	set.RLock()
+	defer set.RUnlock()
+	return len(set.s)
+}
+
+func (set *threadSafeUintPairSet) Contains(p ...UintPair) bool {
+	set.RLock()
+	ret := set.s.Contains(p...)
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUintPairSet) Each(cb func(UintPair) bool) {
+	set.RLock()
+	for elem := range set.s {
+		if cb(elem) {
+			break
+		}
+	}
+	set.RUnlock()
+}
+
+// rlockUintPairSets read-locks x and y in address order, locking a
+// set passed twice only once, as rlockUintPair does for sets.
+func rlockUintPairSets(x, y *threadSafeUintPairSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUintPairSets releases the locks taken by rlockUintPairSets.
+func runlockUintPairSets(x, y *threadSafeUintPairSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
+func (set *threadSafeUintPairSet) Equal(other UintPairSet) bool {
+	o := other.(*threadSafeUintPairSet)
+
+	rlockUintPairSets(set, o)
+	ret := set.s.Equal(&o.s)
+	runlockUintPairSets(set, o)
+	return ret
+}
+
+func (set *threadSafeUintPairSet) Remove(p UintPair) {
+	set.Lock()
+	delete(set.s, p)
+	set.Unlock()
+}
+
+func (set *threadSafeUintPairSet) String() string {
+	set.RLock()
+	ret := set.s.String()
+	set.RUnlock()
+	return ret
+}
+
+func (set *threadSafeUintPairSet) ToSlice() []UintPair {
+	set.RLock()
+	keys := set.s.ToSlice()
+	set.RUnlock()
+	return keys
+}
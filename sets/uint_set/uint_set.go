@@ -1,3 +1,5 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
 package mapsetuint
 
 // UintSet is the primary interface provided by the mapset package.  It
@@ -45,6 +47,10 @@ type UintSet interface {
 	// method. Otherwise, Equal will panic.
 	Equal(other UintSet) bool
 
+	// Makes room for n more elements, so that adding
+	// them does not grow the set repeatedly.
+	Grow(n int)
+
 	// Returns a new set containing only the elements
 	// that exist only in both sets.
 	//
@@ -130,13 +136,16 @@ type UintSet interface {
 	// Pop removes and returns an arbitrary item from the set.
 	Pop() uint
 
-	// Returns all subsets of a given set (Power UintSet).
-	// Not yet supported
-	// PowerSet() UintSet
+	// Returns all subsets of a given set (Power Set).
+	PowerSet() []UintSet
 
 	// Returns the Cartesian Product of two sets.
-	// Not yet supported
-	// CartesianProduct(other UintSet) UintSet
+	//
+	// Note that the argument to CartesianProduct
+	// must be of the same type as the receiver
+	// of the method. Otherwise, CartesianProduct
+	// will panic.
+	CartesianProduct(other UintSet) UintPairSet
 
 	// Returns the members of the set as a slice.
 	ToSlice() []uint
@@ -145,7 +154,7 @@ type UintSet interface {
 // NewUintSet creates and returns a reference to an empty set.  Operations
 // on the resulting set are thread-safe.
 func NewUintSet(s ...uint) UintSet {
-	set := newThreadSafeUintSet()
+	set := threadSafeUintSet{s: newThreadUnsafeUintSetWithCapacity(len(s))}
 	for _, item := range s {
 		set.Add(item)
 	}
@@ -169,6 +178,23 @@ func NewUintSetFromSlice(s []uint) UintSet {
 // Operations on the resulting set are not thread-safe.
 func NewThreadUnsafeUintSet() UintSet {
 	set := newThreadUnsafeUintSet()
+	trackUintSet(&set)
+	return &set
+}
+
+// NewUintSetWithCapacity creates and returns a reference to an empty
+// set with room for n elements.  Operations on the resulting set are
+// thread-safe.
+func NewUintSetWithCapacity(n int) UintSet {
+	return &threadSafeUintSet{s: newThreadUnsafeUintSetWithCapacity(n)}
+}
+
+// NewThreadUnsafeUintSetWithCapacity creates and returns a reference to
+// an empty set with room for n elements.  Operations on the resulting
+// set are not thread-safe.
+func NewThreadUnsafeUintSetWithCapacity(n int) UintSet {
+	set := newThreadUnsafeUintSetWithCapacity(n)
+	trackUintSet(&set)
 	return &set
 }
 
@@ -176,7 +202,7 @@ func NewThreadUnsafeUintSet() UintSet {
 // set from an existing slice.  Operations on the resulting set are
 // not thread-safe.
 func NewThreadUnsafeUintSetFromSlice(s []uint) UintSet {
-	a := NewThreadUnsafeUintSet()
+	a := NewThreadUnsafeUintSetWithCapacity(len(s))
 	for _, item := range s {
 		a.Add(item)
 	}
@@ -0,0 +1,105 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+// sampleUintValues are distinct values of the element type that the
+// tests, fuzz targets and benchmarks fill sets with.
+var sampleUintValues = []uint{
+	0,
+	1,
+	2,
+	3,
+	42,
+	127,
+	128,
+	255,
+}
+
+// uintSetFactories returns constructors for both implementations.
+func uintSetFactories() map[string]func() UintSet {
+	return map[string]func() UintSet{
+		"safe":   func() UintSet { return NewUintSet() },
+		"unsafe": NewThreadUnsafeUintSet,
+	}
+}
+
+// fillUintSet adds values to s and returns it.
+func fillUintSet(s UintSet, values ...uint) UintSet {
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+// assertUintRoundTrip checks that marshal and unmarshal carry sets of
+// both implementations through unchanged. A set of just the first
+// sample, the zero value for some kinds, must not be mistaken for an
+// empty set.
+func assertUintRoundTrip(t *testing.T, marshal func(UintSet) ([]byte, error), unmarshal func([]byte, UintSet) error) {
+	t.Helper()
+	contents := [][]uint{sampleUintValues, sampleUintValues[:1]}
+	for name, newSet := range uintSetFactories() {
+		for _, values := range contents {
+			s := fillUintSet(newSet(), values...)
+			b, err := marshal(s)
+			if err != nil {
+				t.Errorf("%s: marshal %v: %v", name, s, err)
+				continue
+			}
+			decoded := newSet()
+			if err := unmarshal(b, decoded); err != nil {
+				t.Errorf("%s: unmarshal %q: %v", name, b, err)
+				continue
+			}
+			if !decoded.Equal(s) {
+				t.Errorf("%s: expected %v after a round trip, got %v", name, s, decoded)
+			}
+		}
+	}
+}
+
+func TestSuite(t *testing.T) {
+	for name, newSet := range uintSetFactories() {
+		t.Run(name, func(t *testing.T) {
+			settest.RunSuite(t, settest.Factory[uint, UintSet]{
+				New:   newSet,
+				Elems: sampleUintValues,
+			})
+		})
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	for name, newSet := range uintSetFactories() {
+		a, b := newSet(), newSet()
+		a.Add(sampleUintValues[0])
+		a.Add(sampleUintValues[1])
+		for _, v := range sampleUintValues {
+			b.Add(v)
+		}
+
+		product := a.CartesianProduct(b)
+		if got, want := product.Cardinality(), 2*len(sampleUintValues); got != want {
+			t.Errorf("%s: expected %d pairs, got %d", name, want, got)
+		}
+		for _, first := range a.ToSlice() {
+			for _, second := range b.ToSlice() {
+				if !product.Contains(UintPair{First: first, Second: second}) {
+					t.Errorf("%s: expected (%v, %v) in %v", name, first, second, product)
+				}
+			}
+		}
+		if !product.Equal(product) || !product.Equal(a.CartesianProduct(b)) {
+			t.Errorf("%s: expected %v to equal itself and a recomputed product", name, product)
+		}
+		if product := a.CartesianProduct(newSet()); product.Cardinality() != 0 {
+			t.Errorf("%s: expected no pairs with an empty set, got %v", name, product)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"sort"
+)
+
+// lessUint orders elements for output that must be stable,
+// such as sorted encodings.
+func lessUint(a, b uint) bool {
+	return a < b
+}
+
+// sortUintElements sorts elems in place using lessUint.
+func sortUintElements(elems []uint) {
+	sort.Slice(elems, func(i, j int) bool { return lessUint(elems[i], elems[j]) })
+}
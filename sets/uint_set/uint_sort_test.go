@@ -0,0 +1,26 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	elems := make([]uint, 0, len(sampleUintValues))
+	for i := len(sampleUintValues) - 1; i >= 0; i-- {
+		elems = append(elems, sampleUintValues[i])
+	}
+	sortUintElements(elems)
+	for i, elem := range elems {
+		if lessUint(elem, elem) {
+			t.Errorf("expected %v not to be less than itself", elem)
+		}
+		if i > 0 && !lessUint(elems[i-1], elem) {
+			t.Errorf("expected %v sorted, got %v before %v", elems, elems[i-1], elem)
+		}
+	}
+	if !NewUintSet(elems...).Equal(NewUintSet(sampleUintValues...)) {
+		t.Errorf("expected a permutation of %v, got %v", sampleUintValues, elems)
+	}
+}
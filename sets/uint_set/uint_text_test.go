@@ -0,0 +1,14 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"encoding"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	marshal := func(s UintSet) ([]byte, error) { return s.(encoding.TextMarshaler).MarshalText() }
+	unmarshal := func(b []byte, s UintSet) error { return s.(encoding.TextUnmarshaler).UnmarshalText(b) }
+	assertUintRoundTrip(t, marshal, unmarshal)
+}
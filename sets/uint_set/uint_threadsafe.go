@@ -1,11 +1,16 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
 package mapsetuint
 
 import (
 	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 type threadSafeUintSet struct {
-	s threadUnsafeUintSet
+	s     threadUnsafeUintSet
+	stats atomic.Pointer[UintSetStats]
 	sync.RWMutex
 }
 
@@ -13,39 +18,129 @@ func newThreadSafeUintSet() threadSafeUintSet {
 	return threadSafeUintSet{s: newThreadUnsafeUintSet()}
 }
 
+// readLockUintSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockUintSets(sets []UintSet) ([]threadUnsafeUintSet, func()) {
+	maps := make([]threadUnsafeUintSet, len(sets))
+	if _, ok := sets[0].(*threadSafeUintSet); !ok {
+		guards := make([]unsafeUintGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeUintSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeUintSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeUintSet))
+	}
+	distinct := lockUintOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeUintSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockUintOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockUintOrder(sets []*threadSafeUintSet) []*threadSafeUintSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockUintPair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockUintPair(x, y *threadSafeUintSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockUintPair releases the locks taken by rlockUintPair.
+func runlockUintPair(x, y *threadSafeUintSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeUintSet) Add(i uint) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeUintSet) Contains(i ...uint) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeUintSet) IsSubset(other UintSet) bool {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
 func (set *threadSafeUintSet) IsProperSubset(other UintSet) bool {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockUintPair(set, o)
+	defer runlockUintPair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -61,64 +156,69 @@ func (set *threadSafeUintSet) IsProperSuperset(other UintSet) bool {
 func (set *threadSafeUintSet) Union(other UintSet) UintSet {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
+	readUintPair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeUintSet)
 	ret := &threadSafeUintSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
 func (set *threadSafeUintSet) Intersect(other UintSet) UintSet {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
+	readUintPair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeUintSet)
 	ret := &threadSafeUintSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
 func (set *threadSafeUintSet) Difference(other UintSet) UintSet {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
+	readUintPair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeUintSet)
 	ret := &threadSafeUintSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
 func (set *threadSafeUintSet) SymmetricDifference(other UintSet) UintSet {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
+	readUintPair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeUintSet)
 	ret := &threadSafeUintSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
 func (set *threadSafeUintSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeUintSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeUintSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeUintSet) Remove(i uint) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, keyUint(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -129,7 +229,7 @@ func (set *threadSafeUintSet) Cardinality() int {
 }
 
 func (set *threadSafeUintSet) Each(cb func(uint) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -176,12 +276,10 @@ func (set *threadSafeUintSet) Iterator() *UintIterator {
 func (set *threadSafeUintSet) Equal(other UintSet) bool {
 	o := other.(*threadSafeUintSet)
 
-	set.RLock()
-	o.RLock()
+	rlockUintPair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockUintPair(set, o)
 	return ret
 }
 
@@ -201,47 +299,42 @@ func (set *threadSafeUintSet) String() string {
 	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUintSet) PowerSet() UintSet {
-    set.RLock()
-    unsafePowerSet := set.s.PowerSet().(*threadUnsafeUintSet)
-    set.RUnlock()
+func (set *threadSafeUintSet) PowerSet() []UintSet {
+	set.RLock()
+	unsafePowerSet := set.s.PowerSet()
+	set.RUnlock()
 
-    ret := &threadSafeUintSet{s: newThreadUnsafeUintSet()}
-    for subset := range unsafePowerSet.Iter() {
-        unsafeSubset := subset.(*threadUnsafeUintSet)
-        ret.Add(&threadSafeUintSet{s: *unsafeSubset})
-    }
-    return ret
+	ret := make([]UintSet, 0, len(unsafePowerSet))
+	for _, subset := range unsafePowerSet {
+		unsafeSubset := subset.(*threadUnsafeUintSet)
+		ret = append(ret, &threadSafeUintSet{s: *unsafeSubset})
+	}
+	return ret
 }
-*/
 
 func (set *threadSafeUintSet) Pop() uint {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
-/*
-// Not yet supported
-func (set *threadSafeUintSet) CartesianProduct(other UintSet) UintSet {
-    o := other.(*threadSafeUintSet)
+func (set *threadSafeUintSet) CartesianProduct(other UintSet) UintPairSet {
+	o := other.(*threadSafeUintSet)
 
-    set.RLock()
-    o.RLock()
+	rlockUintPair(set, o)
 
-    unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUintSet)
-    ret := &threadSafeUintSet{s: *unsafeCartProduct}
-    set.RUnlock()
-    o.RUnlock()
-    return ret
+	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeUintPairSet)
+	ret := &threadSafeUintPairSet{s: *unsafeCartProduct}
+	runlockUintPair(set, o)
+	return ret
 }
-*/
 
 func (set *threadSafeUintSet) ToSlice() []uint {
-	keys := make([]uint, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]uint, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -258,9 +351,9 @@ func (set *threadSafeUintSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeUintSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -1,7 +1,10 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
 package mapsetuint
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,27 +12,24 @@ import (
 
 type threadUnsafeUintSet map[uint]struct{}
 
-// An OrderedPair represents a 2-tuple of values.
-type OrderedPair struct {
-	First  uint
-	Second uint
-}
-
 func newThreadUnsafeUintSet() threadUnsafeUintSet {
 	return make(threadUnsafeUintSet)
 }
 
-// Equal says whether two 2-tuples contain the same values in the same order.
-func (pair *OrderedPair) Equal(other OrderedPair) bool {
-	if pair.First == other.First &&
-		pair.Second == other.Second {
-		return true
-	}
+// newThreadUnsafeUintSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeUintSetWithCapacity(n int) threadUnsafeUintSet {
+	return make(threadUnsafeUintSet, n)
+}
 
-	return false
+// keyUint returns the map key of i.
+func keyUint(i uint) uint {
+	return i
 }
 
 func (set *threadUnsafeUintSet) Add(i uint) bool {
+	defer set.guardWrite().done()
+	i = keyUint(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -40,8 +40,9 @@ func (set *threadUnsafeUintSet) Add(i uint) bool {
 }
 
 func (set *threadUnsafeUintSet) Contains(i ...uint) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[keyUint(val)]; !ok {
 			return false
 		}
 	}
@@ -50,6 +51,7 @@ func (set *threadUnsafeUintSet) Contains(i ...uint) bool {
 
 func (set *threadUnsafeUintSet) IsSubset(other UintSet) bool {
 	_ = other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -61,8 +63,10 @@ func (set *threadUnsafeUintSet) IsSubset(other UintSet) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeUintSet) IsProperSubset(other UintSet) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeUintSet) IsSuperset(other UintSet) bool {
@@ -70,13 +74,15 @@ func (set *threadUnsafeUintSet) IsSuperset(other UintSet) bool {
 }
 
 func (set *threadUnsafeUintSet) IsProperSuperset(other UintSet) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeUintSet) Union(other UintSet) UintSet {
 	o := other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeUintSet()
+	unionedSet := newThreadUnsafeUintSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -84,11 +90,14 @@ func (set *threadUnsafeUintSet) Union(other UintSet) UintSet {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUintSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeUintSet) Intersect(other UintSet) UintSet {
 	o := other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeUintSet()
 	// loop over smaller set
@@ -105,11 +114,13 @@ func (set *threadUnsafeUintSet) Intersect(other UintSet) UintSet {
 			}
 		}
 	}
+	trackUintSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeUintSet) Difference(other UintSet) UintSet {
 	_ = other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeUintSet()
 	for elem := range *set {
@@ -117,30 +128,63 @@ func (set *threadUnsafeUintSet) Difference(other UintSet) UintSet {
 			difference.Add(elem)
 		}
 	}
+	trackUintSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeUintSet) SymmetricDifference(other UintSet) UintSet {
-	_ = other.(*threadUnsafeUintSet)
+	o := other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeUintSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUintSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeUintSet) Clear() {
-	*set = newThreadUnsafeUintSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeUintSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeUintSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeUintSet) Remove(i uint) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, keyUint(i))
 }
 
 func (set *threadUnsafeUintSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeUintSet) Each(cb func(uint) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -151,9 +195,11 @@ func (set *threadUnsafeUintSet) Each(cb func(uint) bool) {
 func (set *threadUnsafeUintSet) Iter() <-chan uint {
 	ch := make(chan uint)
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -164,6 +210,7 @@ func (set *threadUnsafeUintSet) Iterator() *UintIterator {
 	iterator, ch, stopCh := newUintIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -172,6 +219,7 @@ func (set *threadUnsafeUintSet) Iterator() *UintIterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -180,6 +228,7 @@ func (set *threadUnsafeUintSet) Iterator() *UintIterator {
 
 func (set *threadUnsafeUintSet) Equal(other UintSet) bool {
 	_ = other.(*threadUnsafeUintSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -193,14 +242,17 @@ func (set *threadUnsafeUintSet) Equal(other UintSet) bool {
 }
 
 func (set *threadUnsafeUintSet) Clone() UintSet {
-	clonedSet := newThreadUnsafeUintSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeUintSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUintSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeUintSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -209,12 +261,8 @@ func (set *threadUnsafeUintSet) String() string {
 	return fmt.Sprintf("Set{%s}", strings.Join(items, ", "))
 }
 
-// String outputs a 2-tuple in the form "(A, B)".
-func (pair OrderedPair) String() string {
-	return fmt.Sprintf("(%v, %v)", pair.First, pair.Second)
-}
-
 func (set *threadUnsafeUintSet) Pop() uint {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -222,55 +270,40 @@ func (set *threadUnsafeUintSet) Pop() uint {
 	return 0
 }
 
-/*
-// Not yet supported
-func (set *threadUnsafeUintSet) PowerSet() UintSet {
-	powSet := NewThreadUnsafeUintSet()
+func (set *threadUnsafeUintSet) PowerSet() []UintSet {
+	defer set.guardRead().done()
 	nullset := newThreadUnsafeUintSet()
-	powSet.Add(&nullset)
+	powSet := []UintSet{&nullset}
 
 	for es := range *set {
-		u := newThreadUnsafeUintSet()
-		j := powSet.Iter()
-		for er := range j {
-			p := newThreadUnsafeUintSet()
-			if reflect.TypeOf(er).Name() == "" {
-				k := er.(*threadUnsafeUintSet)
-				for ek := range *(k) {
-					p.Add(ek)
-				}
-			} else {
-				p.Add(er)
-			}
+		for _, er := range powSet {
+			p := er.Clone()
 			p.Add(es)
-			u.Add(&p)
+			powSet = append(powSet, p)
 		}
-
-		powSet = powSet.Union(&u)
 	}
 
 	return powSet
 }
-*/
 
-/*
-// Not yet supported
-func (set *threadUnsafeUintSet) CartesianProduct(other UintSet) UintSet {
+func (set *threadUnsafeUintSet) CartesianProduct(other UintSet) UintPairSet {
 	o := other.(*threadUnsafeUintSet)
-	cartProduct := NewThreadUnsafeUintSet()
+	defer set.guardRead().done()
+	defer o.guardRead().done()
+	cartProduct := newThreadUnsafeUintPairSet()
 
 	for i := range *set {
 		for j := range *o {
-			elem := OrderedPair{First: i, Second: j}
+			elem := UintPair{First: i, Second: j}
 			cartProduct.Add(elem)
 		}
 	}
 
-	return cartProduct
+	return &cartProduct
 }
-*/
 
 func (set *threadUnsafeUintSet) ToSlice() []uint {
+	defer set.guardRead().done()
 	keys := make([]uint, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -281,26 +314,19 @@ func (set *threadUnsafeUintSet) ToSlice() []uint {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeUintSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, UintJSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
 // primitive types. Numbers are decoded as json.Number.
-func (set *threadUnsafeUintSet) UnmarshalJSON(b []byte) error {
+func (set *threadUnsafeUintSet) UnmarshalJSON(data []byte) error {
 	var i []uint
 
-	d := json.NewDecoder(bytes.NewReader(b))
+	d := json.NewDecoder(bytes.NewReader(data))
 	d.UseNumber()
 	err := d.Decode(&i)
 	if err != nil {
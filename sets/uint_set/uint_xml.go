@@ -0,0 +1,154 @@
+// Code generated by generate_set for uint. DO NOT EDIT.
+
+package mapsetuint
+
+import (
+	"encoding/xml"
+)
+
+// DefaultUintXMLItemName is the name of the element wrapping
+// each set element when none is configured.
+const DefaultUintXMLItemName = "item"
+
+// UintXML adapts a UintSet to xml.Marshaler and
+// xml.Unmarshaler with a configurable element name, for documents such
+// as <allow><host>a</host><host>b</host></allow>.
+//
+// Unmarshalling adds elements to the set. Child elements with other
+// names are skipped.
+type UintXML struct {
+	Set UintSet
+
+	// ItemName names the element wrapping each set element. Defaults to
+	// DefaultUintXMLItemName.
+	ItemName string
+}
+
+// MarshalXML implements xml.Marshaler, writing elements in sorted order.
+func (x UintXML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUintXML(e, start, x.Set, x.itemName())
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Nothing is added if any
+// element fails to parse.
+func (x *UintXML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUintXML(d, x.itemName())
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		x.Set.Add(elem)
+	}
+	return nil
+}
+
+func (x UintXML) itemName() string {
+	if x.ItemName == "" {
+		return DefaultUintXMLItemName
+	}
+	return x.ItemName
+}
+
+func encodeUintXML(e *xml.Encoder, start xml.StartElement, s UintSet, itemName string) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	elems := s.ToSlice()
+	sortUintElements(elems)
+
+	item := xml.StartElement{Name: xml.Name{Local: itemName}}
+	for _, elem := range elems {
+		text, err := formatUintText(elem)
+		if err != nil {
+			return err
+		}
+		if err := e.EncodeElement(text, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// decodeUintXML reads the children of the element whose start
+// tag was just consumed, up to and including its end tag.
+func decodeUintXML(dec *xml.Decoder, itemName string) ([]uint, error) {
+	var elems []uint
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != itemName {
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var text string
+			if err := dec.DecodeElement(&text, &t); err != nil {
+				return nil, err
+			}
+			elem, err := parseUintText(text)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, elem)
+		case xml.EndElement:
+			return elems, nil
+		}
+	}
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUintXMLItemName. Use UintXML for other
+// names.
+func (set *threadUnsafeUintSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUintXML(e, start, set, DefaultUintXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadUnsafeUintSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUintXML(d, DefaultUintXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeUintSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler, wrapping each element in an
+// element named DefaultUintXMLItemName. Use UintXML for other
+// names.
+func (set *threadSafeUintSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return encodeUintXML(e, start, set, DefaultUintXMLItemName)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, replacing the contents of the
+// set. The set is left untouched on error.
+func (set *threadSafeUintSet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	elems, err := decodeUintXML(d, DefaultUintXMLItemName)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeUintSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,451 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package settest checks implementations of the mapset set interfaces.
+//
+// RunSuite runs one suite against any set type whose methods follow the
+// shape of mapset.Set: the thread-safe and thread-unsafe sets of the root
+// package, the typed sets generated under sets/, and third-party
+// implementations alike. It checks the methods of Set against a
+// map-based model and the algebraic laws that relate the operations:
+//
+//	func TestIntSet(t *testing.T) {
+//		settest.RunSuite(t, settest.Factory[int, mapsetint.IntSet]{
+//			New:   func() mapsetint.IntSet { return mapsetint.NewIntSet() },
+//			Elems: []int{1, 2, 3, 4, 5, 6, 7, 8},
+//		})
+//	}
+//
+// Iterator, PowerSet, CartesianProduct and the encodings are not
+// checked: their types and element decoding differ between packages,
+// so each implementation tests them itself.
+package settest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Set is the part of the mapset set interfaces that the suite checks. E
+// is the element type and S the set interface itself, such as mapset.Set
+// or a generated IntSet. Methods whose types differ between the packages,
+// like Iterator and PowerSet, are left out.
+type Set[E comparable, S any] interface {
+	Add(i E) bool
+	Cardinality() int
+	Clear()
+	Clone() S
+	Contains(i ...E) bool
+	Difference(other S) S
+	Each(func(E) bool)
+	Equal(other S) bool
+	Grow(n int)
+	Intersect(other S) S
+	IsProperSubset(other S) bool
+	IsProperSuperset(other S) bool
+	IsSubset(other S) bool
+	IsSuperset(other S) bool
+	Iter() <-chan E
+	Pop() E
+	Remove(i E)
+	String() string
+	SymmetricDifference(other S) S
+	ToSlice() []E
+	Union(other S) S
+}
+
+// Factory describes the implementation under test.
+type Factory[E comparable, S Set[E, S]] struct {
+	// New returns an empty set. Every set it returns must be usable as
+	// the argument of another's binary operations.
+	New func() S
+
+	// Elems holds distinct elements to fill the sets with. At least two
+	// are required; the laws are checked on sets drawn from the first
+	// maxLawElems of them.
+	Elems []E
+}
+
+// maxLawElems bounds the universe the laws are checked in, which keeps
+// the number of sets drawn from it, and of triples of them, small.
+const maxLawElems = 8
+
+// lawSets is the number of sets drawn from the universe for the laws.
+const lawSets = 10
+
+// model is the expected contents of a set.
+type model[E comparable] map[E]struct{}
+
+// RunSuite runs the conformance suite as subtests of t.
+func RunSuite[E comparable, S Set[E, S]](t *testing.T, f Factory[E, S]) {
+	t.Helper()
+	if f.New == nil {
+		t.Fatal("settest: Factory.New is nil")
+	}
+	if len(f.Elems) < 2 {
+		t.Fatalf("settest: Factory.Elems needs at least 2 elements, got %d", len(f.Elems))
+	}
+	seen := make(model[E], len(f.Elems))
+	for _, e := range f.Elems {
+		if _, ok := seen[e]; ok {
+			t.Fatalf("settest: Factory.Elems repeats %v", e)
+		}
+		seen[e] = struct{}{}
+	}
+
+	t.Run("Add", f.testAdd)
+	t.Run("Remove", f.testRemove)
+	t.Run("Clear", f.testClear)
+	t.Run("Grow", f.testGrow)
+	t.Run("Clone", f.testClone)
+	t.Run("Pop", f.testPop)
+	t.Run("Each", f.testEach)
+	t.Run("Iter", f.testIter)
+	t.Run("ToSlice", f.testToSlice)
+	t.Run("String", f.testString)
+	t.Run("Operations", f.testOperations)
+	t.Run("Laws", f.testLaws)
+}
+
+// build returns a set holding the elements of f.Elems whose bit is set in
+// mask. Masks only reach the first 64 elements; see full and modelAll for
+// sets of all of them.
+func (f Factory[E, S]) build(mask uint) S {
+	s := f.New()
+	for i, e := range f.Elems {
+		if mask&(1<<uint(i)) != 0 {
+			s.Add(e)
+		}
+	}
+	return s
+}
+
+// full returns a set holding every element of f.Elems.
+func (f Factory[E, S]) full() S {
+	s := f.New()
+	for _, e := range f.Elems {
+		s.Add(e)
+	}
+	return s
+}
+
+// modelAll returns the model of full.
+func (f Factory[E, S]) modelAll() model[E] {
+	m := make(model[E], len(f.Elems))
+	for _, e := range f.Elems {
+		m[e] = struct{}{}
+	}
+	return m
+}
+
+func (f Factory[E, S]) model(mask uint) model[E] {
+	m := make(model[E])
+	for i, e := range f.Elems {
+		if mask&(1<<uint(i)) != 0 {
+			m[e] = struct{}{}
+		}
+	}
+	return m
+}
+
+// check reports an error if s does not hold exactly the elements of want.
+func check[E comparable, S Set[E, S]](t *testing.T, what string, s S, want model[E]) {
+	t.Helper()
+	if got := s.Cardinality(); got != len(want) {
+		t.Errorf("%s: expected %d elements, got %d: %v", what, len(want), got, s)
+		return
+	}
+	for e := range want {
+		if !s.Contains(e) {
+			t.Errorf("%s: expected %v to be in %v", what, e, s)
+		}
+	}
+}
+
+func (f Factory[E, S]) testAdd(t *testing.T) {
+	s := f.New()
+	a, b := f.Elems[0], f.Elems[1]
+	if !s.Contains() {
+		t.Error("expected an empty set to contain no items vacuously")
+	}
+	if s.Contains(a) {
+		t.Errorf("expected a new set not to contain %v", a)
+	}
+	if !s.Add(a) {
+		t.Errorf("expected Add(%v) to report a new element", a)
+	}
+	if s.Add(a) {
+		t.Errorf("expected a second Add(%v) to report an existing element", a)
+	}
+	if !s.Contains(a) || s.Contains(a, b) {
+		t.Errorf("expected %v to hold %v only", s, a)
+	}
+	s.Add(b)
+	if !s.Contains(a, b) || !s.Contains(b, a) {
+		t.Errorf("expected %v to hold %v and %v", s, a, b)
+	}
+	check(t, "after adding all elements", f.full(), f.modelAll())
+}
+
+func (f Factory[E, S]) testRemove(t *testing.T) {
+	s := f.full()
+	a := f.Elems[0]
+	s.Remove(a)
+	if s.Contains(a) {
+		t.Errorf("expected %v to be removed from %v", a, s)
+	}
+	s.Remove(a)
+	if got, want := s.Cardinality(), len(f.Elems)-1; got != want {
+		t.Errorf("expected removing a missing element to change nothing, got %d elements, want %d", got, want)
+	}
+}
+
+func (f Factory[E, S]) testClear(t *testing.T) {
+	s := f.full()
+	s.Clear()
+	check(t, "after Clear", s, nil)
+	s.Add(f.Elems[0])
+	check(t, "after refilling a cleared set", s, f.model(1))
+}
+
+func (f Factory[E, S]) testGrow(t *testing.T) {
+	s := f.build(1)
+	s.Grow(100)
+	s.Grow(0)
+	s.Grow(-1)
+	check(t, "after Grow", s, f.model(1))
+	s.Add(f.Elems[1])
+	check(t, "after adding to a grown set", s, f.model(3))
+}
+
+func (f Factory[E, S]) testClone(t *testing.T) {
+	s := f.build(3)
+	c := s.Clone()
+	if !c.Equal(s) || !s.Equal(c) {
+		t.Errorf("expected the clone %v to equal %v", c, s)
+	}
+	c.Remove(f.Elems[0])
+	check(t, "the original after changing its clone", s, f.model(3))
+	check(t, "an empty clone", f.New().Clone(), nil)
+}
+
+func (f Factory[E, S]) testPop(t *testing.T) {
+	s := f.full()
+	popped := make(model[E])
+	for i := 0; i < len(f.Elems); i++ {
+		e := s.Pop()
+		if _, ok := popped[e]; ok {
+			t.Fatalf("expected Pop to return each element once, got %v twice", e)
+		}
+		popped[e] = struct{}{}
+	}
+	if s.Cardinality() != 0 {
+		t.Errorf("expected Pop to empty the set, got %v", s)
+	}
+	check(t, "the popped elements", f.full(), popped)
+	s.Pop()
+	check(t, "after popping an empty set", s, nil)
+}
+
+func (f Factory[E, S]) testEach(t *testing.T) {
+	s := f.full()
+	visited := make(model[E])
+	s.Each(func(e E) bool {
+		visited[e] = struct{}{}
+		return false
+	})
+	check(t, "the elements visited by Each", s, visited)
+	if len(visited) != len(f.Elems) {
+		t.Errorf("expected Each to visit %d elements, got %d", len(f.Elems), len(visited))
+	}
+
+	calls := 0
+	s.Each(func(E) bool {
+		calls++
+		return true
+	})
+	if calls != 1 {
+		t.Errorf("expected Each to stop when the callback returns true, got %d calls", calls)
+	}
+}
+
+func (f Factory[E, S]) testIter(t *testing.T) {
+	s := f.full()
+	got := make(model[E])
+	for e := range s.Iter() {
+		got[e] = struct{}{}
+	}
+	check(t, "the elements sent by Iter", f.full(), got)
+	for range f.New().Iter() {
+		t.Error("expected Iter on an empty set to send nothing")
+	}
+}
+
+func (f Factory[E, S]) testToSlice(t *testing.T) {
+	s := f.full()
+	got := make(model[E])
+	for _, e := range s.ToSlice() {
+		got[e] = struct{}{}
+	}
+	if n := len(s.ToSlice()); n != len(f.Elems) {
+		t.Errorf("expected ToSlice to return %d elements, got %d", len(f.Elems), n)
+	}
+	check(t, "the elements returned by ToSlice", f.full(), got)
+	if n := len(f.New().ToSlice()); n != 0 {
+		t.Errorf("expected ToSlice on an empty set to be empty, got %d elements", n)
+	}
+}
+
+func (f Factory[E, S]) testString(t *testing.T) {
+	if f.New().String() == f.full().String() {
+		t.Error("expected an empty and a full set to print differently")
+	}
+}
+
+// lawMasks returns the masks of the sets the laws and operations are
+// checked on: the empty set, the universe, and random sets between.
+func (f Factory[E, S]) lawMasks() []uint {
+	n := len(f.Elems)
+	if n > maxLawElems {
+		n = maxLawElems
+	}
+	universe := uint(1)<<uint(n) - 1
+	masks := []uint{0, universe}
+	r := rand.New(rand.NewSource(1))
+	for len(masks) < lawSets {
+		masks = append(masks, uint(r.Intn(int(universe)+1)))
+	}
+	return masks
+}
+
+func (f Factory[E, S]) testOperations(t *testing.T) {
+	masks := f.lawMasks()
+	for _, x := range masks {
+		for _, y := range masks {
+			a, b := f.build(x), f.build(y)
+			name := fmt.Sprintf("%v and %v", a, b)
+
+			check(t, "Union of "+name, a.Union(b), f.model(x|y))
+			check(t, "Intersect of "+name, a.Intersect(b), f.model(x&y))
+			check(t, "Difference of "+name, a.Difference(b), f.model(x&^y))
+			check(t, "SymmetricDifference of "+name, a.SymmetricDifference(b), f.model(x^y))
+
+			subset := x&^y == 0
+			if got := a.IsSubset(b); got != subset {
+				t.Errorf("IsSubset of %s: expected %v, got %v", name, subset, got)
+			}
+			if got := a.IsProperSubset(b); got != (subset && x != y) {
+				t.Errorf("IsProperSubset of %s: expected %v, got %v", name, subset && x != y, got)
+			}
+			superset := y&^x == 0
+			if got := a.IsSuperset(b); got != superset {
+				t.Errorf("IsSuperset of %s: expected %v, got %v", name, superset, got)
+			}
+			if got := a.IsProperSuperset(b); got != (superset && x != y) {
+				t.Errorf("IsProperSuperset of %s: expected %v, got %v", name, superset && x != y, got)
+			}
+			if got := a.Equal(b); got != (x == y) {
+				t.Errorf("Equal of %s: expected %v, got %v", name, x == y, got)
+			}
+
+			check(t, "the receiver after operations on "+name, a, f.model(x))
+			check(t, "the argument after operations on "+name, b, f.model(y))
+		}
+	}
+}
+
+func (f Factory[E, S]) testLaws(t *testing.T) {
+	masks := f.lawMasks()
+	universe := f.build(masks[1])
+	equal := func(law string, x, y S) {
+		t.Helper()
+		if !x.Equal(y) {
+			t.Errorf("%s: expected %v to equal %v", law, x, y)
+		}
+	}
+
+	for _, x := range masks {
+		a := f.build(x)
+		equal("idempotent union", a.Union(a), a)
+		equal("idempotent intersection", a.Intersect(a), a)
+		equal("self difference", a.Difference(a), f.New())
+		equal("identity union", a.Union(f.New()), a)
+		equal("identity intersection", a.Intersect(universe), a)
+		equal("double complement", universe.Difference(universe.Difference(a)), a)
+
+		for _, y := range masks {
+			b := f.build(y)
+			equal("commutative union", a.Union(b), b.Union(a))
+			equal("commutative intersection", a.Intersect(b), b.Intersect(a))
+			equal("commutative symmetric difference", a.SymmetricDifference(b), b.SymmetricDifference(a))
+			if a.Equal(b) != b.Equal(a) {
+				t.Errorf("symmetric equality: %v and %v", a, b)
+			}
+
+			equal("De Morgan for union",
+				universe.Difference(a.Union(b)),
+				universe.Difference(a).Intersect(universe.Difference(b)))
+			equal("De Morgan for intersection",
+				universe.Difference(a.Intersect(b)),
+				universe.Difference(a).Union(universe.Difference(b)))
+
+			equal("absorption of union", a.Union(a.Intersect(b)), a)
+			equal("absorption of intersection", a.Intersect(a.Union(b)), a)
+			equal("symmetric difference as differences", a.SymmetricDifference(b), a.Difference(b).Union(b.Difference(a)))
+
+			if a.IsSubset(b) != b.IsSuperset(a) {
+				t.Errorf("subset/superset duality: %v and %v", a, b)
+			}
+			if a.IsProperSubset(b) != b.IsProperSuperset(a) {
+				t.Errorf("proper subset/superset duality: %v and %v", a, b)
+			}
+			if a.IsProperSubset(b) != (a.IsSubset(b) && !a.Equal(b)) {
+				t.Errorf("proper subset as subset and not equal: %v and %v", a, b)
+			}
+			if (a.IsSubset(b) && b.IsSubset(a)) != a.Equal(b) {
+				t.Errorf("antisymmetric subset: %v and %v", a, b)
+			}
+			if !a.IsSubset(a.Union(b)) || !a.Intersect(b).IsSubset(a) {
+				t.Errorf("union and intersection bounds: %v and %v", a, b)
+			}
+
+			for _, z := range masks {
+				c := f.build(z)
+				equal("associative union", a.Union(b).Union(c), a.Union(b.Union(c)))
+				equal("associative intersection", a.Intersect(b).Intersect(c), a.Intersect(b.Intersect(c)))
+				equal("associative symmetric difference",
+					a.SymmetricDifference(b).SymmetricDifference(c),
+					a.SymmetricDifference(b.SymmetricDifference(c)))
+				equal("distributive intersection over union",
+					a.Intersect(b.Union(c)),
+					a.Intersect(b).Union(a.Intersect(c)))
+				equal("distributive union over intersection",
+					a.Union(b.Intersect(c)),
+					a.Union(b).Intersect(a.Union(c)))
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package mapset
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_Similarity(t *testing.T) {
+	var testCases = []struct {
+		a, b                           Set
+		jaccard, dice, overlap, cosine float64
+		symmetricDifference            int
+	}{
+		{NewSet(1, 2, 3, 4), NewSet(3, 4, 5), 2.0 / 5, 4.0 / 7, 2.0 / 3, 2 / math.Sqrt(12), 3},
+		{NewSet(), NewSet(), 1, 1, 1, 1, 0},
+		{NewSet(1), NewSet(), 0, 0, 0, 0, 1},
+		{NewSet(1, 2), NewSet(1, 2), 1, 1, 1, 1, 0},
+		{NewThreadUnsafeSetFromSlice([]interface{}{1, 2}), NewThreadUnsafeSetFromSlice([]interface{}{2}), 0.5, 2.0 / 3, 1, 1 / math.Sqrt(2), 1},
+	}
+
+	near := func(x, y float64) bool { return math.Abs(x-y) < 1e-12 }
+	for i, testCase := range testCases {
+		if got := Jaccard(testCase.a, testCase.b); !near(got, testCase.jaccard) {
+			t.Errorf("test %d: expected Jaccard %v, got %v", i, testCase.jaccard, got)
+		}
+		if got := SorensenDice(testCase.a, testCase.b); !near(got, testCase.dice) {
+			t.Errorf("test %d: expected SorensenDice %v, got %v", i, testCase.dice, got)
+		}
+		if got := OverlapCoefficient(testCase.a, testCase.b); !near(got, testCase.overlap) {
+			t.Errorf("test %d: expected OverlapCoefficient %v, got %v", i, testCase.overlap, got)
+		}
+		if got := Cosine(testCase.a, testCase.b); !near(got, testCase.cosine) {
+			t.Errorf("test %d: expected Cosine %v, got %v", i, testCase.cosine, got)
+		}
+		if got := SymmetricDifferenceSize(testCase.a, testCase.b); got != testCase.symmetricDifference {
+			t.Errorf("test %d: expected SymmetricDifferenceSize %v, got %v", i, testCase.symmetricDifference, got)
+		}
+	}
+}
+
+func Test_SimilarityDoesNotAllocate(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		Jaccard(a, b)
+		SorensenDice(a, a)
+		OverlapCoefficient(a, b)
+		Cosine(a, b)
+		SymmetricDifferenceSize(a, b)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
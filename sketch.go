@@ -0,0 +1,178 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+// MinHashSignature returns the MinHash signature of s under m. Elements
+// are hashed by their binary encoding, so signatures of root sets are
+// comparable with each other but not with those of typed sets.
+func MinHashSignature(s Set, m *sketch.MinHasher) sketch.Signature {
+	maps, unlock := readLockSets([]Set{s})
+	defer unlock()
+
+	sig := m.NewSignature()
+	var buf []byte
+	for elem := range maps[0] {
+		var h uint64
+		h, buf = hashElement(buf[:0], elem)
+		m.Push(sig, h)
+	}
+	return sig
+}
+
+// ToHyperLogLog returns a HyperLogLog sketch of s with the given
+// precision, hashing elements as MinHashSignature does, so that it can
+// be merged with sketches of other sets.
+func ToHyperLogLog(s Set, precision uint8) *sketch.HyperLogLog {
+	maps, unlock := readLockSets([]Set{s})
+	defer unlock()
+
+	h := sketch.NewHyperLogLog(precision)
+	var buf []byte
+	for elem := range maps[0] {
+		var x uint64
+		x, buf = hashElement(buf[:0], elem)
+		h.AddHash(x)
+	}
+	return h
+}
+
+// BloomFilter is a sketch.BloomFilter over set elements, which hashes
+// elements as MinHashSignature does. A decoded filter answers queries
+// the same way as the one that was encoded.
+type BloomFilter struct {
+	sketch.BloomFilter
+}
+
+// ToBloomFilter returns a Bloom filter holding the elements of s, sized
+// for a false positive rate of about fpRate.
+func ToBloomFilter(s Set, fpRate float64) *BloomFilter {
+	maps, unlock := readLockSets([]Set{s})
+	defer unlock()
+
+	f := &BloomFilter{*sketch.NewBloomFilter(len(maps[0]), fpRate)}
+	var buf []byte
+	for elem := range maps[0] {
+		var h uint64
+		h, buf = hashElement(buf[:0], elem)
+		f.AddHash(h)
+	}
+	return f
+}
+
+// Add adds elem to the filter.
+func (f *BloomFilter) Add(elem interface{}) {
+	h, _ := hashElement(nil, elem)
+	f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *BloomFilter) MightContain(elem interface{}) bool {
+	h, _ := hashElement(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// CuckooFilter is a sketch.CuckooFilter over set elements, which hashes
+// elements as MinHashSignature does.
+type CuckooFilter struct {
+	sketch.CuckooFilter
+}
+
+// ToCuckooFilter returns a cuckoo filter holding the elements of s,
+// sized for a false positive rate of about fpRate. Elements with equal
+// hashes, such as NaNs, are added once. The filter is grown until every
+// element fits, up to a limit, past which an error is returned.
+func ToCuckooFilter(s Set, fpRate float64) (*CuckooFilter, error) {
+	maps, unlock := readLockSets([]Set{s})
+	defer unlock()
+
+	hashes := make([]uint64, 0, len(maps[0]))
+	var buf []byte
+	for elem := range maps[0] {
+		var h uint64
+		h, buf = hashElement(buf[:0], elem)
+		hashes = append(hashes, h)
+	}
+	f, err := newCuckooFilterFromHashes(hashes, fpRate)
+	if err != nil {
+		return nil, err
+	}
+	return &CuckooFilter{*f}, nil
+}
+
+// newCuckooFilterFromHashes returns a filter holding the distinct hashes,
+// doubling its capacity at most cuckooMaxGrowths times.
+func newCuckooFilterFromHashes(hashes []uint64, fpRate float64) (*sketch.CuckooFilter, error) {
+	// A bucket pair holds eight fingerprints, so repeats of one hash
+	// would collide however large the filter grows.
+	slices.Sort(hashes)
+	hashes = slices.Compact(hashes)
+
+	const cuckooMaxGrowths = 8
+	n := len(hashes)
+	for i := 0; i <= cuckooMaxGrowths; i++ {
+		f := sketch.NewCuckooFilter(n, fpRate)
+		if addAllHashes(f, hashes) {
+			return f, nil
+		}
+		n *= 2
+	}
+	return nil, fmt.Errorf("mapset: cannot fit %d distinct hashes in a cuckoo filter", len(hashes))
+}
+
+func addAllHashes(f *sketch.CuckooFilter, hashes []uint64) bool {
+	for _, h := range hashes {
+		if !f.AddHash(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds elem to the filter and reports whether there was room.
+func (f *CuckooFilter) Add(elem interface{}) bool {
+	h, _ := hashElement(nil, elem)
+	return f.AddHash(h)
+}
+
+// MightContain reports whether elem may have been added to the filter.
+func (f *CuckooFilter) MightContain(elem interface{}) bool {
+	h, _ := hashElement(nil, elem)
+	return f.MightContainHash(h)
+}
+
+// Delete removes elem from the filter and reports whether it was found.
+// Only delete elements that were added.
+func (f *CuckooFilter) Delete(elem interface{}) bool {
+	h, _ := hashElement(nil, elem)
+	return f.DeleteHash(h)
+}
@@ -0,0 +1,194 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// bloomFormatVersion is the version byte of encoded BloomFilters.
+const bloomFormatVersion byte = 1
+
+// bloomMaxHashes caps the number of hash functions, which only tiny
+// false positive rates would otherwise push past it.
+const bloomMaxHashes = 64
+
+var errBloomTruncated = errors.New("sketch: truncated bloom filter data")
+
+// BloomFilter is an approximate membership filter. MightContainHash
+// never reports false for an added hash, and reports true for other
+// hashes with roughly the false positive rate the filter was sized for.
+// Elements cannot be removed; see CuckooFilter.
+//
+// The zero value has no room for elements: it contains nothing, and
+// AddHash panics on it. Use NewBloomFilter or UnmarshalBinary to create
+// a usable filter. A BloomFilter is not safe for concurrent use.
+type BloomFilter struct {
+	words  []uint64
+	m      uint64
+	hashes int
+}
+
+// NewBloomFilter returns an empty filter sized to hold n elements with a
+// false positive rate of about fpRate. It panics if fpRate is not
+// between 0 and 1.
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+	if !(fpRate > 0 && fpRate < 1) {
+		panic(fmt.Sprintf("sketch: invalid false positive rate %v", fpRate))
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	if k > bloomMaxHashes {
+		k = bloomMaxHashes
+	}
+	return &BloomFilter{
+		words:  make([]uint64, (uint64(m)+63)/64),
+		m:      uint64(m),
+		hashes: k,
+	}
+}
+
+// AddHash adds an element with hash h. It panics if f was not created
+// by NewBloomFilter or UnmarshalBinary.
+func (f *BloomFilter) AddHash(h uint64) {
+	if f.m == 0 {
+		panic("sketch: AddHash on a zero BloomFilter; use NewBloomFilter")
+	}
+	h1, h2 := bloomHashes(h)
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		f.words[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MightContainHash reports whether an element with hash h may have been
+// added.
+func (f *BloomFilter) MightContainHash(h uint64) bool {
+	if f.m == 0 {
+		return false
+	}
+	h1, h2 := bloomHashes(h)
+	for i := 0; i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		if f.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds every element of other to f. Both filters must have been
+// created with the same parameters.
+func (f *BloomFilter) Merge(other *BloomFilter) error {
+	if f.m != other.m || f.hashes != other.hashes {
+		return errors.New("sketch: cannot merge bloom filters of different shapes")
+	}
+	for i, w := range other.words {
+		f.words[i] |= w
+	}
+	return nil
+}
+
+// EstimatedFPRate returns the false positive rate implied by how full
+// the filter is.
+func (f *BloomFilter) EstimatedFPRate() float64 {
+	if f.m == 0 {
+		return 0
+	}
+	set := 0
+	for _, w := range f.words {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.hashes))
+}
+
+// MarshalBinary encodes the filter as a version byte, the number of hash
+// functions and bits as uvarints, and then the bits as little-endian
+// 64-bit words.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+2*binary.MaxVarintLen64+8*len(f.words))
+	b = append(b, bloomFormatVersion)
+	b = binary.AppendUvarint(b, uint64(f.hashes))
+	b = binary.AppendUvarint(b, f.m)
+	for _, w := range f.words {
+		b = binary.LittleEndian.AppendUint64(b, w)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary.
+// The filter is left untouched on error.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errBloomTruncated
+	}
+	if data[0] != bloomFormatVersion {
+		return fmt.Errorf("sketch: unsupported bloom filter format version %d", data[0])
+	}
+	data = data[1:]
+
+	k, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errBloomTruncated
+	}
+	data = data[n:]
+	m, n := binary.Uvarint(data)
+	if n <= 0 {
+		return errBloomTruncated
+	}
+	data = data[n:]
+
+	if k == 0 || k > bloomMaxHashes || m == 0 {
+		return fmt.Errorf("sketch: invalid bloom filter of %d bits and %d hashes", m, k)
+	}
+	if m > uint64(len(data))*8 || uint64(len(data)) != (m+63)/64*8 {
+		return fmt.Errorf("sketch: bloom filter of %d bits has %d bytes of data", m, len(data))
+	}
+
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	*f = BloomFilter{words: words, m: m, hashes: int(k)}
+	return nil
+}
+
+// bloomHashes derives the two hashes combined by double hashing. The
+// second is odd so that it never degenerates to a single probe.
+func bloomHashes(h uint64) (uint64, uint64) {
+	return h, Mix64(h^0x9e3779b97f4a7c15) | 1
+}
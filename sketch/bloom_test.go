@@ -0,0 +1,109 @@
+package sketch
+
+import "testing"
+
+func TestBloomFilter(t *testing.T) {
+	f := NewBloomFilter(10000, 0.01)
+	for _, h := range hashInts(0, 10000) {
+		f.AddHash(h)
+	}
+	for _, h := range hashInts(0, 10000) {
+		if !f.MightContainHash(h) {
+			t.Fatal("expected no false negatives")
+		}
+	}
+
+	falsePositives := 0
+	for _, h := range hashInts(10000, 110000) {
+		if f.MightContainHash(h) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 100000; rate > 0.02 {
+		t.Errorf("expected a false positive rate near 0.01, got %v", rate)
+	}
+	if rate := f.EstimatedFPRate(); rate > 0.02 {
+		t.Errorf("expected an estimated false positive rate near 0.01, got %v", rate)
+	}
+}
+
+func TestBloomFilterZero(t *testing.T) {
+	var f BloomFilter
+	if f.MightContainHash(1) {
+		t.Error("expected the zero filter to contain nothing")
+	}
+	if rate := f.EstimatedFPRate(); rate != 0 {
+		t.Errorf("expected a false positive rate of 0 for the zero filter, got %v", rate)
+	}
+	if err := f.Merge(NewBloomFilter(10, 0.01)); err == nil {
+		t.Error("expected an error merging into the zero filter")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected AddHash to panic on the zero filter")
+		}
+	}()
+	f.AddHash(1)
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f := NewBloomFilter(100, 0.001)
+	for _, h := range hashInts(0, 100) {
+		f.AddHash(h)
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range hashInts(0, 100) {
+		if !decoded.MightContainHash(h) {
+			t.Fatal("expected the decoded filter to contain every added hash")
+		}
+	}
+
+	for _, bad := range [][]byte{nil, {2}, b[:len(b)-1], {bloomFormatVersion, 0, 64}} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("expected an error decoding %v", bad)
+		}
+	}
+
+	// A tiny rate asks for more hash functions than the format allows.
+	f = NewBloomFilter(10, 1e-20)
+	for _, h := range hashInts(0, 10) {
+		f.AddHash(h)
+	}
+	if b, err = f.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("expected a filter sized for a tiny rate to decode, got %v", err)
+	}
+	for _, h := range hashInts(0, 10) {
+		if !decoded.MightContainHash(h) {
+			t.Fatal("expected the decoded filter to contain every added hash")
+		}
+	}
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	a := NewBloomFilter(100, 0.01)
+	b := NewBloomFilter(100, 0.01)
+	a.AddHash(1)
+	b.AddHash(2)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.MightContainHash(1) || !a.MightContainHash(2) {
+		t.Error("expected the merged filter to contain both hashes")
+	}
+	if err := a.Merge(NewBloomFilter(1000, 0.01)); err == nil {
+		t.Error("expected an error merging filters of different shapes")
+	}
+}
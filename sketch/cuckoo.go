@@ -0,0 +1,315 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package sketch
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// cuckooFormatVersion is the version byte of encoded CuckooFilters.
+const cuckooFormatVersion byte = 1
+
+const (
+	// cuckooBucketSize is the number of fingerprints in each bucket.
+	cuckooBucketSize = 4
+
+	// cuckooLoadFactor is the share of slots a filter is sized to fill.
+	cuckooLoadFactor = 0.95
+
+	// cuckooMaxKicks bounds how many fingerprints an insertion may
+	// relocate before the filter is considered full.
+	cuckooMaxKicks = 500
+)
+
+var errCuckooTruncated = errors.New("sketch: truncated cuckoo filter data")
+
+// CuckooFilter is an approximate membership filter that, unlike a
+// BloomFilter, supports deleting elements. It stores a short fingerprint
+// of each element in one of two candidate buckets.
+//
+// Deleting a hash that was never added may remove the fingerprint of a
+// different element. The zero value is an empty filter that contains
+// nothing; use NewCuckooFilter or UnmarshalBinary to create a usable
+// one. A CuckooFilter is not safe for concurrent use.
+type CuckooFilter struct {
+	buckets []uint32 // cuckooBucketSize fingerprints per bucket, 0 when empty
+	mask    uint64   // bucket count minus one
+	fpBits  uint
+	count   int
+
+	// victim holds a fingerprint that could not be placed after a full
+	// sequence of kicks, so that no added element is ever lost.
+	victim      uint32
+	victimIndex uint64
+
+	rng uint64
+}
+
+// NewCuckooFilter returns an empty filter sized to hold n elements with
+// a false positive rate of about fpRate. It panics if fpRate is not
+// between 0 and 1.
+func NewCuckooFilter(n int, fpRate float64) *CuckooFilter {
+	if !(fpRate > 0 && fpRate < 1) {
+		panic(fmt.Sprintf("sketch: invalid false positive rate %v", fpRate))
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	fpBits := uint(math.Ceil(math.Log2(2 * cuckooBucketSize / fpRate)))
+	if fpBits > 32 {
+		fpBits = 32
+	}
+	buckets := uint64(math.Ceil(float64(n) / (cuckooBucketSize * cuckooLoadFactor)))
+	if buckets < 1 {
+		buckets = 1
+	}
+	buckets = 1 << bits.Len64(buckets-1)
+
+	return &CuckooFilter{
+		buckets: make([]uint32, buckets*cuckooBucketSize),
+		mask:    buckets - 1,
+		fpBits:  fpBits,
+	}
+}
+
+// Len returns the number of fingerprints in the filter.
+func (f *CuckooFilter) Len() int {
+	return f.count
+}
+
+// AddHash adds an element with hash h. It returns false, leaving the
+// filter unchanged, if the filter is too full to place it; a larger
+// filter is needed.
+func (f *CuckooFilter) AddHash(h uint64) bool {
+	if f.buckets == nil || f.victim != 0 {
+		return false
+	}
+
+	fp, i1 := f.fingerprint(h)
+	i2 := f.altIndex(i1, fp)
+	if f.insert(i1, fp) || f.insert(i2, fp) {
+		f.count++
+		return true
+	}
+
+	// Relocate fingerprints along a random walk until one lands in a
+	// free slot. The last displaced fingerprint becomes the victim.
+	i := i1
+	if f.next()&1 == 1 {
+		i = i2
+	}
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := i*cuckooBucketSize + f.next()%cuckooBucketSize
+		fp, f.buckets[slot] = f.buckets[slot], fp
+		i = f.altIndex(i, fp)
+		if f.insert(i, fp) {
+			f.count++
+			return true
+		}
+	}
+	f.victim, f.victimIndex = fp, i
+	f.count++
+	return true
+}
+
+// MightContainHash reports whether an element with hash h may have been
+// added.
+func (f *CuckooFilter) MightContainHash(h uint64) bool {
+	if f.buckets == nil {
+		return false
+	}
+	fp, i1 := f.fingerprint(h)
+	i2 := f.altIndex(i1, fp)
+	if f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		return true
+	}
+	return f.find(i1, fp) >= 0 || f.find(i2, fp) >= 0
+}
+
+// DeleteHash removes one element with hash h and reports whether a
+// matching fingerprint was found.
+func (f *CuckooFilter) DeleteHash(h uint64) bool {
+	if f.buckets == nil {
+		return false
+	}
+	fp, i1 := f.fingerprint(h)
+	i2 := f.altIndex(i1, fp)
+	if f.victim == fp && (f.victimIndex == i1 || f.victimIndex == i2) {
+		f.victim = 0
+		f.count--
+		return true
+	}
+	for _, i := range [2]uint64{i1, i2} {
+		if slot := f.find(i, fp); slot >= 0 {
+			f.buckets[slot] = 0
+			f.count--
+			f.placeVictim()
+			return true
+		}
+	}
+	return false
+}
+
+// placeVictim moves the victim into a bucket once deletion frees room.
+func (f *CuckooFilter) placeVictim() {
+	if f.victim == 0 {
+		return
+	}
+	fp, i := f.victim, f.victimIndex
+	if f.insert(i, fp) || f.insert(f.altIndex(i, fp), fp) {
+		f.victim = 0
+	}
+}
+
+// fingerprint returns the non-zero fingerprint of h and its first
+// bucket index, taken from independent bits of the hash.
+func (f *CuckooFilter) fingerprint(h uint64) (uint32, uint64) {
+	fp := uint32(h >> 32)
+	if f.fpBits < 32 {
+		fp &= 1<<f.fpBits - 1
+	}
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & f.mask
+}
+
+// altIndex returns the other bucket of fp, given one of its buckets.
+// Applying it twice returns the original bucket.
+func (f *CuckooFilter) altIndex(i uint64, fp uint32) uint64 {
+	return (i ^ Mix64(uint64(fp))) & f.mask
+}
+
+func (f *CuckooFilter) insert(i uint64, fp uint32) bool {
+	bucket := f.buckets[i*cuckooBucketSize : (i+1)*cuckooBucketSize]
+	for j, v := range bucket {
+		if v == 0 {
+			bucket[j] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the slot holding fp in bucket i, or -1.
+func (f *CuckooFilter) find(i uint64, fp uint32) int {
+	for j := uint64(0); j < cuckooBucketSize; j++ {
+		if slot := i*cuckooBucketSize + j; f.buckets[slot] == fp {
+			return int(slot)
+		}
+	}
+	return -1
+}
+
+// next returns a value from a xorshift sequence, so that kicks are
+// deterministic for a given sequence of insertions.
+func (f *CuckooFilter) next() uint64 {
+	if f.rng == 0 {
+		f.rng = 0x2545f4914f6cdd1d
+	}
+	f.rng ^= f.rng << 13
+	f.rng ^= f.rng >> 7
+	f.rng ^= f.rng << 17
+	return f.rng
+}
+
+// MarshalBinary encodes the filter as a version byte, then the
+// fingerprint size, the bucket count, the element count and the victim
+// as uvarints, and then every fingerprint slot in as few little-endian
+// bytes as the fingerprint size allows.
+func (f *CuckooFilter) MarshalBinary() ([]byte, error) {
+	width := int(f.fpBits+7) / 8
+	b := make([]byte, 0, 1+5*binary.MaxVarintLen64+width*len(f.buckets))
+	b = append(b, cuckooFormatVersion)
+	b = binary.AppendUvarint(b, uint64(f.fpBits))
+	b = binary.AppendUvarint(b, uint64(len(f.buckets)/cuckooBucketSize))
+	b = binary.AppendUvarint(b, uint64(f.count))
+	b = binary.AppendUvarint(b, uint64(f.victim))
+	b = binary.AppendUvarint(b, f.victimIndex)
+
+	var buf [4]byte
+	for _, fp := range f.buckets {
+		binary.LittleEndian.PutUint32(buf[:], fp)
+		b = append(b, buf[:width]...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary.
+// The filter is left untouched on error.
+func (f *CuckooFilter) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errCuckooTruncated
+	}
+	if data[0] != cuckooFormatVersion {
+		return fmt.Errorf("sketch: unsupported cuckoo filter format version %d", data[0])
+	}
+	data = data[1:]
+
+	var header [5]uint64
+	for i := range header {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errCuckooTruncated
+		}
+		header[i], data = v, data[n:]
+	}
+	fpBits, buckets, count, victim, victimIndex := header[0], header[1], header[2], header[3], header[4]
+
+	if fpBits == 0 || fpBits > 32 || buckets == 0 || buckets&(buckets-1) != 0 ||
+		victim >= 1<<fpBits || victimIndex >= buckets {
+		return errors.New("sketch: invalid cuckoo filter header")
+	}
+	width := (fpBits + 7) / 8
+	if buckets > uint64(len(data)) || uint64(len(data)) != buckets*cuckooBucketSize*width {
+		return fmt.Errorf("sketch: cuckoo filter of %d buckets has %d bytes of data", buckets, len(data))
+	}
+
+	slots := make([]uint32, buckets*cuckooBucketSize)
+	for i := range slots {
+		var buf [4]byte
+		copy(buf[:], data[uint64(i)*width:uint64(i+1)*width])
+		slots[i] = binary.LittleEndian.Uint32(buf[:])
+		if uint64(slots[i]) >= 1<<fpBits {
+			return errors.New("sketch: cuckoo filter fingerprint out of range")
+		}
+	}
+
+	*f = CuckooFilter{
+		buckets:     slots,
+		mask:        buckets - 1,
+		fpBits:      uint(fpBits),
+		count:       int(count),
+		victim:      uint32(victim),
+		victimIndex: victimIndex,
+	}
+	return nil
+}
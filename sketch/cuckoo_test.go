@@ -0,0 +1,114 @@
+package sketch
+
+import "testing"
+
+func TestCuckooFilter(t *testing.T) {
+	f := NewCuckooFilter(10000, 0.01)
+	hashes := hashInts(0, 10000)
+	for _, h := range hashes {
+		if !f.AddHash(h) {
+			t.Fatalf("expected room for %d elements", len(hashes))
+		}
+	}
+	if f.Len() != len(hashes) {
+		t.Errorf("expected %d elements, got %d", len(hashes), f.Len())
+	}
+	for _, h := range hashes {
+		if !f.MightContainHash(h) {
+			t.Fatal("expected no false negatives")
+		}
+	}
+
+	falsePositives := 0
+	for _, h := range hashInts(10000, 110000) {
+		if f.MightContainHash(h) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 100000; rate > 0.02 {
+		t.Errorf("expected a false positive rate near 0.01, got %v", rate)
+	}
+
+	for _, h := range hashes[:5000] {
+		if !f.DeleteHash(h) {
+			t.Fatal("expected every added hash to be deletable")
+		}
+	}
+	for _, h := range hashes[5000:] {
+		if !f.MightContainHash(h) {
+			t.Fatal("expected deletion to keep the other elements")
+		}
+	}
+	if f.Len() != 5000 {
+		t.Errorf("expected 5000 elements after deletion, got %d", f.Len())
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	f := NewCuckooFilter(8, 0.01)
+	added := 0
+	for _, h := range hashInts(0, 1000) {
+		if !f.AddHash(h) {
+			break
+		}
+		added++
+	}
+	if added == 1000 {
+		t.Fatal("expected a small filter to fill up")
+	}
+	for _, h := range hashInts(0, added) {
+		if !f.MightContainHash(h) {
+			t.Fatal("expected a full filter to keep every added hash")
+		}
+	}
+}
+
+func TestCuckooFilterBinary(t *testing.T) {
+	f := NewCuckooFilter(100, 0.0001)
+	for _, h := range hashInts(0, 100) {
+		f.AddHash(h)
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded CuckooFilter
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Len() != 100 {
+		t.Errorf("expected 100 decoded elements, got %d", decoded.Len())
+	}
+	for _, h := range hashInts(0, 100) {
+		if !decoded.MightContainHash(h) {
+			t.Fatal("expected the decoded filter to contain every added hash")
+		}
+	}
+	if !decoded.DeleteHash(hashInts(0, 1)[0]) {
+		t.Error("expected the decoded filter to support deletion")
+	}
+
+	for _, bad := range [][]byte{nil, {2}, b[:len(b)-1]} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("expected an error decoding %v", bad)
+		}
+	}
+
+	// Below a rate of about 3.7e-9 fingerprints take all 32 bits.
+	f = NewCuckooFilter(10, 1e-10)
+	for _, h := range hashInts(0, 10) {
+		f.AddHash(h)
+	}
+	if b, err = f.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("expected a filter with 32-bit fingerprints to decode, got %v", err)
+	}
+	for _, h := range hashInts(0, 10) {
+		if !decoded.MightContainHash(h) {
+			t.Fatal("expected the decoded filter to contain every added hash")
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// hllFormatVersion is the version byte of encoded HyperLogLogs.
+const hllFormatVersion byte = 1
+
+// Bounds on HyperLogLog precision. The standard error of an estimate is
+// about 1.04/sqrt(2^precision).
+const (
+	MinHLLPrecision = 4
+	MaxHLLPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct elements added to it in
+// a fixed 2^precision bytes. Sketches with the same precision can be
+// merged to estimate the size of the union of their inputs.
+//
+// Use NewHyperLogLog or UnmarshalBinary to create a HyperLogLog; the
+// zero value is not usable. A HyperLogLog is not safe for concurrent
+// use.
+type HyperLogLog struct {
+	registers []uint8
+	precision uint8
+}
+
+// NewHyperLogLog returns an empty sketch with the given precision. It
+// panics if precision is outside MinHLLPrecision and MaxHLLPrecision.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < MinHLLPrecision || precision > MaxHLLPrecision {
+		panic(fmt.Sprintf("sketch: invalid HyperLogLog precision %d", precision))
+	}
+	return &HyperLogLog{registers: make([]uint8, 1<<precision), precision: precision}
+}
+
+// Precision returns the precision the sketch was created with.
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// AddHash adds an element with hash x.
+func (h *HyperLogLog) AddHash(x uint64) {
+	index := x >> (64 - h.precision)
+	// The guard bit bounds the run of zeros for hashes whose remaining
+	// bits are all zero.
+	rank := uint8(bits.LeadingZeros64(x<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// Merge folds other into h, so that h estimates the union of both
+// inputs. Both sketches must have the same precision.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("sketch: cannot merge HyperLogLogs of precision %d and %d", h.precision, other.precision)
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// Estimate returns the estimated number of distinct elements added.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	if m == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(len(h.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Clone returns an independent copy of h.
+func (h *HyperLogLog) Clone() *HyperLogLog {
+	registers := make([]uint8, len(h.registers))
+	copy(registers, h.registers)
+	return &HyperLogLog{registers: registers, precision: h.precision}
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// MarshalBinary encodes the sketch as a version byte, the precision and
+// then one byte per register.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 2+len(h.registers))
+	b = append(b, hllFormatVersion, h.precision)
+	return append(b, h.registers...), nil
+}
+
+// UnmarshalBinary replaces the sketch with one encoded by MarshalBinary.
+// The sketch is left untouched on error.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("sketch: truncated HyperLogLog data")
+	}
+	if data[0] != hllFormatVersion {
+		return fmt.Errorf("sketch: unsupported HyperLogLog format version %d", data[0])
+	}
+
+	precision := data[1]
+	if precision < MinHLLPrecision || precision > MaxHLLPrecision {
+		return fmt.Errorf("sketch: invalid HyperLogLog precision %d", precision)
+	}
+	data = data[2:]
+	if len(data) != 1<<precision {
+		return fmt.Errorf("sketch: HyperLogLog of precision %d has %d registers", precision, len(data))
+	}
+	for _, r := range data {
+		if r > 64-precision+1 {
+			return fmt.Errorf("sketch: HyperLogLog register %d out of range", r)
+		}
+	}
+
+	registers := make([]uint8, len(data))
+	copy(registers, data)
+	*h = HyperLogLog{registers: registers, precision: precision}
+	return nil
+}
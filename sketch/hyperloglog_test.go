@@ -0,0 +1,67 @@
+package sketch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperLogLogEstimate(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 100000} {
+		h := NewHyperLogLog(14)
+		for _, x := range hashInts(0, n) {
+			h.AddHash(x)
+			h.AddHash(x)
+		}
+
+		est := float64(h.Estimate())
+		if math.Abs(est-float64(n)) > 0.03*float64(n)+1 {
+			t.Errorf("expected an estimate near %d, got %v", n, est)
+		}
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog(12)
+	b := NewHyperLogLog(12)
+	for _, x := range hashInts(0, 6000) {
+		a.AddHash(x)
+	}
+	for _, x := range hashInts(4000, 10000) {
+		b.AddHash(x)
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if est := float64(a.Estimate()); math.Abs(est-10000) > 500 {
+		t.Errorf("expected a merged estimate near 10000, got %v", est)
+	}
+	if err := a.Merge(NewHyperLogLog(10)); err == nil {
+		t.Error("expected an error merging sketches of different precision")
+	}
+}
+
+func TestHyperLogLogBinary(t *testing.T) {
+	h := NewHyperLogLog(8)
+	for _, x := range hashInts(0, 500) {
+		h.AddHash(x)
+	}
+
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded HyperLogLog
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Estimate() != h.Estimate() || decoded.Precision() != 8 {
+		t.Errorf("expected the decoded sketch to match, got %d", decoded.Estimate())
+	}
+
+	for _, bad := range [][]byte{nil, {2, 8}, {hllFormatVersion, 3}, b[:len(b)-1]} {
+		if err := decoded.UnmarshalBinary(bad); err == nil {
+			t.Errorf("expected an error decoding %v", bad)
+		}
+	}
+}
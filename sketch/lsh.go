@@ -0,0 +1,195 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package sketch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LSHIndex finds similar sets among many by locality-sensitive hashing
+// of their MinHash signatures. A signature is cut into bands of rows;
+// sets whose signatures agree on every row of at least one band become
+// candidates. Two sets with Jaccard index s are candidates with
+// probability 1-(1-s^rows)^bands, so the choice of bands and rows sets
+// a similarity threshold; see LSHBands.
+//
+// An LSHIndex is not safe for concurrent use.
+type LSHIndex struct {
+	bands, rows int
+	buckets     []map[uint64][]string
+	signatures  map[string]Signature
+}
+
+// LSHPair is a pair of keys whose signatures are similar.
+type LSHPair struct {
+	A, B string
+
+	// Similarity is the Jaccard index estimated from the signatures.
+	Similarity float64
+}
+
+// LSHMatch is an indexed key whose signature is similar to a query.
+type LSHMatch struct {
+	Key string
+
+	// Similarity is the Jaccard index estimated from the signatures.
+	Similarity float64
+}
+
+// NewLSHIndex returns an empty index for signatures of bands*rows
+// hashes. It panics if bands or rows is not positive.
+func NewLSHIndex(bands, rows int) *LSHIndex {
+	if bands <= 0 || rows <= 0 {
+		panic(fmt.Sprintf("sketch: invalid LSH shape of %d bands and %d rows", bands, rows))
+	}
+
+	buckets := make([]map[uint64][]string, bands)
+	for i := range buckets {
+		buckets[i] = make(map[uint64][]string)
+	}
+	return &LSHIndex{
+		bands:      bands,
+		rows:       rows,
+		buckets:    buckets,
+		signatures: make(map[string]Signature),
+	}
+}
+
+// LSHBands returns the number of bands and rows, dividing a signature of
+// k hashes, whose candidate threshold (1/bands)^(1/rows) is closest to
+// threshold.
+func LSHBands(k int, threshold float64) (bands, rows int) {
+	best := math.Inf(1)
+	for r := 1; r <= k; r++ {
+		if k%r != 0 {
+			continue
+		}
+		b := k / r
+		if d := math.Abs(math.Pow(1/float64(b), 1/float64(r)) - threshold); d < best {
+			best, bands, rows = d, b, r
+		}
+	}
+	return bands, rows
+}
+
+// Len returns the number of signatures in the index.
+func (ix *LSHIndex) Len() int {
+	return len(ix.signatures)
+}
+
+// Add indexes a copy of sig under key, so the caller may reuse sig. It
+// fails if the signature does not have bands*rows hashes or if key is
+// already indexed.
+func (ix *LSHIndex) Add(key string, sig Signature) error {
+	if len(sig) != ix.bands*ix.rows {
+		return fmt.Errorf("sketch: signature of %d hashes does not fit %d bands of %d rows", len(sig), ix.bands, ix.rows)
+	}
+	if _, ok := ix.signatures[key]; ok {
+		return fmt.Errorf("sketch: key %q is already indexed", key)
+	}
+
+	sig = append(make(Signature, 0, len(sig)), sig...)
+	ix.signatures[key] = sig
+	for band, buckets := range ix.buckets {
+		h := ix.bandHash(sig, band)
+		buckets[h] = append(buckets[h], key)
+	}
+	return nil
+}
+
+// Query returns the keys whose signatures share a band with sig and
+// have an estimated similarity to it of at least threshold, sorted by
+// key.
+func (ix *LSHIndex) Query(sig Signature, threshold float64) []LSHMatch {
+	if len(sig) != ix.bands*ix.rows {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var matches []LSHMatch
+	for band, buckets := range ix.buckets {
+		for _, key := range buckets[ix.bandHash(sig, band)] {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+
+			if s := EstimateJaccard(sig, ix.signatures[key]); s >= threshold {
+				matches = append(matches, LSHMatch{Key: key, Similarity: s})
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Key < matches[j].Key })
+	return matches
+}
+
+// CandidatePairs returns every pair of indexed keys that share a band
+// and have an estimated similarity of at least threshold, with A < B,
+// sorted by A and then B. The cost grows with the square of the
+// largest bucket.
+func (ix *LSHIndex) CandidatePairs(threshold float64) []LSHPair {
+	seen := make(map[[2]string]struct{})
+	var pairs []LSHPair
+	for _, buckets := range ix.buckets {
+		for _, keys := range buckets {
+			for i := 0; i < len(keys); i++ {
+				for j := i + 1; j < len(keys); j++ {
+					a, b := keys[i], keys[j]
+					if b < a {
+						a, b = b, a
+					}
+					if _, ok := seen[[2]string{a, b}]; ok {
+						continue
+					}
+					seen[[2]string{a, b}] = struct{}{}
+
+					if s := EstimateJaccard(ix.signatures[a], ix.signatures[b]); s >= threshold {
+						pairs = append(pairs, LSHPair{A: a, B: b, Similarity: s})
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+// bandHash hashes the rows of sig that make up band.
+func (ix *LSHIndex) bandHash(sig Signature, band int) uint64 {
+	h := uint64(band)
+	for _, v := range sig[band*ix.rows : (band+1)*ix.rows] {
+		h = Mix64(h ^ v)
+	}
+	return h
+}
@@ -0,0 +1,81 @@
+package sketch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLSHBands(t *testing.T) {
+	bands, rows := LSHBands(128, 0.8)
+	if bands*rows != 128 {
+		t.Fatalf("expected %d bands of %d rows to cover 128 hashes", bands, rows)
+	}
+	if bands != 8 || rows != 16 {
+		t.Errorf("expected 8 bands of 16 rows for a threshold of 0.8, got %d of %d", bands, rows)
+	}
+}
+
+func TestLSHIndex(t *testing.T) {
+	m := NewMinHasher(128, 42)
+	ix := NewLSHIndex(LSHBands(m.Size(), 0.7))
+
+	// Documents 0 and 1 are near-duplicates; the others are disjoint.
+	docs := [][]uint64{
+		hashInts(0, 1000),
+		hashInts(10, 1010),
+		hashInts(5000, 6000),
+		hashInts(9000, 9500),
+	}
+	for i, doc := range docs {
+		if err := ix.Add(fmt.Sprint("doc", i), m.Signature(doc)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ix.Len() != len(docs) {
+		t.Errorf("expected %d indexed documents, got %d", len(docs), ix.Len())
+	}
+
+	pairs := ix.CandidatePairs(0.8)
+	if len(pairs) != 1 || pairs[0].A != "doc0" || pairs[0].B != "doc1" {
+		t.Fatalf("expected only doc0 and doc1 to pair, got %v", pairs)
+	}
+	if pairs[0].Similarity < 0.8 {
+		t.Errorf("expected a similarity of at least 0.8, got %v", pairs[0].Similarity)
+	}
+
+	matches := ix.Query(m.Signature(hashInts(5, 1005)), 0.8)
+	if len(matches) != 2 || matches[0].Key != "doc0" || matches[1].Key != "doc1" {
+		t.Errorf("expected the query to match doc0 and doc1, got %v", matches)
+	}
+}
+
+func TestLSHIndexAddErrors(t *testing.T) {
+	ix := NewLSHIndex(4, 2)
+	if err := ix.Add("a", NewMinHasher(7, 0).NewSignature()); err == nil {
+		t.Error("expected an error adding a signature of the wrong length")
+	}
+	sig := NewMinHasher(8, 0).NewSignature()
+	if err := ix.Add("a", sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Add("a", sig); err == nil {
+		t.Error("expected an error adding a key twice")
+	}
+}
+
+func TestLSHIndexAddCopies(t *testing.T) {
+	m := NewMinHasher(128, 42)
+	ix := NewLSHIndex(LSHBands(m.Size(), 0.7))
+
+	sig := m.Signature(hashInts(0, 1000))
+	if err := ix.Add("doc", sig); err != nil {
+		t.Fatal(err)
+	}
+	query := append(Signature(nil), sig...)
+	for i := range sig {
+		sig[i] = 0
+	}
+	if matches := ix.Query(query, 0.99); len(matches) != 1 || matches[0].Similarity != 1 {
+		t.Errorf("expected the indexed signature to survive reuse of the buffer, got %v", matches)
+	}
+}
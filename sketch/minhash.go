@@ -0,0 +1,113 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package sketch
+
+import (
+	"fmt"
+	"math"
+)
+
+// Signature is a MinHash signature: for each of the hash functions of
+// a MinHasher, the minimum hash over the elements of a set.
+type Signature []uint64
+
+// MinHasher computes MinHash signatures with a fixed number of hash
+// functions derived from a seed. Signatures are comparable when they
+// come from MinHashers with the same size and seed and their elements
+// were hashed the same way.
+type MinHasher struct {
+	seeds []uint64
+}
+
+// NewMinHasher returns a MinHasher with k hash functions derived from
+// seed. The standard error of estimated similarities is about
+// 1/sqrt(k). NewMinHasher panics if k is not positive.
+func NewMinHasher(k int, seed uint64) *MinHasher {
+	if k <= 0 {
+		panic(fmt.Sprintf("sketch: invalid MinHash size %d", k))
+	}
+
+	seeds := make([]uint64, k)
+	state := seed
+	for i := range seeds {
+		seeds[i] = splitMix64(&state)
+	}
+	return &MinHasher{seeds: seeds}
+}
+
+// Size returns the number of hash functions, which is the length of the
+// signatures m produces.
+func (m *MinHasher) Size() int {
+	return len(m.seeds)
+}
+
+// NewSignature returns the signature of an empty set, ready for Push.
+func (m *MinHasher) NewSignature() Signature {
+	sig := make(Signature, len(m.seeds))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	return sig
+}
+
+// Push updates sig, which must have been created by m, with an element
+// whose hash is h.
+func (m *MinHasher) Push(sig Signature, h uint64) {
+	for i, seed := range m.seeds {
+		if v := Mix64(h ^ seed); v < sig[i] {
+			sig[i] = v
+		}
+	}
+}
+
+// Signature returns the signature of the elements with the given hashes.
+func (m *MinHasher) Signature(hashes []uint64) Signature {
+	sig := m.NewSignature()
+	for _, h := range hashes {
+		m.Push(sig, h)
+	}
+	return sig
+}
+
+// EstimateJaccard estimates the Jaccard index of the sets behind two
+// signatures as the fraction of hash functions on which they agree. It
+// panics if the signatures have different lengths.
+func EstimateJaccard(a, b Signature) float64 {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("sketch: signature lengths %d and %d differ", len(a), len(b)))
+	}
+	if len(a) == 0 {
+		return 1
+	}
+
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
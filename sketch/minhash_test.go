@@ -0,0 +1,57 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func hashInts(lo, hi int) []uint64 {
+	var buf [8]byte
+	hashes := make([]uint64, 0, hi-lo)
+	for i := lo; i < hi; i++ {
+		binary.LittleEndian.PutUint64(buf[:], uint64(i))
+		hashes = append(hashes, Hash64(buf[:], 0))
+	}
+	return hashes
+}
+
+func TestMinHashEstimate(t *testing.T) {
+	m := NewMinHasher(512, 1)
+
+	// {0..1499} and {500..1999} share 1000 of 2000 elements.
+	a := m.Signature(hashInts(0, 1500))
+	b := m.Signature(hashInts(500, 2000))
+	if est := EstimateJaccard(a, b); math.Abs(est-0.5) > 0.08 {
+		t.Errorf("expected an estimate near 0.5, got %v", est)
+	}
+	if est := EstimateJaccard(a, a); est != 1 {
+		t.Errorf("expected a signature to match itself, got %v", est)
+	}
+	if est := EstimateJaccard(m.NewSignature(), m.NewSignature()); est != 1 {
+		t.Errorf("expected empty sets to be identical, got %v", est)
+	}
+}
+
+func TestMinHashSeeded(t *testing.T) {
+	hashes := hashInts(0, 100)
+	a := NewMinHasher(64, 7).Signature(hashes)
+	b := NewMinHasher(64, 7).Signature(hashes)
+	c := NewMinHasher(64, 8).Signature(hashes)
+
+	if EstimateJaccard(a, b) != 1 {
+		t.Error("expected the same seed to give the same signature")
+	}
+	if EstimateJaccard(a, c) == 1 {
+		t.Error("expected different seeds to give different signatures")
+	}
+}
+
+func TestEstimateJaccardLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic comparing signatures of different lengths")
+		}
+	}()
+	EstimateJaccard(NewMinHasher(2, 0).NewSignature(), NewMinHasher(3, 0).NewSignature())
+}
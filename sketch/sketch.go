@@ -0,0 +1,79 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package sketch implements probabilistic summaries of sets: MinHash
+// signatures with a locality-sensitive hashing index for finding
+// similar sets, Bloom and cuckoo filters for approximate membership, and
+// HyperLogLog for estimating the number of distinct elements.
+//
+// Sketches work on 64-bit element hashes rather than on elements, so
+// that they can be built from any set. The mapset package and the
+// generated typed sets provide adapters that hash their elements with
+// Hash64.
+//
+// Every encoded sketch starts with a version byte. Decoders reject
+// versions they do not know, and the version is bumped whenever an
+// encoding changes, so stored sketches are never misread.
+package sketch
+
+// FNV-1a parameters used by Hash64.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash64 returns a 64-bit hash of b under seed. The hash is stable
+// across processes and platforms, so sketches built from the same
+// seed can be compared and merged anywhere.
+func Hash64(b []byte, seed uint64) uint64 {
+	h := uint64(fnvOffset64) ^ Mix64(seed)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+	return Mix64(h)
+}
+
+// Mix64 is a bijective finalizer that spreads every input bit across
+// the whole output, so that any subset of the bits of the result can be
+// used as a hash.
+func Mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// splitMix64 advances state and returns the next value of the
+// SplitMix64 sequence, used to derive independent seeds from one seed.
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
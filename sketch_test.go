@@ -0,0 +1,136 @@
+package mapset
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/emarcey/golang-set/sketch"
+)
+
+func Test_MinHashSignature(t *testing.T) {
+	a := NewSet()
+	b := NewThreadUnsafeSet()
+	c := NewThreadUnsafeSet()
+	for i := 0; i < 1000; i++ {
+		a.Add(fmt.Sprint("shingle", i))
+		b.Add(fmt.Sprint("shingle", i+500))
+		c.Add(fmt.Sprint("shingle", i+500))
+	}
+
+	m := sketch.NewMinHasher(256, 3)
+	est := sketch.EstimateJaccard(MinHashSignature(a, m), MinHashSignature(b, m))
+	if exact := 500.0 / 1500; math.Abs(est-exact) > 0.1 {
+		t.Errorf("expected an estimate near %v, got %v", exact, est)
+	}
+	if sketch.EstimateJaccard(MinHashSignature(b, m), MinHashSignature(c, m)) != 1 {
+		t.Error("expected equal sets to have equal signatures")
+	}
+}
+
+func Test_ToBloomFilter(t *testing.T) {
+	s := NewSet("a", "b", 1, 2.5)
+	f := ToBloomFilter(s, 0.001)
+	s.Each(func(elem interface{}) bool {
+		if !f.MightContain(elem) {
+			t.Errorf("expected the filter to contain %v", elem)
+		}
+		return false
+	})
+	if f.MightContain("c") {
+		t.Error("expected c to be absent")
+	}
+
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.MightContain("a") {
+		t.Error("expected the decoded filter to contain a")
+	}
+}
+
+func Test_ToCuckooFilter(t *testing.T) {
+	s := NewThreadUnsafeSet()
+	for i := 0; i < 1000; i++ {
+		s.Add(i)
+	}
+	f, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != 1000 {
+		t.Errorf("expected 1000 elements, got %d", f.Len())
+	}
+	if !f.MightContain(10) || !f.Delete(10) || f.MightContain(10) {
+		t.Error("expected 10 to be present and then deleted")
+	}
+	if !f.Add("new") || !f.MightContain("new") {
+		t.Error("expected to add a new element")
+	}
+}
+
+func Test_ToCuckooFilterEqualHashes(t *testing.T) {
+	s := NewThreadUnsafeSet()
+	for i := 0; i < 100; i++ {
+		s.Add(math.NaN())
+	}
+	s.Add(1.5)
+	f, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Len() != 2 {
+		t.Errorf("expected the NaNs to be added once, got %d elements", f.Len())
+	}
+	if !f.MightContain(math.NaN()) || !f.MightContain(1.5) {
+		t.Error("expected NaN and 1.5 in the filter")
+	}
+}
+
+func Test_FiltersFindTimes(t *testing.T) {
+	// Times with a monotonic reading or in another location must be
+	// found as the set stores them.
+	now := time.Now()
+	zoned := time.Date(2020, 2, 29, 12, 30, 0, 0, time.FixedZone("UTC+5", 5*60*60))
+	s := NewSet(now, zoned)
+
+	bloom := ToBloomFilter(s, 0.001)
+	cuckoo, err := ToCuckooFilter(s, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []time.Time{now, zoned} {
+		if !bloom.MightContain(v) {
+			t.Errorf("expected %v in the Bloom filter", v)
+		}
+		if !cuckoo.MightContain(v) {
+			t.Errorf("expected %v in the cuckoo filter", v)
+		}
+	}
+	if !cuckoo.Delete(now) {
+		t.Errorf("expected to delete %v from the cuckoo filter", now)
+	}
+}
+
+func Test_ToHyperLogLog(t *testing.T) {
+	a := NewSet()
+	b := NewThreadUnsafeSet()
+	for i := 0; i < 5000; i++ {
+		a.Add(i)
+		b.Add(i + 2500)
+	}
+
+	h := ToHyperLogLog(a, 12)
+	if err := h.Merge(ToHyperLogLog(b, 12)); err != nil {
+		t.Fatal(err)
+	}
+	if est := float64(h.Estimate()); math.Abs(est-7500) > 400 {
+		t.Errorf("expected a merged estimate near 7500, got %v", est)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// Ranks order elements of different kinds relative to each other.
+const (
+	rankNil = iota
+	rankBool
+	rankNumber
+	rankString
+	rankTime
+	rankOther
+)
+
+func elementRank(elem interface{}) int {
+	if elem == nil {
+		return rankNil
+	}
+	if _, ok := elem.(time.Time); ok {
+		return rankTime
+	}
+	switch reflect.ValueOf(elem).Kind() {
+	case reflect.Bool:
+		return rankBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return rankNumber
+	case reflect.String:
+		return rankString
+	default:
+		return rankOther
+	}
+}
+
+// lessElement orders arbitrary elements for output that must be stable,
+// such as sorted encodings. Elements are grouped by kind, then ordered
+// by value within booleans, numbers, strings and times. Numbers compare
+// exactly whatever their types, with NaN before every other number.
+// Anything else, and ties between equal values of different types,
+// falls back to comparing the type and formatted value.
+func lessElement(a, b interface{}) bool {
+	ra, rb := elementRank(a), elementRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+
+	switch ra {
+	case rankBool:
+		va, vb := reflect.ValueOf(a).Bool(), reflect.ValueOf(b).Bool()
+		if va != vb {
+			return !va
+		}
+	case rankNumber:
+		if c := compareNumbers(a, b); c != 0 {
+			return c < 0
+		}
+	case rankString:
+		if sa, sb := reflect.ValueOf(a).String(), reflect.ValueOf(b).String(); sa != sb {
+			return sa < sb
+		}
+	case rankTime:
+		if ta, tb := a.(time.Time), b.(time.Time); !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+	}
+	return fmt.Sprintf("%T %v", a, a) < fmt.Sprintf("%T %v", b, b)
+}
+
+// compareNumbers returns -1, 0 or +1 as the number a is less than, equal
+// to or greater than b. Signed, unsigned and floating-point values are
+// compared as such, so that integers beyond the precision of a float64
+// stay distinct; mixed pairs are compared exactly. NaN orders before
+// every other number, as with cmp.Compare.
+func compareNumbers(a, b interface{}) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	ka, kb := numberKind(va), numberKind(vb)
+	if ka == kb {
+		switch ka {
+		case reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint64:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		default:
+			return cmp.Compare(va.Float(), vb.Float())
+		}
+	}
+
+	fa, fb := exactNumber(va, ka), exactNumber(vb, kb)
+	switch {
+	case fa == nil:
+		return -1
+	case fb == nil:
+		return 1
+	}
+	return fa.Cmp(fb)
+}
+
+// numberKind returns reflect.Int64, reflect.Uint64 or reflect.Float64 for
+// signed, unsigned and floating-point numbers respectively.
+func numberKind(v reflect.Value) reflect.Kind {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint64
+	default:
+		return reflect.Float64
+	}
+}
+
+// exactNumber returns v as a big.Float, which holds any integer or
+// float64 exactly, or nil for NaN.
+func exactNumber(v reflect.Value, kind reflect.Kind) *big.Float {
+	switch kind {
+	case reflect.Int64:
+		return new(big.Float).SetInt64(v.Int())
+	case reflect.Uint64:
+		return new(big.Float).SetUint64(v.Uint())
+	}
+	f := v.Float()
+	if math.IsNaN(f) {
+		return nil
+	}
+	return new(big.Float).SetFloat64(f)
+}
+
+// sortElements sorts elems in place using lessElement.
+func sortElements(elems []interface{}) {
+	sort.Slice(elems, func(i, j int) bool { return lessElement(elems[i], elems[j]) })
+}
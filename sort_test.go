@@ -0,0 +1,46 @@
+package mapset
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_LessElementNumbers(t *testing.T) {
+	nan := math.NaN()
+	big := int64(1) << 53
+	ordered := []interface{}{
+		nan,
+		math.Inf(-1),
+		int64(math.MinInt64),
+		-1.5,
+		int8(-1),
+		0,
+		uint8(1),
+		1.5,
+		big,
+		big + 1,
+		uint64(big + 2),
+		float64(big + 4),
+		uint64(math.MaxUint64),
+		math.Inf(1),
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got := lessElement(a, b); got != (i < j) {
+				t.Errorf("lessElement(%T %v, %T %v) = %v, want %v", a, a, b, b, got, i < j)
+			}
+		}
+	}
+
+	// Distinct NaNs are equivalent, and equal values of different types
+	// still get a fixed order.
+	if lessElement(nan, math.NaN()) || lessElement(math.NaN(), nan) {
+		t.Error("expected NaNs to be equivalent")
+	}
+	if lessElement(float32(nan), nan) == lessElement(nan, float32(nan)) {
+		t.Error("expected NaNs of different types to be ordered by type")
+	}
+	if lessElement(1, 1.0) == lessElement(1.0, 1) {
+		t.Error("expected equal numbers of different types to be ordered by type")
+	}
+}
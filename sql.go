@@ -0,0 +1,323 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SQLFormat selects how a set is stored in a database column.
+type SQLFormat int
+
+const (
+	// SQLArray stores sets as Postgres array literals such as
+	// {"a","b"}, for text[], bigint[] and similar columns.
+	SQLArray SQLFormat = iota
+
+	// SQLJSON stores sets as JSON arrays, for json, jsonb and text
+	// columns.
+	SQLJSON
+)
+
+var errSQLMultidimensional = errors.New("mapset: multidimensional arrays are not supported")
+
+// SQLValue adapts a Set for use as a query argument or a scan
+// destination with database/sql, in a chosen format.
+//
+// A NULL column scans as an empty set. Scanning replaces the contents
+// of the set and detects the format of the column, so either format
+// can be read regardless of Format.
+type SQLValue struct {
+	set    Set
+	Format SQLFormat
+
+	// Parse converts each element of a Postgres array. Defaults to
+	// keeping elements as strings. NULL elements are scanned as nil.
+	Parse func(string) (interface{}, error)
+}
+
+// NewSQLValue returns an SQLValue that reads and writes s in format.
+func NewSQLValue(s Set, format SQLFormat) *SQLValue {
+	return &SQLValue{set: s, Format: format}
+}
+
+// Value implements driver.Valuer.
+func (v *SQLValue) Value() (driver.Value, error) {
+	if v.set == nil {
+		return nil, nil
+	}
+	if v.Format == SQLJSON {
+		b, err := json.Marshal(v.set)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	return formatSQLArray(v.set)
+}
+
+// Scan implements sql.Scanner.
+func (v *SQLValue) Scan(src interface{}) error {
+	elems, err := scanSQL(src, v.Parse)
+	if err != nil {
+		return err
+	}
+
+	v.set.Clear()
+	for _, elem := range elems {
+		v.set.Add(elem)
+	}
+	return nil
+}
+
+// formatSQLArray renders s as a Postgres array literal. Elements are
+// sorted by their text so that the output is stable.
+func formatSQLArray(s Set) (string, error) {
+	items := make([]string, 0, s.Cardinality())
+
+	var err error
+	s.Each(func(elem interface{}) bool {
+		var item string
+		if item, err = formatSQLElement(elem); err != nil {
+			return true
+		}
+		items = append(items, item)
+		return false
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(items)
+	return "{" + strings.Join(items, ",") + "}", nil
+}
+
+func formatSQLElement(elem interface{}) (string, error) {
+	switch v := elem.(type) {
+	case nil:
+		return "NULL", nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	case string:
+		return quoteSQLElement(v), nil
+	case time.Time:
+		return quoteSQLElement(v.Format(time.RFC3339Nano)), nil
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		return quoteSQLElement(string(b)), err
+	default:
+		return quoteSQLElement(fmt.Sprint(v)), nil
+	}
+}
+
+func quoteSQLElement(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// scanSQL decodes a column holding either a JSON array or a Postgres
+// array literal. NULL decodes as no elements.
+func scanSQL(src interface{}, parse func(string) (interface{}, error)) ([]interface{}, error) {
+	var text []byte
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		text = v
+	case string:
+		text = []byte(v)
+	default:
+		return nil, fmt.Errorf("mapset: cannot scan %T into a set", src)
+	}
+
+	text = bytes.TrimSpace(text)
+	switch {
+	case bytes.HasPrefix(text, []byte("[")):
+		decoded := newThreadUnsafeSet()
+		if err := decoded.UnmarshalJSON(text); err != nil {
+			return nil, err
+		}
+		return decoded.ToSlice(), nil
+	case bytes.HasPrefix(text, []byte("{")):
+		items, err := parseSQLArray(string(text))
+		if err != nil {
+			return nil, err
+		}
+
+		elems := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			var elem interface{}
+			if !item.null {
+				elem = item.text
+				if parse != nil {
+					if elem, err = parse(item.text); err != nil {
+						return nil, err
+					}
+				}
+			}
+			elems = append(elems, elem)
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("mapset: cannot scan %q into a set", text)
+	}
+}
+
+// sqlArrayItem is a single element of a Postgres array literal.
+type sqlArrayItem struct {
+	text string
+	null bool
+}
+
+// parseSQLArray parses a one-dimensional Postgres array literal such
+// as {a,"b c",NULL}.
+func parseSQLArray(s string) ([]sqlArrayItem, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("mapset: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var items []sqlArrayItem
+	for {
+		s = strings.TrimLeft(s, " \t\n\r")
+
+		var item sqlArrayItem
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("mapset: unterminated quote in array literal")
+			}
+			item.text = b.String()
+			s = strings.TrimLeft(s[i+1:], " \t\n\r")
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			item.text = strings.TrimSpace(s[:end])
+			if item.text == "" {
+				return nil, fmt.Errorf("mapset: empty unquoted element in array literal")
+			}
+			if strings.ContainsAny(item.text, `{}"\`) {
+				if strings.HasPrefix(item.text, "{") {
+					return nil, errSQLMultidimensional
+				}
+				return nil, fmt.Errorf("mapset: invalid array element %q", item.text)
+			}
+			item.null = strings.EqualFold(item.text, "NULL")
+			s = s[end:]
+		}
+		items = append(items, item)
+
+		if s == "" {
+			return items, nil
+		}
+		if s[0] != ',' {
+			return nil, fmt.Errorf("mapset: expected ',' in array literal, found %q", s)
+		}
+		s = s[1:]
+	}
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewSQLValue for JSON columns.
+func (set *threadUnsafeSet) Value() (driver.Value, error) {
+	return formatSQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. Array elements are scanned as
+// strings and NULL scans as an empty set. The set is left untouched on
+// error.
+func (set *threadUnsafeSet) Scan(src interface{}) error {
+	elems, err := scanSQL(src, nil)
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// Value implements driver.Valuer, storing the set as a Postgres array
+// literal. Use NewSQLValue for JSON columns.
+func (set *threadSafeSet) Value() (driver.Value, error) {
+	return formatSQLArray(set)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with a
+// JSON array or a Postgres array literal. Array elements are scanned as
+// strings and NULL scans as an empty set. The set is left untouched on
+// error.
+func (set *threadSafeSet) Scan(src interface{}) error {
+	elems, err := scanSQL(src, nil)
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
@@ -0,0 +1,180 @@
+package mapset
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is an in-memory database/sql driver holding a single
+// column per key. It understands "SET key" with one argument and
+// "GET key" returning one row.
+type fakeDriver struct {
+	sync.Mutex
+	columns map[string]driver.Value
+}
+
+var fakeDB = &fakeDriver{columns: map[string]driver.Value{}}
+
+func init() {
+	sql.Register("mapsetfake", fakeDB)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	fields := strings.Fields(query)
+	if len(fields) != 2 || (fields[0] != "SET" && fields[0] != "GET") {
+		return nil, errors.New("fake driver: unsupported query " + query)
+	}
+	return fakeStmt{d: c.d, op: fields[0], key: fields[1]}, nil
+}
+
+func (c fakeConn) Close() error              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("fake driver: no transactions") }
+
+type fakeStmt struct {
+	d       *fakeDriver
+	op, key string
+}
+
+func (s fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int {
+	if s.op == "SET" {
+		return 1
+	}
+	return 0
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.Lock()
+	s.d.columns[s.key] = args[0]
+	s.d.Unlock()
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.Lock()
+	defer s.d.Unlock()
+	return &fakeRows{value: s.d.columns[s.key]}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("mapsetfake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func storeAndLoad(t *testing.T, db *sql.DB, key string, in interface{}, out interface{}) {
+	if _, err := db.Exec("SET "+key, in); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow("GET " + key).Scan(out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_SQLArrayRoundTrip(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	for _, s := range []Set{NewSet(), NewThreadUnsafeSet()} {
+		s.Add("plain")
+		s.Add(`with "quotes"`)
+		s.Add(`back\slash`)
+		s.Add("a,b")
+		s.Add("{braces}")
+		s.Add("NULL")
+
+		decoded := s.Clone()
+		decoded.Clear()
+		decoded.Add("stale")
+		storeAndLoad(t, db, "tags", s, decoded)
+		assertEqual(s, decoded, t)
+	}
+
+	fakeDB.Lock()
+	stored := fakeDB.columns["tags"]
+	fakeDB.Unlock()
+	if stored != `{"NULL","a,b","back\\slash","plain","with \"quotes\"","{braces}"}` {
+		t.Errorf("unexpected array literal %v", stored)
+	}
+}
+
+func Test_SQLJSONRoundTrip(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	s := NewSet("a", "b")
+	decoded := NewSet()
+	storeAndLoad(t, db, "json", NewSQLValue(s, SQLJSON), NewSQLValue(decoded, SQLJSON))
+	assertEqual(s, decoded, t)
+}
+
+func Test_SQLNull(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	nilSet := NewSQLValue(nil, SQLArray)
+	decoded := NewSet("stale")
+	storeAndLoad(t, db, "null", nilSet, decoded)
+	if decoded.Cardinality() != 0 {
+		t.Errorf("NULL should scan as an empty set, got %v", decoded)
+	}
+}
+
+func Test_SQLScanArrayLiteral(t *testing.T) {
+	s := NewSet()
+	if err := s.(sql.Scanner).Scan([]byte(`{a, "b c" ,NULL,"d\"e"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(NewSet("a", "b c", nil, `d"e`)) {
+		t.Errorf("unexpected set %v", s)
+	}
+
+	ids := NewSet()
+	value := NewSQLValue(ids, SQLArray)
+	value.Parse = ParseIntElement
+	if err := value.Scan("{1,2,3}"); err != nil {
+		t.Fatal(err)
+	}
+	if !ids.Equal(NewSet(1, 2, 3)) {
+		t.Errorf("unexpected ids %v", ids)
+	}
+
+	for _, src := range []interface{}{`{{1,2},{3,4}}`, `{"a}`, `{a"b}`, `{a,,b}`, `{a,}`, `{,a}`, `1,2`, `{a,b`, 42} {
+		s := NewSet("untouched")
+		if err := s.(sql.Scanner).Scan(src); err == nil {
+			t.Errorf("expected an error scanning %v", src)
+		}
+		if !s.Equal(NewSet("untouched")) {
+			t.Errorf("set was modified scanning %v: %v", src, s)
+		}
+	}
+}
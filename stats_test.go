@@ -0,0 +1,141 @@
+package mapset
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_Stats(t *testing.T) {
+	s := NewSet(1, 2)
+	st := EnableStats(s)
+	if EnableStats(s) != st {
+		t.Error("expected EnableStats to return the existing stats")
+	}
+
+	s.Add(3)
+	s.Add(3)
+	s.Contains(1)
+	s.Contains(4)
+	s.Contains(1, 4)
+	s.Remove(2)
+	s.Remove(2)
+	s.Pop()
+
+	want := StatsSnapshot{
+		Adds:           1,
+		Removes:        2,
+		Hits:           1,
+		Misses:         2,
+		MaxCardinality: 3,
+	}
+	got := st.Snapshot()
+	if got.LockWaits != 8 {
+		t.Errorf("expected 8 timed lock acquisitions, got %d", got.LockWaits)
+	}
+	got.LockWaits, got.LockWait, got.MaxLockWait = 0, 0, 0
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+
+	st.Reset()
+	if got := st.Snapshot(); got != (StatsSnapshot{}) {
+		t.Errorf("expected zero stats after Reset, got %+v", got)
+	}
+
+	DisableStats(s)
+	s.Add(10)
+	if got := st.Snapshot(); got.Adds != 0 {
+		t.Errorf("expected no adds recorded after DisableStats, got %d", got.Adds)
+	}
+}
+
+func Test_StatsBulk(t *testing.T) {
+	s, other := NewSet(1, 2, 3), NewSet(3, 4)
+	st, otherSt := EnableStats(s), EnableStats(other)
+
+	s.Each(func(interface{}) bool {
+		return false
+	})
+	s.Union(other)
+	s.Intersect(s)
+	s.Difference(other)
+	s.SymmetricDifference(other)
+	s.Clear()
+
+	got := st.Snapshot()
+	if got.Reads != 5 || got.Removes != 3 || got.LockWaits != 2 {
+		t.Errorf("expected 5 reads, 3 removes and 2 timed lock acquisitions, got %+v", got)
+	}
+	if got := otherSt.Snapshot(); got.Reads != 3 {
+		t.Errorf("expected 3 reads of the other set, got %+v", got)
+	}
+}
+
+func Test_StatsLockWait(t *testing.T) {
+	s := NewSet(1)
+	st := EnableStats(s)
+
+	held := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.Each(func(interface{}) bool {
+			close(held)
+			<-release
+			return true
+		})
+	}()
+	<-held
+
+	time.AfterFunc(20*time.Millisecond, func() { close(release) })
+	s.Add(2)
+	wg.Wait()
+
+	if got := st.Snapshot().MaxLockWait; got < 10*time.Millisecond {
+		t.Errorf("expected Add to wait for the held lock, got %v", got)
+	}
+}
+
+func Test_StatsExpvar(t *testing.T) {
+	s := NewSet()
+	st := EnableStats(s)
+	expvar.Publish("mapset_test_stats", st)
+	s.Add(1)
+	s.Contains(1)
+
+	var got StatsSnapshot
+	if err := json.Unmarshal([]byte(expvar.Get("mapset_test_stats").String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Adds != 1 || got.Hits != 1 || got.MaxCardinality != 1 {
+		t.Errorf("expected 1 add, 1 hit and a max cardinality of 1, got %+v", got)
+	}
+}
+
+func Test_StatsConcurrent(t *testing.T) {
+	s := NewSet()
+	st := EnableStats(s)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Add(g*100 + i)
+				s.Contains(g*100 + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	got := st.Snapshot()
+	if got.Adds != 800 || got.Hits != 800 || got.MaxCardinality != 800 {
+		t.Errorf("expected 800 adds and hits and a max cardinality of 800, got %+v", got)
+	}
+}
@@ -0,0 +1,34 @@
+package mapset
+
+import (
+	"testing"
+
+	"github.com/emarcey/golang-set/settest"
+)
+
+var suiteElems = []interface{}{1, 2, 3, "a", "b", "c", 4.5, true, OrderedPair{First: 1, Second: 2}}
+
+func Test_SuiteSafe(t *testing.T) {
+	settest.RunSuite(t, settest.Factory[interface{}, Set]{
+		New:   func() Set { return NewSet() },
+		Elems: suiteElems,
+	})
+}
+
+func Test_SuiteUnsafe(t *testing.T) {
+	settest.RunSuite(t, settest.Factory[interface{}, Set]{
+		New:   NewThreadUnsafeSet,
+		Elems: suiteElems,
+	})
+}
+
+func Test_SuiteManyElems(t *testing.T) {
+	elems := make([]interface{}, 100)
+	for i := range elems {
+		elems[i] = i
+	}
+	settest.RunSuite(t, settest.Factory[interface{}, Set]{
+		New:   NewThreadUnsafeSet,
+		Elems: elems,
+	})
+}
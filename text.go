@@ -0,0 +1,336 @@
+/*
+Open Source Initiative OSI - The MIT License (MIT):Licensing
+
+The MIT License (MIT)
+Copyright (c) 2013 Ralph Caraveo (deckarep@gmail.com)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of
+this software and associated documentation files (the "Software"), to deal in
+the Software without restriction, including without limitation the rights to
+use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies
+of the Software, and to permit persons to whom the Software is furnished to do
+so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package mapset
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TextFormat describes how a set is written to and read from a single
+// line of text, such as a command line flag or an environment variable.
+//
+// Elements are joined by Separator. Occurrences of the separator, of a
+// double quote or of a backslash inside an element are escaped with a
+// backslash, and an empty element is written as "" so that a set of
+// just the empty string is not read back as an empty set. When Quote
+// is set, string elements are written as Go quoted strings instead,
+// and quoted elements are unquoted when read.
+type TextFormat struct {
+	// Separator delimits elements. Defaults to ",".
+	Separator string
+
+	// Quote writes string elements using strconv.Quote.
+	Quote bool
+
+	// Parse converts each element read from text. Defaults to
+	// keeping elements as strings.
+	Parse func(string) (interface{}, error)
+}
+
+// DefaultTextFormat is the format used by MarshalText, UnmarshalText
+// and NewFlagValue.
+var DefaultTextFormat = TextFormat{Separator: ","}
+
+var errTextEscape = errors.New("mapset: text ends with an unfinished escape")
+var errTextQuote = errors.New("mapset: text has an unterminated quote")
+
+// Marshal renders the elements of s as text. Elements are sorted by
+// their text so that the output is stable.
+func (f TextFormat) Marshal(s Set) ([]byte, error) {
+	items := make([]string, 0, s.Cardinality())
+
+	var err error
+	s.Each(func(elem interface{}) bool {
+		var item string
+		if str, ok := elem.(string); ok && f.Quote {
+			item = strconv.Quote(str)
+		} else if item, err = formatTextElement(elem); err != nil {
+			return true
+		} else {
+			item = f.escape(item)
+		}
+		items = append(items, item)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(items)
+	return []byte(strings.Join(items, f.separator())), nil
+}
+
+// Unmarshal adds the elements read from text to s. Nothing is added if
+// any element fails to parse. Empty text holds no elements.
+func (f TextFormat) Unmarshal(text []byte, s Set) error {
+	elems, err := f.parse(string(text))
+	if err != nil {
+		return err
+	}
+	for _, elem := range elems {
+		s.Add(elem)
+	}
+	return nil
+}
+
+func (f TextFormat) separator() string {
+	if f.Separator == "" {
+		return ","
+	}
+	return f.Separator
+}
+
+func (f TextFormat) parse(text string) ([]interface{}, error) {
+	fields, err := f.split(text)
+	if err != nil {
+		return nil, err
+	}
+
+	elems := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		item, err := f.unescape(field)
+		if err != nil {
+			return nil, err
+		}
+
+		var elem interface{} = item
+		if f.Parse != nil {
+			if elem, err = f.Parse(item); err != nil {
+				return nil, err
+			}
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// split cuts text at every separator that is neither escaped nor, when
+// quoting, inside quotes. Escapes and quotes are kept in the fields.
+func (f TextFormat) split(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+
+	sep := f.separator()
+	var fields []string
+	var field strings.Builder
+	inQuote := false
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\':
+			if i+1 == len(text) {
+				return nil, errTextEscape
+			}
+			field.WriteString(text[i : i+2])
+			i += 2
+			continue
+		case f.Quote && c == '"':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(text[i:], sep):
+			fields = append(fields, field.String())
+			field.Reset()
+			i += len(sep)
+			continue
+		}
+		field.WriteByte(text[i])
+		i++
+	}
+	if inQuote {
+		return nil, errTextQuote
+	}
+	return append(fields, field.String()), nil
+}
+
+func (f TextFormat) escape(item string) string {
+	if item == "" {
+		return `""`
+	}
+	sep := f.separator()
+	if !strings.ContainsAny(item, `\"`) && !strings.Contains(item, sep) {
+		return item
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(item); {
+		if strings.HasPrefix(item[i:], sep) {
+			for j := 0; j < len(sep); j++ {
+				b.WriteByte('\\')
+				b.WriteByte(sep[j])
+			}
+			i += len(sep)
+			continue
+		}
+		if c := item[i]; c == '\\' || c == '"' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(item[i])
+		i++
+	}
+	return b.String()
+}
+
+func (f TextFormat) unescape(field string) (string, error) {
+	if f.Quote {
+		if quoted := strings.TrimSpace(field); strings.HasPrefix(quoted, `"`) {
+			return strconv.Unquote(quoted)
+		}
+	}
+	if field == `""` {
+		return "", nil
+	}
+	if !strings.Contains(field, `\`) {
+		return field, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' {
+			i++
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String(), nil
+}
+
+func formatTextElement(elem interface{}) (string, error) {
+	switch v := elem.(type) {
+	case string:
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		return string(b), err
+	default:
+		return fmt.Sprint(elem), nil
+	}
+}
+
+// ParseIntElement parses a base 10 int. It can be used as TextFormat.Parse.
+func ParseIntElement(s string) (interface{}, error) {
+	i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 0)
+	return int(i), err
+}
+
+// ParseFloatElement parses a float64. It can be used as TextFormat.Parse.
+func ParseFloatElement(s string) (interface{}, error) {
+	return strconv.ParseFloat(strings.TrimSpace(s), 64)
+}
+
+// ParseBoolElement parses a bool as accepted by strconv.ParseBool. It can
+// be used as TextFormat.Parse.
+func ParseBoolElement(s string) (interface{}, error) {
+	return strconv.ParseBool(strings.TrimSpace(s))
+}
+
+// ParseTimeElement parses an RFC 3339 time. It can be used as
+// TextFormat.Parse.
+func ParseTimeElement(s string) (interface{}, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(s))
+}
+
+// FlagValue adapts a Set to the flag.Value interface. Every occurrence
+// of the flag adds its elements to the set, so both -ids=1,2 and
+// -ids=1 -ids=2 collect the same elements.
+type FlagValue struct {
+	set    Set
+	Format TextFormat
+}
+
+// NewFlagValue returns a flag.Value that adds elements to s using
+// DefaultTextFormat.
+func NewFlagValue(s Set) *FlagValue {
+	return &FlagValue{set: s, Format: DefaultTextFormat}
+}
+
+// String renders the set as text, as required by flag.Value.
+func (v *FlagValue) String() string {
+	if v == nil || v.set == nil {
+		return ""
+	}
+	b, err := v.Format.Marshal(v.set)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Set adds the elements read from text to the set, as required by
+// flag.Value.
+func (v *FlagValue) Set(text string) error {
+	return v.Format.Unmarshal([]byte(text), v.set)
+}
+
+// MarshalText renders the set using DefaultTextFormat.
+func (set *threadUnsafeSet) MarshalText() ([]byte, error) {
+	return DefaultTextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultTextFormat. The set is left untouched on error.
+func (set *threadUnsafeSet) UnmarshalText(text []byte) error {
+	elems, err := DefaultTextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	decoded := newThreadUnsafeSet()
+	for _, elem := range elems {
+		decoded.Add(elem)
+	}
+
+	defer set.guardWrite().done()
+	*set = decoded
+	return nil
+}
+
+// MarshalText renders the set using DefaultTextFormat.
+func (set *threadSafeSet) MarshalText() ([]byte, error) {
+	return DefaultTextFormat.Marshal(set)
+}
+
+// UnmarshalText replaces the contents of the set with the elements read
+// from text using DefaultTextFormat. The set is left untouched on error.
+func (set *threadSafeSet) UnmarshalText(text []byte) error {
+	elems, err := DefaultTextFormat.parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	set.Lock()
+	set.s = newThreadUnsafeSet()
+	for _, elem := range elems {
+		set.s.Add(elem)
+	}
+	set.Unlock()
+	return nil
+}
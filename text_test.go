@@ -0,0 +1,122 @@
+package mapset
+
+import (
+	"encoding"
+	"flag"
+	"testing"
+	"time"
+)
+
+func Test_TextRoundTrip(t *testing.T) {
+	formats := []TextFormat{
+		DefaultTextFormat,
+		{Separator: ";"},
+		{Separator: ", "},
+		{Separator: ",", Quote: true},
+	}
+	sets := [][]interface{}{
+		{"plain", "with,comma", `back\slash`, `"quoted"`, `""`, "", "semi;colon", " padded ", "a, b"},
+		{""},
+	}
+
+	for _, format := range formats {
+		for _, elems := range sets {
+			s := NewSetFromSlice(elems)
+			text, err := format.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := NewSet()
+			if err := format.Unmarshal(text, decoded); err != nil {
+				t.Fatalf("%+v: %v", format, err)
+			}
+			if !s.Equal(decoded) {
+				t.Errorf("%+v: %q decoded as %v", format, text, decoded)
+			}
+		}
+	}
+}
+
+func Test_TextMarshalSorted(t *testing.T) {
+	text, err := NewThreadUnsafeSetFromSlice([]interface{}{"c", "a", "b,"}).(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != `a,b\,,c` {
+		t.Errorf("unexpected text %q", text)
+	}
+
+	text, err = (TextFormat{Quote: true}).Marshal(NewSet("b", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != `"b",1` {
+		t.Errorf("unexpected quoted text %q", text)
+	}
+}
+
+func Test_TextParse(t *testing.T) {
+	s := NewSet()
+	if err := (TextFormat{Parse: ParseIntElement}).Unmarshal([]byte("1, 2,3"), s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(NewSet(1, 2, 3)) {
+		t.Errorf("unexpected ints %v", s)
+	}
+
+	s = NewSet()
+	if err := (TextFormat{Parse: ParseTimeElement}).Unmarshal([]byte("2020-01-02T03:04:05Z"), s); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Contains(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected times %v", s)
+	}
+
+	s = NewSet("untouched")
+	if err := (TextFormat{Parse: ParseIntElement}).Unmarshal([]byte("1,x"), s); err == nil {
+		t.Error("expected a parse error")
+	}
+	if !s.Equal(NewSet("untouched")) {
+		t.Errorf("set was modified on error: %v", s)
+	}
+
+	for _, text := range []string{`a\`, `"a`} {
+		if err := (TextFormat{Quote: true}).Unmarshal([]byte(text), NewSet()); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+}
+
+func Test_UnmarshalTextReplaces(t *testing.T) {
+	for _, s := range []Set{NewSet("old"), NewThreadUnsafeSetFromSlice([]interface{}{"old"})} {
+		if err := s.(encoding.TextUnmarshaler).UnmarshalText([]byte("a,b")); err != nil {
+			t.Fatal(err)
+		}
+		if s.Cardinality() != 2 || !s.Contains("a", "b") {
+			t.Errorf("unexpected set %v", s)
+		}
+	}
+}
+
+func Test_FlagValue(t *testing.T) {
+	ids := NewSet()
+	value := NewFlagValue(ids)
+	value.Format.Parse = ParseIntElement
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(value, "ids", "allowed ids")
+	if err := fs.Parse([]string{"-ids=1,2", "-ids", "3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ids.Equal(NewSet(1, 2, 3)) {
+		t.Errorf("unexpected ids %v", ids)
+	}
+	if value.String() != "1,2,3" {
+		t.Errorf("unexpected flag string %q", value.String())
+	}
+	if err := fs.Parse([]string{"-ids=x"}); err == nil {
+		t.Error("expected a parse error")
+	}
+}
@@ -25,10 +25,15 @@ SOFTWARE.
 
 package mapset
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
 
 type threadSafeSet struct {
-	s threadUnsafeSet
+	s     threadUnsafeSet
+	stats atomic.Pointer[SetStats]
 	sync.RWMutex
 }
 
@@ -36,39 +41,129 @@ func newThreadSafeSet() threadSafeSet {
 	return threadSafeSet{s: newThreadUnsafeSet()}
 }
 
+// readLockSets read-locks every distinct set in sets, in address order
+// so that concurrent multi-set operations cannot deadlock, and returns
+// the underlying maps along with a function releasing the locks. The
+// sets must share one implementation; thread-unsafe sets are guarded
+// for reading instead, which catches misuse under the mapsetdebug tag.
+func readLockSets(sets []Set) ([]threadUnsafeSet, func()) {
+	maps := make([]threadUnsafeSet, len(sets))
+	if _, ok := sets[0].(*threadSafeSet); !ok {
+		guards := make([]unsafeGuard, 0, len(sets))
+		release := func() {
+			for i := len(guards) - 1; i >= 0; i-- {
+				guards[i].done()
+			}
+		}
+		defer func() {
+			// A misuse panic must not leave the sets guarded so far
+			// marked as busy.
+			if len(guards) < len(sets) {
+				release()
+			}
+		}()
+		for i, s := range sets {
+			u := s.(*threadUnsafeSet)
+			guards = append(guards, u.guardRead())
+			maps[i] = *u
+		}
+		return maps, release
+	}
+
+	locks := make([]*threadSafeSet, 0, len(sets))
+	for _, s := range sets {
+		locks = append(locks, s.(*threadSafeSet))
+	}
+	distinct := lockOrder(locks)
+	for _, s := range distinct {
+		s.RLock()
+	}
+
+	for i, s := range sets {
+		maps[i] = s.(*threadSafeSet).s
+	}
+	return maps, func() {
+		for i := len(distinct) - 1; i >= 0; i-- {
+			distinct[i].RUnlock()
+		}
+	}
+}
+
+// lockOrder sorts sets by address and drops repeats, giving the order
+// in which operations on several thread-safe sets take their locks. It
+// reorders sets in place and returns a prefix of it. Operations lock a
+// handful of sets, so an insertion sort avoids the allocations of
+// sort.Slice.
+func lockOrder(sets []*threadSafeSet) []*threadSafeSet {
+	for i := 1; i < len(sets); i++ {
+		for j := i; j > 0 && uintptr(unsafe.Pointer(sets[j])) < uintptr(unsafe.Pointer(sets[j-1])); j-- {
+			sets[j], sets[j-1] = sets[j-1], sets[j]
+		}
+	}
+
+	distinct := sets[:0]
+	for i, s := range sets {
+		if i == 0 || s != sets[i-1] {
+			distinct = append(distinct, s)
+		}
+	}
+	return distinct
+}
+
+// rlockPair read-locks x and y in address order, locking a set passed
+// twice only once. Every operation on two sets locks through it: with
+// writer-preferring RWMutexes, a.Union(b) racing b.Union(a) could
+// otherwise deadlock behind pending writers, and a.Equal(a) would
+// read-lock the same set twice.
+func rlockPair(x, y *threadSafeSet) {
+	if uintptr(unsafe.Pointer(y)) < uintptr(unsafe.Pointer(x)) {
+		x, y = y, x
+	}
+	x.RLock()
+	if y != x {
+		y.RLock()
+	}
+}
+
+// runlockPair releases the locks taken by rlockPair.
+func runlockPair(x, y *threadSafeSet) {
+	x.RUnlock()
+	if y != x {
+		y.RUnlock()
+	}
+}
+
 func (set *threadSafeSet) Add(i interface{}) bool {
-	set.Lock()
+	st := set.lock()
 	ret := set.s.Add(i)
+	st.added(ret, len(set.s))
 	set.Unlock()
 	return ret
 }
 
 func (set *threadSafeSet) Contains(i ...interface{}) bool {
-	set.RLock()
+	st := set.rlock()
 	ret := set.s.Contains(i...)
 	set.RUnlock()
+	st.looked(ret)
 	return ret
 }
 
 func (set *threadSafeSet) IsSubset(other Set) bool {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
 
 	ret := set.s.IsSubset(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) IsProperSubset(other Set) bool {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	defer set.RUnlock()
-	o.RLock()
-	defer o.RUnlock()
+	rlockPair(set, o)
+	defer runlockPair(set, o)
 
 	return set.s.IsProperSubset(&o.s)
 }
@@ -84,64 +179,69 @@ func (set *threadSafeSet) IsProperSuperset(other Set) bool {
 func (set *threadSafeSet) Union(other Set) Set {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
+	readPair(set, o)
 
 	unsafeUnion := set.s.Union(&o.s).(*threadUnsafeSet)
 	ret := &threadSafeSet{s: *unsafeUnion}
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) Intersect(other Set) Set {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
+	readPair(set, o)
 
 	unsafeIntersection := set.s.Intersect(&o.s).(*threadUnsafeSet)
 	ret := &threadSafeSet{s: *unsafeIntersection}
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) Difference(other Set) Set {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
+	readPair(set, o)
 
 	unsafeDifference := set.s.Difference(&o.s).(*threadUnsafeSet)
 	ret := &threadSafeSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) SymmetricDifference(other Set) Set {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
+	readPair(set, o)
 
 	unsafeDifference := set.s.SymmetricDifference(&o.s).(*threadUnsafeSet)
 	ret := &threadSafeSet{s: *unsafeDifference}
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) Clear() {
+	st := set.lock()
+	st.cleared(len(set.s))
+	set.s.Clear()
+	set.Unlock()
+}
+
+func (set *threadSafeSet) Grow(n int) {
 	set.Lock()
-	set.s = newThreadUnsafeSet()
+	set.s.Grow(n)
 	set.Unlock()
 }
 
 func (set *threadSafeSet) Remove(i interface{}) {
-	set.Lock()
-	delete(set.s, i)
+	st := set.lock()
+	n := len(set.s)
+	delete(set.s, elementKey(i))
+	st.removed(len(set.s) < n)
 	set.Unlock()
 }
 
@@ -152,7 +252,7 @@ func (set *threadSafeSet) Cardinality() int {
 }
 
 func (set *threadSafeSet) Each(cb func(interface{}) bool) {
-	set.RLock()
+	set.rlock().read()
 	for elem := range set.s {
 		if cb(elem) {
 			break
@@ -199,12 +299,10 @@ func (set *threadSafeSet) Iterator() *Iterator {
 func (set *threadSafeSet) Equal(other Set) bool {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
 
 	ret := set.s.Equal(&o.s)
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
@@ -238,27 +336,28 @@ func (set *threadSafeSet) PowerSet() Set {
 }
 
 func (set *threadSafeSet) Pop() interface{} {
-	set.Lock()
+	st := set.lock()
 	defer set.Unlock()
-	return set.s.Pop()
+	n := len(set.s)
+	ret := set.s.Pop()
+	st.removed(len(set.s) < n)
+	return ret
 }
 
 func (set *threadSafeSet) CartesianProduct(other Set) Set {
 	o := other.(*threadSafeSet)
 
-	set.RLock()
-	o.RLock()
+	rlockPair(set, o)
 
 	unsafeCartProduct := set.s.CartesianProduct(&o.s).(*threadUnsafeSet)
 	ret := &threadSafeSet{s: *unsafeCartProduct}
-	set.RUnlock()
-	o.RUnlock()
+	runlockPair(set, o)
 	return ret
 }
 
 func (set *threadSafeSet) ToSlice() []interface{} {
-	keys := make([]interface{}, 0, set.Cardinality())
 	set.RLock()
+	keys := make([]interface{}, 0, len(set.s))
 	for elem := range set.s {
 		keys = append(keys, elem)
 	}
@@ -275,9 +374,9 @@ func (set *threadSafeSet) MarshalJSON() ([]byte, error) {
 }
 
 func (set *threadSafeSet) UnmarshalJSON(p []byte) error {
-	set.RLock()
+	set.Lock()
 	err := set.s.UnmarshalJSON(p)
-	set.RUnlock()
+	set.Unlock()
 
 	return err
 }
@@ -32,6 +32,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 const N = 1000
@@ -522,3 +523,76 @@ func Test_MarshalJSON(t *testing.T) {
 		t.Errorf("Expected no difference, got: %v", expected.Difference(actual))
 	}
 }
+
+func Test_BinaryOpsLockOrder(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				a.Union(b)
+				a.Intersect(b)
+				a.IsSubset(b)
+				a.Equal(b)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				b.Union(a)
+				b.Difference(a)
+				b.IsProperSuperset(a)
+				b.SymmetricDifference(a)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			// Writers keep the mutexes contended so that readers queue
+			// behind them.
+			for j := 0; j < 500; j++ {
+				a.Add(i*1000 + j)
+				b.Add(i*1000 + j)
+				a.Remove(i*1000 + j)
+			}
+		}(i)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("binary operations deadlocked")
+	}
+}
+
+func Test_BinaryOpsSelfArgument(t *testing.T) {
+	s := NewSet(1, 2, 3)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			s.Add(i)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if !s.Equal(s) || !s.IsSubset(s) || s.IsProperSubset(s) {
+			t.Fatal("unexpected comparison of a set with itself")
+		}
+		s.Union(s)
+		s.Intersect(s)
+		s.Difference(s)
+		s.SymmetricDifference(s)
+		s.CartesianProduct(NewSet())
+	}
+	<-done
+}
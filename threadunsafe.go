@@ -27,10 +27,11 @@ package mapset
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type threadUnsafeSet map[interface{}]struct{}
@@ -45,6 +46,21 @@ func newThreadUnsafeSet() threadUnsafeSet {
 	return make(threadUnsafeSet)
 }
 
+// newThreadUnsafeSetWithCapacity returns an empty set with room for n
+// elements before the map needs to grow.
+func newThreadUnsafeSetWithCapacity(n int) threadUnsafeSet {
+	return make(threadUnsafeSet, n)
+}
+
+// elementKey returns the map key of i. A time.Time is keyed without
+// its monotonic clock reading, which decoded times lack.
+func elementKey(i interface{}) interface{} {
+	if t, ok := i.(time.Time); ok {
+		return t.Round(0)
+	}
+	return i
+}
+
 // Equal says whether two 2-tuples contain the same values in the same order.
 func (pair *OrderedPair) Equal(other OrderedPair) bool {
 	if pair.First == other.First &&
@@ -56,6 +72,8 @@ func (pair *OrderedPair) Equal(other OrderedPair) bool {
 }
 
 func (set *threadUnsafeSet) Add(i interface{}) bool {
+	defer set.guardWrite().done()
+	i = elementKey(i)
 	_, found := (*set)[i]
 	if found {
 		return false //False if it existed already
@@ -66,8 +84,9 @@ func (set *threadUnsafeSet) Add(i interface{}) bool {
 }
 
 func (set *threadUnsafeSet) Contains(i ...interface{}) bool {
+	defer set.guardRead().done()
 	for _, val := range i {
-		if _, ok := (*set)[val]; !ok {
+		if _, ok := (*set)[elementKey(val)]; !ok {
 			return false
 		}
 	}
@@ -76,6 +95,7 @@ func (set *threadUnsafeSet) Contains(i ...interface{}) bool {
 
 func (set *threadUnsafeSet) IsSubset(other Set) bool {
 	_ = other.(*threadUnsafeSet)
+	defer set.guardRead().done()
 	if set.Cardinality() > other.Cardinality() {
 		return false
 	}
@@ -87,8 +107,10 @@ func (set *threadUnsafeSet) IsSubset(other Set) bool {
 	return true
 }
 
+// IsProperSubset relies on a subset of strictly smaller cardinality
+// being a proper subset, which needs a single pass.
 func (set *threadUnsafeSet) IsProperSubset(other Set) bool {
-	return set.IsSubset(other) && !set.Equal(other)
+	return set.Cardinality() < other.Cardinality() && set.IsSubset(other)
 }
 
 func (set *threadUnsafeSet) IsSuperset(other Set) bool {
@@ -96,13 +118,15 @@ func (set *threadUnsafeSet) IsSuperset(other Set) bool {
 }
 
 func (set *threadUnsafeSet) IsProperSuperset(other Set) bool {
-	return set.IsSuperset(other) && !set.Equal(other)
+	return other.IsProperSubset(set)
 }
 
 func (set *threadUnsafeSet) Union(other Set) Set {
 	o := other.(*threadUnsafeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	unionedSet := newThreadUnsafeSet()
+	unionedSet := newThreadUnsafeSetWithCapacity(len(*set) + len(*o))
 
 	for elem := range *set {
 		unionedSet.Add(elem)
@@ -110,11 +134,14 @@ func (set *threadUnsafeSet) Union(other Set) Set {
 	for elem := range *o {
 		unionedSet.Add(elem)
 	}
+	trackUnsafeSet(&unionedSet)
 	return &unionedSet
 }
 
 func (set *threadUnsafeSet) Intersect(other Set) Set {
 	o := other.(*threadUnsafeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
 	intersection := newThreadUnsafeSet()
 	// loop over smaller set
@@ -131,11 +158,13 @@ func (set *threadUnsafeSet) Intersect(other Set) Set {
 			}
 		}
 	}
+	trackUnsafeSet(&intersection)
 	return &intersection
 }
 
 func (set *threadUnsafeSet) Difference(other Set) Set {
 	_ = other.(*threadUnsafeSet)
+	defer set.guardRead().done()
 
 	difference := newThreadUnsafeSet()
 	for elem := range *set {
@@ -143,30 +172,63 @@ func (set *threadUnsafeSet) Difference(other Set) Set {
 			difference.Add(elem)
 		}
 	}
+	trackUnsafeSet(&difference)
 	return &difference
 }
 
 func (set *threadUnsafeSet) SymmetricDifference(other Set) Set {
-	_ = other.(*threadUnsafeSet)
+	o := other.(*threadUnsafeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 
-	aDiff := set.Difference(other)
-	bDiff := other.Difference(set)
-	return aDiff.Union(bDiff)
+	difference := newThreadUnsafeSet()
+	for elem := range *set {
+		if _, ok := (*o)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	for elem := range *o {
+		if _, ok := (*set)[elem]; !ok {
+			difference[elem] = struct{}{}
+		}
+	}
+	trackUnsafeSet(&difference)
+	return &difference
 }
 
+// Clear removes every element but keeps the memory of the map, so that
+// refilling the set does not allocate again.
 func (set *threadUnsafeSet) Clear() {
-	*set = newThreadUnsafeSet()
+	defer set.guardWrite().done()
+	clear(*set)
+}
+
+// Grow copies the set into a map with room for n more elements, so that
+// adding them does not rehash repeatedly.
+func (set *threadUnsafeSet) Grow(n int) {
+	defer set.guardWrite().done()
+	if n <= 0 {
+		return
+	}
+	grown := newThreadUnsafeSetWithCapacity(len(*set) + n)
+	for elem := range *set {
+		grown[elem] = struct{}{}
+	}
+	*set = grown
 }
 
 func (set *threadUnsafeSet) Remove(i interface{}) {
-	delete(*set, i)
+	defer set.guardWrite().done()
+	delete(*set, elementKey(i))
 }
 
 func (set *threadUnsafeSet) Cardinality() int {
+	defer set.guardRead().done()
 	return len(*set)
 }
 
 func (set *threadUnsafeSet) Each(cb func(interface{}) bool) {
+	defer set.guardIterate().done()
 	for elem := range *set {
 		if cb(elem) {
 			break
@@ -177,9 +239,11 @@ func (set *threadUnsafeSet) Each(cb func(interface{}) bool) {
 func (set *threadUnsafeSet) Iter() <-chan interface{} {
 	ch := make(chan interface{})
 	go func() {
+		g := set.guardRead()
 		for elem := range *set {
 			ch <- elem
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -190,6 +254,7 @@ func (set *threadUnsafeSet) Iterator() *Iterator {
 	iterator, ch, stopCh := newIterator()
 
 	go func() {
+		g := set.guardRead()
 	L:
 		for elem := range *set {
 			select {
@@ -198,6 +263,7 @@ func (set *threadUnsafeSet) Iterator() *Iterator {
 			case ch <- elem:
 			}
 		}
+		g.done()
 		close(ch)
 	}()
 
@@ -206,6 +272,7 @@ func (set *threadUnsafeSet) Iterator() *Iterator {
 
 func (set *threadUnsafeSet) Equal(other Set) bool {
 	_ = other.(*threadUnsafeSet)
+	defer set.guardRead().done()
 
 	if set.Cardinality() != other.Cardinality() {
 		return false
@@ -219,14 +286,17 @@ func (set *threadUnsafeSet) Equal(other Set) bool {
 }
 
 func (set *threadUnsafeSet) Clone() Set {
-	clonedSet := newThreadUnsafeSet()
+	defer set.guardRead().done()
+	clonedSet := newThreadUnsafeSetWithCapacity(len(*set))
 	for elem := range *set {
 		clonedSet.Add(elem)
 	}
+	trackUnsafeSet(&clonedSet)
 	return &clonedSet
 }
 
 func (set *threadUnsafeSet) String() string {
+	defer set.guardRead().done()
 	items := make([]string, 0, len(*set))
 
 	for elem := range *set {
@@ -241,6 +311,7 @@ func (pair OrderedPair) String() string {
 }
 
 func (set *threadUnsafeSet) Pop() interface{} {
+	defer set.guardWrite().done()
 	for item := range *set {
 		delete(*set, item)
 		return item
@@ -249,6 +320,7 @@ func (set *threadUnsafeSet) Pop() interface{} {
 }
 
 func (set *threadUnsafeSet) PowerSet() Set {
+	defer set.guardRead().done()
 	powSet := NewThreadUnsafeSet()
 	nullset := newThreadUnsafeSet()
 	powSet.Add(&nullset)
@@ -278,6 +350,8 @@ func (set *threadUnsafeSet) PowerSet() Set {
 
 func (set *threadUnsafeSet) CartesianProduct(other Set) Set {
 	o := other.(*threadUnsafeSet)
+	defer set.guardRead().done()
+	defer o.guardRead().done()
 	cartProduct := NewThreadUnsafeSet()
 
 	for i := range *set {
@@ -291,6 +365,7 @@ func (set *threadUnsafeSet) CartesianProduct(other Set) Set {
 }
 
 func (set *threadUnsafeSet) ToSlice() []interface{} {
+	defer set.guardRead().done()
 	keys := make([]interface{}, 0, set.Cardinality())
 	for elem := range *set {
 		keys = append(keys, elem)
@@ -301,39 +376,24 @@ func (set *threadUnsafeSet) ToSlice() []interface{} {
 
 // MarshalJSON creates a JSON array from the set, it marshals all elements
 func (set *threadUnsafeSet) MarshalJSON() ([]byte, error) {
-	items := make([]string, 0, set.Cardinality())
-
-	for elem := range *set {
-		b, err := json.Marshal(elem)
-		if err != nil {
-			return nil, err
-		}
-
-		items = append(items, string(b))
+	var buf bytes.Buffer
+	if err := EncodeJSON(context.Background(), &buf, set, JSONEncodeOptions{}); err != nil {
+		return nil, err
 	}
-
-	return []byte(fmt.Sprintf("[%s]", strings.Join(items, ","))), nil
+	return buf.Bytes(), nil
 }
 
 // UnmarshalJSON recreates a set from a JSON array, it only decodes
-// primitive types. Numbers are decoded as json.Number.
+// primitive types. Numbers are decoded as json.Number. Use
+// UnmarshalJSONWith for other number types or strict decoding.
 func (set *threadUnsafeSet) UnmarshalJSON(b []byte) error {
-	var i []interface{}
-
-	d := json.NewDecoder(bytes.NewReader(b))
-	d.UseNumber()
-	err := d.Decode(&i)
+	elems, err := JSONDecodeOptions{}.decodeArray(b)
 	if err != nil {
 		return err
 	}
 
-	for _, v := range i {
-		switch t := v.(type) {
-		case []interface{}, map[string]interface{}:
-			continue
-		default:
-			set.Add(t)
-		}
+	for _, elem := range elems {
+		set.Add(elem)
 	}
 
 	return nil